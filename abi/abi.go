@@ -0,0 +1,602 @@
+// Package abi implements Ethereum contract ABI parsing, encoding and decoding.
+//
+// Values are encoded from Go values:
+//
+//	uint<N>, int<N>     *big.Int, big.Int, Go integers, decimal or 0x prefixed hex string
+//	bool                bool
+//	address             hex string with 0x prefix, [20]byte or []byte
+//	string              string
+//	bytes, bytes<N>     []byte, [N]byte or hex string with 0x prefix
+//	T[], T[N]           slice or array of values of T
+//	tuple               slice of component values, map[string]interface{} by component names or struct
+//
+// Struct fields are matched to tuple components by `abi:"name"` tag or by name case-insensitively.
+// Decoded values have Go types returned by Type.GoType.
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/onrik/ethrpc"
+)
+
+var (
+	// ErrNotFound - returned when method, event or error is not found in ABI
+	ErrNotFound = errors.New("abi: not found")
+)
+
+// Method - contract function or constructor
+type Method struct {
+	// Name is unique name of method, overloaded methods get index suffix like "transfer0"
+	Name string
+	// RawName is name of method in ABI JSON
+	RawName         string
+	Inputs          Arguments
+	Outputs         Arguments
+	StateMutability string
+}
+
+// Signature returns method signature like "transfer(address,uint256)"
+func (method Method) Signature() string {
+	return method.RawName + "(" + method.Inputs.Types() + ")"
+}
+
+// ID returns method selector, first 4 bytes of signature hash
+func (method Method) ID() []byte {
+	return ethrpc.Keccak256([]byte(method.Signature()))[:4]
+}
+
+// IsConstant reports whether method doesn't modify state
+func (method Method) IsConstant() bool {
+	return method.StateMutability == "view" || method.StateMutability == "pure"
+}
+
+// Encode returns call data: method selector followed by encoded arguments
+func (method Method) Encode(args ...interface{}) ([]byte, error) {
+	encoded, err := method.Inputs.Encode(args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", method.Name, err)
+	}
+
+	return append(method.ID(), encoded...), nil
+}
+
+// DecodeInput returns arguments decoded from call data with method selector
+func (method Method) DecodeInput(data []byte) ([]interface{}, error) {
+	if len(data) < 4 || string(data[:4]) != string(method.ID()) {
+		return nil, fmt.Errorf("call data doesn't match selector of %s", method.Signature())
+	}
+
+	return method.Inputs.Decode(data[4:])
+}
+
+// DecodeOutput returns decoded return values
+func (method Method) DecodeOutput(data []byte) ([]interface{}, error) {
+	return method.Outputs.Decode(data)
+}
+
+// Event - contract event
+type Event struct {
+	// Name is unique name of event, overloaded events get index suffix like "Transfer0"
+	Name string
+	// RawName is name of event in ABI JSON
+	RawName   string
+	Inputs    Arguments
+	Anonymous bool
+}
+
+// Signature returns event signature like "Transfer(address,address,uint256)"
+func (event Event) Signature() string {
+	return event.RawName + "(" + event.Inputs.Types() + ")"
+}
+
+// ID returns event topic, hash of signature
+func (event Event) ID() []byte {
+	return ethrpc.Keccak256([]byte(event.Signature()))
+}
+
+// Error - contract custom error
+type Error struct {
+	// Name is unique name of error, overloaded errors get index suffix
+	Name string
+	// RawName is name of error in ABI JSON
+	RawName string
+	Inputs  Arguments
+}
+
+// Signature returns error signature like "InsufficientBalance(uint256,uint256)"
+func (e Error) Signature() string {
+	return e.RawName + "(" + e.Inputs.Types() + ")"
+}
+
+// ID returns error selector, first 4 bytes of signature hash
+func (e Error) ID() []byte {
+	return ethrpc.Keccak256([]byte(e.Signature()))[:4]
+}
+
+// Decode returns error arguments decoded from revert data with error selector
+func (e Error) Decode(data []byte) ([]interface{}, error) {
+	if len(data) < 4 || string(data[:4]) != string(e.ID()) {
+		return nil, fmt.Errorf("revert data doesn't match selector of %s", e.Signature())
+	}
+
+	return e.Inputs.Decode(data[4:])
+}
+
+var (
+	// RevertReason - standard Error(string) revert of require and revert statements
+	RevertReason = Error{Name: "Error", RawName: "Error", Inputs: Arguments{{Name: "reason", Type: Type{Kind: StringKind}}}}
+	// RevertPanic - standard Panic(uint256) revert of failed assertions, arithmetic errors and so on
+	RevertPanic = Error{Name: "Panic", RawName: "Panic", Inputs: Arguments{{Name: "code", Type: Type{Kind: UintKind, Size: 256}}}}
+)
+
+// Revert - decoded revert data
+type Revert struct {
+	Error Error
+	Args  []interface{}
+}
+
+// panicReasons - descriptions of Solidity panic codes
+var panicReasons = map[int64]string{
+	0x00: "generic panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "enum overflow",
+	0x22: "invalid encoded storage byte array",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to uninitialized function",
+}
+
+// String returns revert reason for Error(string), panic code with description for Panic(uint256)
+// and error name with arguments for other errors
+func (r Revert) String() string {
+	switch r.Error.Signature() {
+	case RevertReason.Signature():
+		return r.Args[0].(string)
+	case RevertPanic.Signature():
+		code := r.Args[0].(*big.Int)
+		description, ok := panicReasons[code.Int64()]
+		if !ok || !code.IsInt64() {
+			description = "unknown panic code"
+		}
+		return fmt.Sprintf("panic 0x%x (%s)", code, description)
+	}
+
+	args := make([]string, len(r.Args))
+	for i, arg := range r.Args {
+		if s, ok := arg.(string); ok && !strings.HasPrefix(s, "0x") {
+			args[i] = strconv.Quote(s)
+		} else {
+			args[i] = fmt.Sprintf("%v", arg)
+		}
+	}
+
+	return r.Error.RawName + "(" + strings.Join(args, ", ") + ")"
+}
+
+// DecodeRevert decodes standard Error(string) and Panic(uint256) revert data
+func DecodeRevert(data []byte) (*Revert, error) {
+	return ABI{}.DecodeRevert(data)
+}
+
+// Caller - client executing eth_call, implemented by *ethrpc.EthRPC
+type Caller interface {
+	EthCall(transaction ethrpc.T, tag string) (string, error)
+}
+
+// ABI - contract interface
+type ABI struct {
+	Constructor *Method
+	Methods     map[string]Method
+	Events      map[string]Event
+	Errors      map[string]Error
+	HasFallback bool
+	HasReceive  bool
+}
+
+type jsonEntry struct {
+	Type            string         `json:"type"`
+	Name            string         `json:"name"`
+	Inputs          []jsonArgument `json:"inputs"`
+	Outputs         []jsonArgument `json:"outputs"`
+	StateMutability string         `json:"stateMutability"`
+	Constant        bool           `json:"constant"`
+	Payable         bool           `json:"payable"`
+	Anonymous       bool           `json:"anonymous"`
+}
+
+// Parse parses contract ABI JSON
+func Parse(data []byte) (*ABI, error) {
+	abi := new(ABI)
+	if err := json.Unmarshal(data, abi); err != nil {
+		return nil, err
+	}
+
+	return abi, nil
+}
+
+// MustParse is like Parse but panics on error, it is intended for ABI constants
+func MustParse(data string) *ABI {
+	abi, err := Parse([]byte(data))
+	if err != nil {
+		panic(err)
+	}
+
+	return abi
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (abi *ABI) UnmarshalJSON(data []byte) error {
+	entries := []jsonEntry{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	result := ABI{
+		Methods: map[string]Method{},
+		Events:  map[string]Event{},
+		Errors:  map[string]Error{},
+	}
+	for _, entry := range entries {
+		inputs, err := toArguments(entry.Inputs)
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", entry.Type, entry.Name, err)
+		}
+		outputs, err := toArguments(entry.Outputs)
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", entry.Type, entry.Name, err)
+		}
+
+		switch entry.Type {
+		case "function", "":
+			name := uniqueName(entry.Name, func(name string) bool {
+				_, ok := result.Methods[name]
+				return ok
+			})
+			result.Methods[name] = Method{
+				Name:            name,
+				RawName:         entry.Name,
+				Inputs:          inputs,
+				Outputs:         outputs,
+				StateMutability: entry.stateMutability(),
+			}
+		case "constructor":
+			result.Constructor = &Method{Inputs: inputs, StateMutability: entry.stateMutability()}
+		case "event":
+			name := uniqueName(entry.Name, func(name string) bool {
+				_, ok := result.Events[name]
+				return ok
+			})
+			result.Events[name] = Event{Name: name, RawName: entry.Name, Inputs: inputs, Anonymous: entry.Anonymous}
+		case "error":
+			name := uniqueName(entry.Name, func(name string) bool {
+				_, ok := result.Errors[name]
+				return ok
+			})
+			result.Errors[name] = Error{Name: name, RawName: entry.Name, Inputs: inputs}
+		case "fallback":
+			result.HasFallback = true
+		case "receive":
+			result.HasReceive = true
+		default:
+			return fmt.Errorf("unknown ABI entry type %s", entry.Type)
+		}
+	}
+
+	*abi = result
+	return nil
+}
+
+func (entry jsonEntry) stateMutability() string {
+	if entry.StateMutability != "" {
+		return entry.StateMutability
+	}
+
+	// Solidity < 0.5 ABI
+	if entry.Constant {
+		return "view"
+	}
+	if entry.Payable {
+		return "payable"
+	}
+	return "nonpayable"
+}
+
+func toArguments(arguments []jsonArgument) (Arguments, error) {
+	result := make(Arguments, len(arguments))
+	for i, argument := range arguments {
+		a, err := argument.toArgument()
+		if err != nil {
+			return nil, err
+		}
+		result[i] = a
+	}
+
+	return result, nil
+}
+
+// uniqueName returns name with the lowest index suffix not used yet
+func uniqueName(name string, exists func(string) bool) string {
+	unique := name
+	for i := 0; exists(unique); i++ {
+		unique = name + strconv.Itoa(i)
+	}
+
+	return unique
+}
+
+// MethodByName returns method by unique name or signature like "transfer(address,uint256)"
+func (abi ABI) MethodByName(name string) (Method, error) {
+	if method, ok := abi.Methods[name]; ok {
+		return method, nil
+	}
+
+	signature := strings.ReplaceAll(name, " ", "")
+	for _, method := range abi.Methods {
+		if method.Signature() == signature {
+			return method, nil
+		}
+	}
+
+	return Method{}, fmt.Errorf("%w: method %s", ErrNotFound, name)
+}
+
+// MethodByID returns method by selector
+func (abi ABI) MethodByID(id []byte) (Method, error) {
+	for _, method := range abi.Methods {
+		if len(id) >= 4 && string(method.ID()) == string(id[:4]) {
+			return method, nil
+		}
+	}
+
+	return Method{}, fmt.Errorf("%w: method with selector %x", ErrNotFound, id)
+}
+
+// EventByName returns event by unique name or signature
+func (abi ABI) EventByName(name string) (Event, error) {
+	if event, ok := abi.Events[name]; ok {
+		return event, nil
+	}
+
+	signature := strings.ReplaceAll(name, " ", "")
+	for _, event := range abi.Events {
+		if event.Signature() == signature {
+			return event, nil
+		}
+	}
+
+	return Event{}, fmt.Errorf("%w: event %s", ErrNotFound, name)
+}
+
+// EventByID returns not anonymous event by topic
+func (abi ABI) EventByID(topic []byte) (Event, error) {
+	for _, event := range abi.Events {
+		if !event.Anonymous && string(event.ID()) == string(topic) {
+			return event, nil
+		}
+	}
+
+	return Event{}, fmt.Errorf("%w: event with topic %x", ErrNotFound, topic)
+}
+
+// ErrorByName returns custom error by unique name or signature
+func (abi ABI) ErrorByName(name string) (Error, error) {
+	if e, ok := abi.Errors[name]; ok {
+		return e, nil
+	}
+
+	signature := strings.ReplaceAll(name, " ", "")
+	for _, e := range abi.Errors {
+		if e.Signature() == signature {
+			return e, nil
+		}
+	}
+
+	return Error{}, fmt.Errorf("%w: error %s", ErrNotFound, name)
+}
+
+// ErrorByID returns custom error or one of the standard Error(string) and Panic(uint256) by selector
+func (abi ABI) ErrorByID(id []byte) (Error, error) {
+	if len(id) >= 4 {
+		for _, e := range abi.Errors {
+			if string(e.ID()) == string(id[:4]) {
+				return e, nil
+			}
+		}
+		for _, e := range []Error{RevertReason, RevertPanic} {
+			if string(e.ID()) == string(id[:4]) {
+				return e, nil
+			}
+		}
+	}
+
+	return Error{}, fmt.Errorf("%w: error with selector %x", ErrNotFound, id)
+}
+
+// Encode returns call data of method by name or signature
+func (abi ABI) Encode(name string, args ...interface{}) ([]byte, error) {
+	method, err := abi.MethodByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return method.Encode(args...)
+}
+
+// EncodeConstructor returns encoded constructor arguments to append to contract bytecode
+func (abi ABI) EncodeConstructor(args ...interface{}) ([]byte, error) {
+	if abi.Constructor == nil {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("%w: constructor", ErrNotFound)
+		}
+		return []byte{}, nil
+	}
+
+	return abi.Constructor.Inputs.Encode(args...)
+}
+
+// Transaction returns transaction calling method of contract with encoded call data
+func (abi ABI) Transaction(to string, name string, args ...interface{}) (ethrpc.T, error) {
+	data, err := abi.Encode(name, args...)
+	if err != nil {
+		return ethrpc.T{}, err
+	}
+
+	return ethrpc.T{To: to, Data: "0x" + hex.EncodeToString(data)}, nil
+}
+
+// DecodeInput returns method and arguments decoded from call data
+func (abi ABI) DecodeInput(data []byte) (Method, []interface{}, error) {
+	method, err := abi.MethodByID(data)
+	if err != nil {
+		return Method{}, nil, err
+	}
+
+	args, err := method.DecodeInput(data)
+	return method, args, err
+}
+
+// DecodeOutput returns decoded return values of method
+func (abi ABI) DecodeOutput(name string, data []byte) ([]interface{}, error) {
+	method, err := abi.MethodByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return method.DecodeOutput(data)
+}
+
+// DecodeOutputMap returns decoded return values of method by output names
+func (abi ABI) DecodeOutputMap(name string, data []byte) (map[string]interface{}, error) {
+	method, err := abi.MethodByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return method.Outputs.DecodeMap(data)
+}
+
+// DecodeRevert decodes revert data of custom error or standard Error(string) and Panic(uint256)
+func (abi ABI) DecodeRevert(data []byte) (*Revert, error) {
+	e, err := abi.ErrorByID(data)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := e.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Revert{Error: e, Args: args}, nil
+}
+
+// RevertError - error of reverted eth_call or eth_estimateGas with decoded revert data
+type RevertError struct {
+	ethrpc.EthError
+	// Revert is raw revert data returned by the node
+	Revert []byte
+	// Name is "Error" for revert reasons, "Panic" for panics and name of custom error if it's decoded, empty otherwise
+	Name string
+	// Args are decoded arguments of the error
+	Args []interface{}
+	// Reason is revert reason, description of panic code or custom error with arguments
+	Reason string
+	// PanicCode is code of Panic(uint256)
+	PanicCode *big.Int
+}
+
+func (err *RevertError) Error() string {
+	if err.Reason == "" {
+		return err.EthError.Error()
+	}
+
+	return "execution reverted: " + err.Reason
+}
+
+// Unwrap returns EthError of the revert
+func (err *RevertError) Unwrap() error {
+	return err.EthError
+}
+
+// AsRevertError returns revert error of ethrpc.EthError with revert data found in err chain,
+// standard Error(string) and Panic(uint256) are decoded
+func AsRevertError(err error) (*RevertError, bool) {
+	return ABI{}.AsRevertError(err)
+}
+
+// AsRevertError returns revert error of ethrpc.EthError with revert data found in err chain,
+// custom errors of the contract and standard Error(string) and Panic(uint256) are decoded
+func (abi ABI) AsRevertError(err error) (*RevertError, bool) {
+	revertErr := new(RevertError)
+	if errors.As(err, &revertErr) {
+		if revertErr.Name != "" {
+			return revertErr, true
+		}
+		err = revertErr.EthError
+	}
+
+	ethErr := ethrpc.EthError{}
+	if !errors.As(err, &ethErr) {
+		return nil, false
+	}
+	data, ok := ethErr.RevertData()
+	if !ok {
+		return nil, false
+	}
+
+	revertErr = &RevertError{EthError: ethErr, Revert: data}
+	if revert, err := abi.DecodeRevert(data); err == nil {
+		revertErr.Name = revert.Error.RawName
+		revertErr.Args = revert.Args
+		revertErr.Reason = revert.String()
+		if revert.Error.Signature() == RevertPanic.Signature() {
+			revertErr.PanicCode = revert.Args[0].(*big.Int)
+		}
+	}
+
+	return revertErr, true
+}
+
+// Call calls method of contract with eth_call at block number or tag ("latest", "pending" and so on) and returns decoded return values.
+// Reverts are returned as *RevertError with decoded custom errors of the contract.
+func (abi ABI) Call(caller Caller, to, block, name string, args ...interface{}) ([]interface{}, error) {
+	transaction, err := abi.Transaction(to, name, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := caller.EthCall(transaction, block)
+	if revertErr, ok := abi.AsRevertError(err); ok {
+		return nil, revertErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := DecodeHex(result)
+	if err != nil {
+		return nil, err
+	}
+
+	return abi.DecodeOutput(name, data)
+}
+
+// DecodeHex decodes hex string with optional 0x prefix like result of eth_call
+func DecodeHex(value string) ([]byte, error) {
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+	if len(value)%2 == 1 {
+		value = "0" + value
+	}
+
+	return hex.DecodeString(value)
+}
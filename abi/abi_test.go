@@ -0,0 +1,328 @@
+package abi
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/onrik/ethrpc"
+	"github.com/onrik/ethrpc/internal/rpctest"
+	"github.com/stretchr/testify/require"
+)
+
+const testABI = `[
+	{"type": "constructor", "inputs": [{"name": "owner", "type": "address"}], "stateMutability": "nonpayable"},
+	{"type": "function", "name": "balanceOf", "inputs": [{"name": "account", "type": "address"}], "outputs": [{"name": "", "type": "uint256"}], "stateMutability": "view"},
+	{"type": "function", "name": "transfer", "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}], "stateMutability": "nonpayable"},
+	{"type": "function", "name": "transfer", "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}, {"name": "data", "type": "bytes"}], "outputs": [], "stateMutability": "nonpayable"},
+	{"constant": true, "name": "symbol", "inputs": [], "outputs": [{"name": "", "type": "string"}], "payable": false, "type": "function"},
+	{
+		"type": "function",
+		"name": "positions",
+		"inputs": [{"name": "id", "type": "uint256"}],
+		"outputs": [
+			{
+				"name": "position",
+				"type": "tuple",
+				"internalType": "struct Position",
+				"components": [
+					{"name": "owner", "type": "address"},
+					{"name": "amounts", "type": "uint128[]"}
+				]
+			},
+			{"name": "active", "type": "bool"}
+		],
+		"stateMutability": "view"
+	},
+	{"type": "event", "name": "Transfer", "anonymous": false, "inputs": [
+		{"name": "from", "type": "address", "indexed": true},
+		{"name": "to", "type": "address", "indexed": true},
+		{"name": "value", "type": "uint256", "indexed": false}
+	]},
+	{"type": "error", "name": "InsufficientBalance", "inputs": [{"name": "available", "type": "uint256"}, {"name": "required", "type": "uint256"}]},
+	{"type": "fallback", "stateMutability": "payable"},
+	{"type": "receive", "stateMutability": "payable"}
+]`
+
+func TestParse(t *testing.T) {
+	abi, err := Parse([]byte(testABI))
+	require.Nil(t, err)
+
+	require.NotNil(t, abi.Constructor)
+	require.True(t, abi.HasFallback)
+	require.True(t, abi.HasReceive)
+	require.Len(t, abi.Methods, 5)
+
+	method, err := abi.MethodByName("transfer")
+	require.Nil(t, err)
+	require.Equal(t, "transfer(address,uint256)", method.Signature())
+	require.Equal(t, "a9059cbb", hex.EncodeToString(method.ID()))
+	require.False(t, method.IsConstant())
+
+	// Overloaded method gets index suffix and can be found by signature
+	method, err = abi.MethodByName("transfer0")
+	require.Nil(t, err)
+	require.Equal(t, "transfer", method.RawName)
+	require.Equal(t, "transfer(address,uint256,bytes)", method.Signature())
+	method, err = abi.MethodByName("transfer(address, uint256, bytes)")
+	require.Nil(t, err)
+	require.Equal(t, "transfer0", method.Name)
+
+	method, err = abi.MethodByName("symbol")
+	require.Nil(t, err)
+	require.True(t, method.IsConstant())
+
+	method, err = abi.MethodByName("positions")
+	require.Nil(t, err)
+	require.Equal(t, "(address,uint128[])", method.Outputs[0].Type.String())
+
+	method, err = abi.MethodByID(unhex("70a08231"))
+	require.Nil(t, err)
+	require.Equal(t, "balanceOf", method.Name)
+
+	event, err := abi.EventByName("Transfer")
+	require.Nil(t, err)
+	require.Equal(t, "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", hex.EncodeToString(event.ID()))
+	require.True(t, event.Inputs[0].Indexed)
+	require.Len(t, event.Inputs.NonIndexed(), 1)
+	event, err = abi.EventByID(event.ID())
+	require.Nil(t, err)
+	require.Equal(t, "Transfer", event.Name)
+	_, err = abi.EventByName("Transfer(address,address,uint256)")
+	require.Nil(t, err)
+
+	e, err := abi.ErrorByName("InsufficientBalance")
+	require.Nil(t, err)
+	require.Equal(t, "cf479181", hex.EncodeToString(e.ID()))
+	_, err = abi.ErrorByName("InsufficientBalance(uint256,uint256)")
+	require.Nil(t, err)
+
+	for _, err := range []error{
+		func() error { _, err := abi.MethodByName("approve"); return err }(),
+		func() error { _, err := abi.MethodByID([]byte{1}); return err }(),
+		func() error { _, err := abi.EventByName("Approval"); return err }(),
+		func() error { _, err := abi.EventByID([]byte{1}); return err }(),
+		func() error { _, err := abi.ErrorByName("Unauthorized"); return err }(),
+		func() error { _, err := abi.ErrorByID([]byte{1, 2, 3, 4}); return err }(),
+	} {
+		require.True(t, errors.Is(err, ErrNotFound))
+	}
+
+	for _, data := range []string{
+		`{}`,
+		`[{"type": "unknown"}]`,
+		`[{"type": "function", "name": "f", "inputs": [{"name": "a", "type": "uint7"}]}]`,
+		`[{"type": "function", "name": "f", "outputs": [{"name": "a", "type": "tuple"}]}]`,
+	} {
+		_, err := Parse([]byte(data))
+		require.NotNil(t, err, data)
+	}
+	require.Panics(t, func() { MustParse(`{}`) })
+}
+
+func TestEncodeDecodeMethod(t *testing.T) {
+	abi := MustParse(testABI)
+
+	data, err := abi.Encode("transfer", "0x3535353535353535353535353535353535353535", big.NewInt(100))
+	require.Nil(t, err)
+	require.Equal(t, "a9059cbb"+words("3535353535353535353535353535353535353535", "64"), hex.EncodeToString(data))
+
+	method, args, err := abi.DecodeInput(data)
+	require.Nil(t, err)
+	require.Equal(t, "transfer", method.Name)
+	require.Equal(t, []interface{}{"0x3535353535353535353535353535353535353535", big.NewInt(100)}, args)
+
+	_, err = abi.Encode("transfer", "0x3535353535353535353535353535353535353535")
+	require.NotNil(t, err)
+	_, err = abi.Encode("approve")
+	require.NotNil(t, err)
+	_, _, err = abi.DecodeInput([]byte{1, 2})
+	require.NotNil(t, err)
+	_, err = method.DecodeInput(unhex("70a08231"))
+	require.NotNil(t, err)
+
+	transaction, err := abi.Transaction("0x1111111111111111111111111111111111111111", "balanceOf", "0x3535353535353535353535353535353535353535")
+	require.Nil(t, err)
+	require.Equal(t, ethrpc.T{
+		To:   "0x1111111111111111111111111111111111111111",
+		Data: "0x70a08231" + words("3535353535353535353535353535353535353535"),
+	}, transaction)
+	_, err = abi.Transaction("0x1111111111111111111111111111111111111111", "balanceOf", "zz")
+	require.NotNil(t, err)
+
+	data, err = abi.EncodeConstructor("0x3535353535353535353535353535353535353535")
+	require.Nil(t, err)
+	require.Equal(t, words("3535353535353535353535353535353535353535"), hex.EncodeToString(data))
+	data, err = ABI{}.EncodeConstructor()
+	require.Nil(t, err)
+	require.Empty(t, data)
+	_, err = ABI{}.EncodeConstructor(1)
+	require.NotNil(t, err)
+
+	output := unhex(words("40", "1", "3535353535353535353535353535353535353535", "40", "2", "5", "6"))
+	values, err := abi.DecodeOutput("positions", output)
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{
+		[]interface{}{"0x3535353535353535353535353535353535353535", []*big.Int{big.NewInt(5), big.NewInt(6)}},
+		true,
+	}, values)
+
+	m, err := abi.DecodeOutputMap("positions", output)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"position": map[string]interface{}{
+			"owner":   "0x3535353535353535353535353535353535353535",
+			"amounts": []*big.Int{big.NewInt(5), big.NewInt(6)},
+		},
+		"active": true,
+	}, m)
+
+	_, err = abi.DecodeOutput("approve", output)
+	require.NotNil(t, err)
+	_, err = abi.DecodeOutputMap("approve", output)
+	require.NotNil(t, err)
+}
+
+func TestDecodeRevert(t *testing.T) {
+	// revert("Not enough Ether provided.")
+	data := unhex("08c379a0" + words("20", "1a", "4e6f7420656e6f7567682045746865722070726f76696465642e*"))
+	revert, err := DecodeRevert(data)
+	require.Nil(t, err)
+	require.Equal(t, "Error", revert.Error.Name)
+	require.Equal(t, "Not enough Ether provided.", revert.String())
+
+	// arithmetic overflow
+	revert, err = DecodeRevert(unhex("4e487b71" + words("11")))
+	require.Nil(t, err)
+	require.Equal(t, "Panic", revert.Error.Name)
+	require.Equal(t, []interface{}{big.NewInt(17)}, revert.Args)
+	require.Equal(t, "panic 0x11 (arithmetic underflow or overflow)", revert.String())
+
+	revert, err = DecodeRevert(unhex("4e487b71" + words("ff")))
+	require.Nil(t, err)
+	require.Equal(t, "panic 0xff (unknown panic code)", revert.String())
+
+	abi := MustParse(testABI)
+	revert, err = abi.DecodeRevert(unhex("cf479181" + words("1", "2")))
+	require.Nil(t, err)
+	require.Equal(t, "InsufficientBalance(1, 2)", revert.String())
+
+	_, err = DecodeRevert(unhex("cf479181" + words("1", "2")))
+	require.True(t, errors.Is(err, ErrNotFound))
+	_, err = DecodeRevert(unhex("08c379a0" + words("20")))
+	require.NotNil(t, err)
+
+	e := Error{RawName: "Unauthorized", Inputs: Arguments{{Name: "reason", Type: MustNewType("string")}, {Name: "account", Type: MustNewType("address")}}}
+	require.Equal(t, `Unauthorized("admin only", 0x3535353535353535353535353535353535353535)`, Revert{
+		Error: e,
+		Args:  []interface{}{"admin only", "0x3535353535353535353535353535353535353535"},
+	}.String())
+	_, err = e.Decode([]byte{1})
+	require.NotNil(t, err)
+}
+
+func TestCall(t *testing.T) {
+	abi := MustParse(testABI)
+	caller := &rpctest.Caller{Result: "0x" + words("de0b6b3a7640000")}
+
+	values, err := abi.Call(caller, "0x1111111111111111111111111111111111111111", "0x10", "balanceOf", "0x3535353535353535353535353535353535353535")
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{big.NewInt(1000000000000000000)}, values)
+	require.Equal(t, "0x1111111111111111111111111111111111111111", caller.Transaction.To)
+	require.Equal(t, "0x70a08231"+words("3535353535353535353535353535353535353535"), caller.Transaction.Data)
+	require.Equal(t, "0x10", caller.Block)
+
+	_, err = abi.Call(caller, "0x1111111111111111111111111111111111111111", "latest", "approve")
+	require.NotNil(t, err)
+
+	caller.Result = "0xzz"
+	_, err = abi.Call(caller, "0x1111111111111111111111111111111111111111", "latest", "symbol")
+	require.NotNil(t, err)
+
+	// Contract without code returns empty result
+	caller.Result = "0x"
+	_, err = abi.Call(caller, "0x1111111111111111111111111111111111111111", "latest", "symbol")
+	require.True(t, errors.Is(err, ErrShortData))
+
+	caller.Err = errors.New("error")
+	_, err = abi.Call(caller, "0x1111111111111111111111111111111111111111", "latest", "symbol")
+	require.Equal(t, caller.Err, err)
+
+	// Custom error of the contract
+	data, err := abi.Errors["InsufficientBalance"].Inputs.Encode(1, 2)
+	require.Nil(t, err)
+	revert := append(abi.Errors["InsufficientBalance"].ID(), data...)
+	caller.Err = ethrpc.EthError{Code: 3, Message: "execution reverted", Data: `"` + hexString(revert) + `"`}
+	_, err = abi.Call(caller, "0x1111111111111111111111111111111111111111", "latest", "symbol")
+	revertErr, ok := err.(*RevertError)
+	require.True(t, ok)
+	require.Equal(t, "InsufficientBalance", revertErr.Name)
+	require.Equal(t, []interface{}{big.NewInt(1), big.NewInt(2)}, revertErr.Args)
+	require.Equal(t, "execution reverted: InsufficientBalance(1, 2)", err.Error())
+}
+
+func TestAsRevertError(t *testing.T) {
+	// revert("Not enough Ether provided.")
+	reason := "0x08c379a0" + words("20", "1a", "4e6f7420656e6f7567682045746865722070726f76696465642e*")
+	ethErr := ethrpc.EthError{Code: 3, Message: "execution reverted", Data: `"` + reason + `"`}
+	revertErr, ok := AsRevertError(fmt.Errorf("call: %w", ethErr))
+	require.True(t, ok)
+	require.Equal(t, ethErr, revertErr.EthError)
+	require.Equal(t, "Error", revertErr.Name)
+	require.Equal(t, []interface{}{"Not enough Ether provided."}, revertErr.Args)
+	require.Equal(t, "execution reverted: Not enough Ether provided.", revertErr.Error())
+	require.Nil(t, revertErr.PanicCode)
+	require.True(t, errors.Is(revertErr, ethrpc.ErrExecutionReverted))
+
+	ethErr.Data = `"0x4e487b71` + words("11") + `"`
+	revertErr, ok = AsRevertError(ethErr)
+	require.True(t, ok)
+	require.Equal(t, "Panic", revertErr.Name)
+	require.Equal(t, big.NewInt(0x11), revertErr.PanicCode)
+	require.Equal(t, "panic 0x11 (arithmetic underflow or overflow)", revertErr.Reason)
+
+	// Custom error is decoded with contract ABI
+	ethErr.Data = `"0xcf479181` + words("1", "2") + `"`
+	revertErr, ok = AsRevertError(ethErr)
+	require.True(t, ok)
+	require.Equal(t, "", revertErr.Name)
+	require.Equal(t, "Error 3 (execution reverted)", revertErr.Error())
+	require.Equal(t, ethErr, errors.Unwrap(revertErr))
+
+	revertErr, ok = MustParse(testABI).AsRevertError(revertErr)
+	require.True(t, ok)
+	require.Equal(t, "InsufficientBalance", revertErr.Name)
+	require.Equal(t, "InsufficientBalance(1, 2)", revertErr.Reason)
+
+	// Decoded error is returned as is
+	decoded, ok := AsRevertError(revertErr)
+	require.True(t, ok)
+	require.Equal(t, revertErr, decoded)
+
+	ethErr.Data = `"0x08c379a0` + words("40") + `"`
+	revertErr, ok = AsRevertError(ethErr)
+	require.True(t, ok)
+	require.Equal(t, "", revertErr.Name)
+
+	// Errors without revert data
+	_, ok = AsRevertError(ethrpc.EthError{Code: -32000, Message: "out of gas"})
+	require.False(t, ok)
+	_, ok = AsRevertError(ethrpc.EthError{Code: -32000, Message: "error", Data: `"0x0102"`})
+	require.False(t, ok)
+	_, ok = AsRevertError(errors.New("error"))
+	require.False(t, ok)
+	_, ok = AsRevertError(nil)
+	require.False(t, ok)
+}
+
+func TestDecodeHex(t *testing.T) {
+	data, err := DecodeHex("0x102")
+	require.Nil(t, err)
+	require.Equal(t, []byte{1, 2}, data)
+
+	data, err = DecodeHex("")
+	require.Nil(t, err)
+	require.Empty(t, data)
+}
@@ -0,0 +1,163 @@
+package abi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Argument - named and typed parameter of function, event or error
+type Argument struct {
+	Name string
+	Type Type
+	// InternalType is Solidity type like "struct Pool.Position[]" or "contract IERC20" if present in ABI JSON
+	InternalType string
+	Indexed      bool
+}
+
+type jsonArgument struct {
+	Name         string         `json:"name"`
+	Type         string         `json:"type"`
+	InternalType string         `json:"internalType,omitempty"`
+	Components   []jsonArgument `json:"components,omitempty"`
+	Indexed      bool           `json:"indexed,omitempty"`
+}
+
+func (argument jsonArgument) toArgument() (Argument, error) {
+	components := make(Arguments, len(argument.Components))
+	for i, component := range argument.Components {
+		c, err := component.toArgument()
+		if err != nil {
+			return Argument{}, err
+		}
+		components[i] = c
+	}
+
+	t, err := NewType(argument.Type, components)
+	if err != nil {
+		return Argument{}, fmt.Errorf("argument %s: %w", argument.Name, err)
+	}
+
+	return Argument{Name: argument.Name, Type: t, InternalType: argument.InternalType, Indexed: argument.Indexed}, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (argument *Argument) UnmarshalJSON(data []byte) error {
+	proxy := jsonArgument{}
+	if err := json.Unmarshal(data, &proxy); err != nil {
+		return err
+	}
+
+	a, err := proxy.toArgument()
+	if err != nil {
+		return err
+	}
+
+	*argument = a
+	return nil
+}
+
+// Arguments - list of arguments encoded as tuple
+type Arguments []Argument
+
+// NewArguments returns unnamed arguments of the given types
+func NewArguments(types ...string) (Arguments, error) {
+	arguments := make(Arguments, len(types))
+	for i, typeName := range types {
+		t, err := NewType(typeName, nil)
+		if err != nil {
+			return nil, err
+		}
+		arguments[i] = Argument{Type: t}
+	}
+
+	return arguments, nil
+}
+
+// Types returns canonical type names of the arguments joined with comma
+func (arguments Arguments) Types() string {
+	tuple := Type{Kind: TupleKind, Components: arguments}.String()
+
+	return tuple[1 : len(tuple)-1]
+}
+
+// NonIndexed returns arguments that are not indexed
+func (arguments Arguments) NonIndexed() Arguments {
+	result := Arguments{}
+	for _, argument := range arguments {
+		if !argument.Indexed {
+			result = append(result, argument)
+		}
+	}
+
+	return result
+}
+
+// Encode returns ABI encoding of the values
+func (arguments Arguments) Encode(values ...interface{}) ([]byte, error) {
+	if len(values) != len(arguments) {
+		return nil, fmt.Errorf("invalid arguments number %d, expected %d", len(values), len(arguments))
+	}
+
+	return encodeTuple(arguments, values)
+}
+
+// Decode returns values decoded from ABI encoding, see Type.GoType for Go types of values
+func (arguments Arguments) Decode(data []byte) ([]interface{}, error) {
+	return decodeTuple(arguments, data)
+}
+
+// DecodeMap returns values decoded from ABI encoding by argument names.
+// Tuples are decoded to maps too, unnamed arguments have names like "arg0".
+func (arguments Arguments) DecodeMap(data []byte) (map[string]interface{}, error) {
+	values, err := arguments.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return arguments.toMap(values), nil
+}
+
+func (arguments Arguments) toMap(values []interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(arguments))
+	for i, argument := range arguments {
+		result[argument.name(i)] = toMapValue(argument.Type, values[i])
+	}
+
+	return result
+}
+
+func (argument Argument) name(index int) string {
+	if argument.Name == "" {
+		return "arg" + strconv.Itoa(index)
+	}
+
+	return argument.Name
+}
+
+func toMapValue(t Type, value interface{}) interface{} {
+	switch t.Kind {
+	case TupleKind:
+		return t.Components.toMap(value.([]interface{}))
+	case SliceKind, ArrayKind:
+		if !containsTuple(t) {
+			return value
+		}
+		items := toValues(value)
+		result := make([]interface{}, len(items))
+		for i, item := range items {
+			result[i] = toMapValue(*t.Elem, item)
+		}
+		return result
+	}
+
+	return value
+}
+
+func containsTuple(t Type) bool {
+	for t.Kind == SliceKind || t.Kind == ArrayKind {
+		t = *t.Elem
+	}
+
+	return t.Kind == TupleKind
+}
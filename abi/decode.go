@@ -0,0 +1,287 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+var (
+	// ErrShortData - returned when data is shorter than required by the types
+	ErrShortData = errors.New("abi: data is too short")
+	// ErrInvalidOffset - returned when offset or length of dynamic value points outside of data
+	ErrInvalidOffset = errors.New("abi: invalid offset or length")
+	// ErrNonCanonical - returned when value padding or range doesn't match the type
+	ErrNonCanonical = errors.New("abi: non-canonical value")
+	// ErrTooLarge - returned when offsets of dynamic values reuse data to decode values much larger than data
+	ErrTooLarge = errors.New("abi: decoded values are too large")
+)
+
+// decodeSizeFactor limits size of decoded values relative to data size.
+// Canonical encoding never exceeds it, while overlapping offsets could produce exponentially large values.
+const decodeSizeFactor = 4
+
+// decoder - tracks size of decoded values
+type decoder struct {
+	budget int
+}
+
+func decodeTuple(arguments Arguments, data []byte) ([]interface{}, error) {
+	d := &decoder{budget: decodeSizeFactor * (len(data) + 32)}
+
+	return d.decodeTuple(arguments, data)
+}
+
+// consume charges size of decoded value
+func (d *decoder) consume(size int) error {
+	d.budget -= size
+	if d.budget < 0 {
+		return ErrTooLarge
+	}
+
+	return nil
+}
+
+// decodeTuple decodes values of tuple starting at the beginning of data
+func (d *decoder) decodeTuple(arguments Arguments, data []byte) ([]interface{}, error) {
+	values := make([]interface{}, len(arguments))
+	offset := 0
+	for i, argument := range arguments {
+		var value interface{}
+		var err error
+		if argument.Type.IsDynamic() {
+			var position int
+			position, err = readSize(data, offset, len(data))
+			if err == nil {
+				value, err = d.decodeValue(argument.Type, data[position:])
+			}
+		} else {
+			if offset > len(data) {
+				return nil, ErrShortData
+			}
+			value, err = d.decodeValue(argument.Type, data[offset:])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %w", argument.name(i), err)
+		}
+
+		values[i] = value
+		offset += argument.Type.headSize()
+	}
+
+	return values, nil
+}
+
+func (d *decoder) decodeValue(t Type, data []byte) (interface{}, error) {
+	switch t.Kind {
+	case StringKind, BytesKind:
+		size, err := readSize(data, 0, len(data)-32)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.consume(32 + size); err != nil {
+			return nil, err
+		}
+		b := make([]byte, size)
+		copy(b, data[32:])
+		if t.Kind == StringKind {
+			return string(b), nil
+		}
+		return b, nil
+	case SliceKind:
+		size, err := readSize(data, 0, (len(data)-32)/t.Elem.headSize())
+		if err != nil {
+			return nil, err
+		}
+		if err := d.consume(32); err != nil {
+			return nil, err
+		}
+		return d.decodeSequence(t, size, data[32:])
+	case ArrayKind:
+		if t.Size > len(data)/t.Elem.headSize() {
+			return nil, ErrShortData
+		}
+		return d.decodeSequence(t, t.Size, data)
+	case TupleKind:
+		return d.decodeTuple(t.Components, data)
+	}
+
+	if len(data) < 32 {
+		return nil, ErrShortData
+	}
+	if err := d.consume(32); err != nil {
+		return nil, err
+	}
+	word := data[:32]
+
+	switch t.Kind {
+	case UintKind, IntKind:
+		i := new(big.Int).SetBytes(word)
+		if t.Kind == IntKind && word[0]&0x80 != 0 {
+			i.Sub(i, two256)
+		}
+		if _, err := encodeInteger(t, i); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrNonCanonical, err)
+		}
+
+		goType := t.GoType()
+		switch goType.Kind() {
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return reflect.ValueOf(i.Uint64()).Convert(goType).Interface(), nil
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return reflect.ValueOf(i.Int64()).Convert(goType).Interface(), nil
+		}
+		return i, nil
+	case BoolKind:
+		if !isZero(word[:31]) || word[31] > 1 {
+			return nil, fmt.Errorf("%w: invalid bool %x", ErrNonCanonical, word)
+		}
+		return word[31] == 1, nil
+	case AddressKind:
+		if !isZero(word[:12]) {
+			return nil, fmt.Errorf("%w: invalid address %x", ErrNonCanonical, word)
+		}
+		return fmt.Sprintf("0x%x", word[12:]), nil
+	case FixedBytesKind, FunctionKind:
+		if !isZero(word[t.Size:]) {
+			return nil, fmt.Errorf("%w: invalid %s %x", ErrNonCanonical, t, word)
+		}
+		array := reflect.New(t.GoType()).Elem()
+		reflect.Copy(array, reflect.ValueOf(word[:t.Size]))
+		return array.Interface(), nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// decodeSequence decodes size elements of slice or array into Go slice or array
+func (d *decoder) decodeSequence(t Type, size int, data []byte) (interface{}, error) {
+	arguments := make(Arguments, size)
+	for i := range arguments {
+		arguments[i] = Argument{Type: *t.Elem}
+	}
+
+	items, err := d.decodeTuple(arguments, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result reflect.Value
+	if t.Kind == SliceKind {
+		result = reflect.MakeSlice(t.GoType(), size, size)
+	} else {
+		result = reflect.New(t.GoType()).Elem()
+	}
+	for i, item := range items {
+		result.Index(i).Set(reflect.ValueOf(item))
+	}
+
+	return result.Interface(), nil
+}
+
+// readSize reads offset or length word at position, the value must not exceed limit
+func readSize(data []byte, position, limit int) (int, error) {
+	if position+32 > len(data) {
+		return 0, ErrShortData
+	}
+
+	word := data[position : position+32]
+	if !isZero(word[:24]) {
+		return 0, ErrInvalidOffset
+	}
+
+	size := new(big.Int).SetBytes(word[24:])
+	if !size.IsInt64() || size.Int64() > int64(limit) {
+		return 0, ErrInvalidOffset
+	}
+
+	return int(size.Int64()), nil
+}
+
+func isZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Assign copies decoded value to Go value pointed by dest.
+// Tuples are assigned to exported struct fields in order of components, slices and arrays element by element.
+func Assign(dest interface{}, value interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("invalid destination %T, not nil pointer expected", dest)
+	}
+
+	return assign(v.Elem(), reflect.ValueOf(value))
+}
+
+func assign(dest, value reflect.Value) error {
+	if value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return fmt.Errorf("cannot assign nil to %s", dest.Type())
+	}
+	if value.Type().AssignableTo(dest.Type()) {
+		dest.Set(value)
+		return nil
+	}
+
+	switch dest.Kind() {
+	case reflect.Struct:
+		items, ok := value.Interface().([]interface{})
+		if !ok {
+			break
+		}
+		fields := []reflect.Value{}
+		for i := 0; i < dest.NumField(); i++ {
+			if dest.Type().Field(i).PkgPath == "" {
+				fields = append(fields, dest.Field(i))
+			}
+		}
+		if len(fields) != len(items) {
+			return fmt.Errorf("cannot assign tuple of %d components to %s with %d fields", len(items), dest.Type(), len(fields))
+		}
+		for i, item := range items {
+			if err := assign(fields[i], reflect.ValueOf(item)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice:
+		if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+			break
+		}
+		slice := reflect.MakeSlice(dest.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			if err := assign(slice.Index(i), value.Index(i)); err != nil {
+				return err
+			}
+		}
+		dest.Set(slice)
+		return nil
+	case reflect.Array:
+		if (value.Kind() != reflect.Slice && value.Kind() != reflect.Array) || value.Len() != dest.Len() {
+			break
+		}
+		for i := 0; i < value.Len(); i++ {
+			if err := assign(dest.Index(i), value.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// named types like type Amount uint64
+	if value.Kind() == dest.Kind() && value.Type().ConvertibleTo(dest.Type()) {
+		dest.Set(value.Convert(dest.Type()))
+		return nil
+	}
+
+	return fmt.Errorf("cannot assign %s to %s", value.Type(), dest.Type())
+}
@@ -0,0 +1,168 @@
+package abi
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func unhex(s string) []byte {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func TestDecode(t *testing.T) {
+	arguments, err := NewArguments("uint8", "int16", "uint256", "int256", "bool", "address", "bytes2", "string", "bytes", "uint64[]", "bytes1[2]")
+	require.Nil(t, err)
+
+	data := unhex(words(
+		"ff", strings.Repeat("f", 60)+"fffe", "de0b6b3a7640000", strings.Repeat("f", 64), "1",
+		"3535353535353535353535353535353535353535", "abcd*", "180", "1c0", "1e0", "01*", "02*",
+		"3", "646f67*", "0", "2", "1", "2",
+	))
+	values, err := arguments.Decode(data)
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{
+		uint8(255),
+		int16(-2),
+		big.NewInt(1000000000000000000),
+		big.NewInt(-1),
+		true,
+		"0x3535353535353535353535353535353535353535",
+		[2]byte{0xab, 0xcd},
+		"dog",
+		[]byte{},
+		[]uint64{1, 2},
+		[2][1]byte{{1}, {2}},
+	}, values)
+}
+
+func TestDecodeMap(t *testing.T) {
+	tuple := Type{Kind: TupleKind, Components: Arguments{
+		{Name: "amount", Type: MustNewType("uint256")},
+		{Type: MustNewType("bool")},
+	}}
+	arguments := Arguments{
+		{Name: "pair", Type: tuple},
+		{Name: "pairs", Type: Type{Kind: SliceKind, Elem: &tuple}},
+		{Type: MustNewType("uint8[]")},
+	}
+
+	data, err := arguments.Encode(
+		[]interface{}{1, true},
+		[]interface{}{[]interface{}{2, false}},
+		[]uint8{3},
+	)
+	require.Nil(t, err)
+
+	values, err := arguments.DecodeMap(data)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"pair":  map[string]interface{}{"amount": big.NewInt(1), "arg1": true},
+		"pairs": []interface{}{map[string]interface{}{"amount": big.NewInt(2), "arg1": false}},
+		"arg2":  []uint8{3},
+	}, values)
+
+	_, err = arguments.DecodeMap(data[:32])
+	require.NotNil(t, err)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		typeName string
+		data     string
+		err      error
+	}{
+		{"uint256", "", ErrShortData},
+		{"uint256", "01", ErrShortData},
+		{"uint8", words("100"), ErrNonCanonical},
+		{"int8", words("80"), ErrNonCanonical},
+		{"int8", strings.Repeat("f", 62) + "7f", ErrNonCanonical},
+		{"bool", words("2"), ErrNonCanonical},
+		{"address", words("1" + strings.Repeat("0", 40)), ErrNonCanonical},
+		{"bytes1", words("0101*"), ErrNonCanonical},
+		{"bytes", words("20"), ErrShortData},
+		{"bytes", words("40"), ErrInvalidOffset},
+		{"bytes", words("20", "21", "0"), ErrInvalidOffset},
+		{"bytes", words("20", "1"+strings.Repeat("0", 48)), ErrInvalidOffset},
+		{"uint256[]", words("20", "2", "1"), ErrInvalidOffset},
+		{"uint256[3]", words("1", "2"), ErrShortData},
+		{"string[2]", words("20", "40"), ErrShortData},
+		{"(uint256,bool)", words("1"), ErrShortData},
+	}
+	for _, test := range tests {
+		arguments, err := NewArguments(test.typeName)
+		require.Nil(t, err)
+		_, err = arguments.Decode(unhex(test.data))
+		require.True(t, errors.Is(err, test.err), "%s %s: %v", test.typeName, test.data, err)
+	}
+}
+
+func TestDecodeTooLarge(t *testing.T) {
+	arguments, err := NewArguments("string[]")
+	require.Nil(t, err)
+
+	// All items point to the same string
+	size := 64
+	data := words("20", strconv.FormatInt(int64(size), 16))
+	for i := 0; i < size; i++ {
+		data += words(strconv.FormatInt(int64(size*32), 16))
+	}
+	data += words("800") + strings.Repeat("61", 0x800)
+
+	_, err = arguments.Decode(unhex(data))
+	require.True(t, errors.Is(err, ErrTooLarge))
+}
+
+func TestAssign(t *testing.T) {
+	type amount uint64
+	type position struct {
+		ID     *big.Int
+		Range  [2]int32
+		Owners []string
+		hidden bool
+	}
+
+	var p position
+	err := Assign(&p, []interface{}{big.NewInt(1), [2]int32{-1, 1}, []string{"0x35"}})
+	require.Nil(t, err)
+	require.Equal(t, position{ID: big.NewInt(1), Range: [2]int32{-1, 1}, Owners: []string{"0x35"}}, p)
+
+	var positions [][]position
+	err = Assign(&positions, [][]interface{}{{[]interface{}{big.NewInt(2), [2]int32{}, []string{}}}})
+	require.Nil(t, err)
+	require.Equal(t, [][]position{{{ID: big.NewInt(2), Owners: []string{}}}}, positions)
+
+	var a amount
+	require.Nil(t, Assign(&a, uint64(5)))
+	require.Equal(t, amount(5), a)
+
+	var i interface{}
+	require.Nil(t, Assign(&i, "value"))
+	require.Equal(t, "value", i)
+
+	for _, test := range []struct {
+		dest  interface{}
+		value interface{}
+	}{
+		{p, []interface{}{}},
+		{(*position)(nil), []interface{}{}},
+		{&p, []interface{}{big.NewInt(1)}},
+		{&p, []interface{}{1, [2]int32{}, []string{}}},
+		{&p, "value"},
+		{&positions, "value"},
+		{&[2]int32{}, []int32{1}},
+		{&a, int64(1)},
+		{&a, nil},
+	} {
+		require.NotNil(t, Assign(test.dest, test.value), test)
+	}
+}
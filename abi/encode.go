@@ -0,0 +1,300 @@
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+var (
+	two256 = new(big.Int).Lsh(big.NewInt(1), 256)
+)
+
+// encodeTuple returns encoding of values as tuple: heads of static values and offsets of dynamic ones followed by tails
+func encodeTuple(arguments Arguments, values []interface{}) ([]byte, error) {
+	if len(values) != len(arguments) {
+		return nil, fmt.Errorf("invalid tuple values number %d, expected %d", len(values), len(arguments))
+	}
+
+	headSize := 0
+	for _, argument := range arguments {
+		headSize += argument.Type.headSize()
+	}
+
+	head := make([]byte, 0, headSize)
+	tail := []byte{}
+	for i, argument := range arguments {
+		encoded, err := encodeValue(argument.Type, values[i])
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %w", argument.name(i), err)
+		}
+
+		if argument.Type.IsDynamic() {
+			head = append(head, encodeUint(uint64(headSize+len(tail)))...)
+			tail = append(tail, encoded...)
+		} else {
+			head = append(head, encoded...)
+		}
+	}
+
+	return append(head, tail...), nil
+}
+
+func encodeValue(t Type, value interface{}) ([]byte, error) {
+	switch t.Kind {
+	case UintKind, IntKind:
+		i, err := toBigInt(value)
+		if err != nil {
+			return nil, err
+		}
+		return encodeInteger(t, i)
+	case BoolKind:
+		b, ok := indirect(value).(bool)
+		if !ok {
+			return nil, fmt.Errorf("invalid bool value %v", value)
+		}
+		if b {
+			return encodeUint(1), nil
+		}
+		return encodeUint(0), nil
+	case AddressKind:
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != 20 {
+			return nil, fmt.Errorf("invalid address length %d", len(b))
+		}
+		return append(make([]byte, 12), b...), nil
+	case FixedBytesKind, FunctionKind:
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > t.Size {
+			return nil, fmt.Errorf("invalid %s value length %d", t, len(b))
+		}
+		return padRight(b), nil
+	case StringKind:
+		s, ok := indirect(value).(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid string value %v", value)
+		}
+		return append(encodeUint(uint64(len(s))), padRight([]byte(s))...), nil
+	case BytesKind:
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return append(encodeUint(uint64(len(b))), padRight(b)...), nil
+	case SliceKind, ArrayKind:
+		items := toValues(value)
+		if items == nil {
+			return nil, fmt.Errorf("invalid %s value %v", t, value)
+		}
+		if t.Kind == ArrayKind && len(items) != t.Size {
+			return nil, fmt.Errorf("invalid %s length %d", t, len(items))
+		}
+
+		arguments := make(Arguments, len(items))
+		for i := range arguments {
+			arguments[i] = Argument{Type: *t.Elem}
+		}
+		encoded, err := encodeTuple(arguments, items)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == SliceKind {
+			encoded = append(encodeUint(uint64(len(items))), encoded...)
+		}
+		return encoded, nil
+	case TupleKind:
+		items, err := tupleValues(t.Components, value)
+		if err != nil {
+			return nil, err
+		}
+		return encodeTuple(t.Components, items)
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+func encodeUint(i uint64) []byte {
+	return new(big.Int).SetUint64(i).FillBytes(make([]byte, 32))
+}
+
+func encodeInteger(t Type, i *big.Int) ([]byte, error) {
+	if t.Kind == UintKind {
+		if i.Sign() < 0 || i.BitLen() > t.Size {
+			return nil, fmt.Errorf("value %s overflows %s", i, t)
+		}
+		return i.FillBytes(make([]byte, 32)), nil
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+	if i.Cmp(limit) >= 0 || i.Cmp(new(big.Int).Neg(limit)) < 0 {
+		return nil, fmt.Errorf("value %s overflows %s", i, t)
+	}
+	// two's complement
+	if i.Sign() < 0 {
+		i = new(big.Int).Add(i, two256)
+	}
+	return i.FillBytes(make([]byte, 32)), nil
+}
+
+func padRight(b []byte) []byte {
+	size := (len(b) + 31) / 32 * 32
+	padded := make([]byte, size)
+	copy(padded, b)
+
+	return padded
+}
+
+func indirect(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr && !v.IsNil() && v.Type() != bigIntType {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+
+	return v.Interface()
+}
+
+func toBigInt(value interface{}) (*big.Int, error) {
+	switch v := indirect(value).(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, fmt.Errorf("invalid integer value %v", value)
+		}
+		return new(big.Int).Set(v), nil
+	case big.Int:
+		return new(big.Int).Set(&v), nil
+	case float64:
+		if v != float64(int64(v)) {
+			return nil, fmt.Errorf("invalid integer value %v", v)
+		}
+		return big.NewInt(int64(v)), nil
+	case json.Number:
+		return toBigInt(string(v))
+	case string:
+		i, ok := new(big.Int).SetString(v, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer value %s", v)
+		}
+		return i, nil
+	}
+
+	v := reflect.ValueOf(indirect(value))
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(v.Uint()), nil
+	}
+
+	return nil, fmt.Errorf("invalid integer value %v", value)
+}
+
+// toBytes converts []byte, [N]byte or hex string with 0x prefix to bytes
+func toBytes(value interface{}) ([]byte, error) {
+	switch v := indirect(value).(type) {
+	case []byte:
+		return v, nil
+	case string:
+		if !strings.HasPrefix(v, "0x") && !strings.HasPrefix(v, "0X") {
+			return nil, fmt.Errorf("invalid hex value %s", v)
+		}
+		return hex.DecodeString(v[2:])
+	}
+
+	v := reflect.ValueOf(indirect(value))
+	if v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return b, nil
+	}
+
+	return nil, fmt.Errorf("invalid bytes value %v", value)
+}
+
+// toValues returns items of slice or array, nil if value is not a slice or array
+func toValues(value interface{}) []interface{} {
+	if items, ok := value.([]interface{}); ok {
+		return items
+	}
+
+	v := reflect.ValueOf(indirect(value))
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil
+	}
+
+	items := make([]interface{}, v.Len())
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+
+	return items
+}
+
+// tupleValues returns values of tuple components from slice, map by component names or struct.
+// Struct fields are matched by `abi:"name"` tag or by name case-insensitively.
+func tupleValues(components Arguments, value interface{}) ([]interface{}, error) {
+	value = indirect(value)
+	if m, ok := value.(map[string]interface{}); ok {
+		items := make([]interface{}, len(components))
+		for i, component := range components {
+			item, ok := m[component.name(i)]
+			if !ok {
+				return nil, fmt.Errorf("missing value of tuple field %s", component.name(i))
+			}
+			items[i] = item
+		}
+		return items, nil
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Struct {
+		if items := toValues(value); items != nil {
+			return items, nil
+		}
+		return nil, fmt.Errorf("invalid tuple value %v", value)
+	}
+
+	items := make([]interface{}, len(components))
+	for i, component := range components {
+		field, ok := structField(v, component.name(i))
+		if !ok {
+			return nil, fmt.Errorf("missing field %s in %s", component.name(i), v.Type())
+		}
+		items[i] = field.Interface()
+	}
+
+	return items, nil
+}
+
+func structField(v reflect.Value, name string) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		if field.Tag.Get("abi") == name {
+			return v.Field(i), true
+		}
+	}
+
+	name = strings.ReplaceAll(name, "_", "")
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.PkgPath == "" && field.Tag.Get("abi") == "" && strings.EqualFold(field.Name, name) {
+			return v.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
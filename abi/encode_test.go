@@ -0,0 +1,168 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func words(values ...string) string {
+	result := ""
+	for _, value := range values {
+		if len(value) < 64 && !strings.HasSuffix(value, "*") {
+			value = strings.Repeat("0", 64-len(value)) + value
+		}
+		value = strings.TrimSuffix(value, "*")
+		result += value + strings.Repeat("0", 64-len(value))
+	}
+
+	return result
+}
+
+func newMethod(t *testing.T, name string, types ...string) Method {
+	inputs, err := NewArguments(types...)
+	require.Nil(t, err)
+
+	return Method{Name: name, RawName: name, Inputs: inputs}
+}
+
+// Examples from Solidity ABI specification
+func TestEncodeSpecExamples(t *testing.T) {
+	tests := []struct {
+		method  Method
+		args    []interface{}
+		encoded string
+	}{
+		{
+			method:  newMethod(t, "baz", "uint32", "bool"),
+			args:    []interface{}{69, true},
+			encoded: "cdcd77c0" + words("45", "1"),
+		},
+		{
+			method:  newMethod(t, "bar", "bytes3[2]"),
+			args:    []interface{}{[2][]byte{[]byte("abc"), []byte("def")}},
+			encoded: "fce353f6" + words("616263*", "646566*"),
+		},
+		{
+			method:  newMethod(t, "sam", "bytes", "bool", "uint256[]"),
+			args:    []interface{}{[]byte("dave"), true, []int{1, 2, 3}},
+			encoded: "a5643bf2" + words("60", "1", "a0", "4", "64617665*", "3", "1", "2", "3"),
+		},
+		{
+			method:  newMethod(t, "f", "uint256", "uint32[]", "bytes10", "bytes"),
+			args:    []interface{}{0x123, []uint32{0x456, 0x789}, []byte("1234567890"), []byte("Hello, world!")},
+			encoded: "8be65246" + words("123", "80", "31323334353637383930*", "e0", "2", "456", "789", "d", "48656c6c6f2c20776f726c6421*"),
+		},
+		{
+			method: newMethod(t, "g", "uint256[][]", "string[]"),
+			args:   []interface{}{[][]*big.Int{{big.NewInt(1), big.NewInt(2)}, {big.NewInt(3)}}, []string{"one", "two", "three"}},
+			encoded: "2289b18c" + words(
+				"40", "140", "2", "40", "a0", "2", "1", "2", "1", "3",
+				"3", "60", "a0", "e0", "3", "6f6e65*", "3", "74776f*", "5", "7468726565*",
+			),
+		},
+	}
+
+	for _, test := range tests {
+		encoded, err := test.method.Encode(test.args...)
+		require.Nil(t, err, test.method.Signature())
+		require.Equal(t, test.encoded, hex.EncodeToString(encoded), test.method.Signature())
+
+		// Decoding returns the same values encoded
+		decoded, err := test.method.DecodeInput(encoded)
+		require.Nil(t, err)
+		again, err := test.method.Encode(decoded...)
+		require.Nil(t, err)
+		require.Equal(t, encoded, again)
+	}
+}
+
+func TestEncodeValues(t *testing.T) {
+	type pair struct {
+		Amount *big.Int
+		Owner  string `abi:"account"`
+	}
+
+	tests := []struct {
+		types   []string
+		args    []interface{}
+		encoded string
+	}{
+		{[]string{"int8"}, []interface{}{-1}, strings.Repeat("f", 64)},
+		{[]string{"int256"}, []interface{}{"-0x2"}, strings.Repeat("f", 63) + "e"},
+		{[]string{"uint256"}, []interface{}{"1000000000000000000"}, words("de0b6b3a7640000")},
+		{[]string{"uint64"}, []interface{}{uint64(1) << 63}, words("8000000000000000")},
+		{[]string{"uint16"}, []interface{}{float64(300)}, words("12c")},
+		{[]string{"address"}, []interface{}{"0x3535353535353535353535353535353535353535"}, words("3535353535353535353535353535353535353535")},
+		{[]string{"bytes4"}, []interface{}{[4]byte{1, 2, 3, 4}}, words("01020304*")},
+		{[]string{"bytes4"}, []interface{}{"0x0102"}, words("0102*")},
+		{[]string{"string"}, []interface{}{""}, words("20", "0")},
+		{[]string{"bool", "bool"}, []interface{}{false, &[]bool{true}[0]}, words("0", "1")},
+		{[]string{"(uint256,address)"}, []interface{}{[]interface{}{1, "0x3535353535353535353535353535353535353535"}}, words("1", "3535353535353535353535353535353535353535")},
+		{[]string{"(uint256,bytes)"}, []interface{}{[]interface{}{1, []byte{0xab}}}, words("20", "1", "40", "1", "ab*")},
+		{[]string{"uint8[2]", "uint8"}, []interface{}{[]uint8{1, 2}, uint8(3)}, words("1", "2", "3")},
+	}
+	for _, test := range tests {
+		arguments, err := NewArguments(test.types...)
+		require.Nil(t, err)
+		encoded, err := arguments.Encode(test.args...)
+		require.Nil(t, err, test.types)
+		require.Equal(t, test.encoded, hex.EncodeToString(encoded), test.types)
+	}
+
+	// Tuple from map and struct by component names
+	tuple := Arguments{{Name: "amount", Type: MustNewType("uint256")}, {Name: "account", Type: MustNewType("address")}}
+	arguments := Arguments{{Type: Type{Kind: TupleKind, Components: tuple}}}
+	expected := words("5", "3535353535353535353535353535353535353535")
+
+	encoded, err := arguments.Encode(map[string]interface{}{"amount": 5, "account": "0x3535353535353535353535353535353535353535"})
+	require.Nil(t, err)
+	require.Equal(t, expected, hex.EncodeToString(encoded))
+
+	encoded, err = arguments.Encode(&pair{Amount: big.NewInt(5), Owner: "0x3535353535353535353535353535353535353535"})
+	require.Nil(t, err)
+	require.Equal(t, expected, hex.EncodeToString(encoded))
+}
+
+func TestEncodeErrors(t *testing.T) {
+	tests := []struct {
+		typeName string
+		value    interface{}
+	}{
+		{"uint8", 256},
+		{"uint256", -1},
+		{"int8", 128},
+		{"int8", -129},
+		{"uint256", "abc"},
+		{"uint256", 1.5},
+		{"uint256", (*big.Int)(nil)},
+		{"uint256", nil},
+		{"bool", 1},
+		{"address", "0x35"},
+		{"address", "3535353535353535353535353535353535353535"},
+		{"bytes2", []byte{1, 2, 3}},
+		{"bytes", "zz"},
+		{"string", []byte{}},
+		{"uint256[2]", []int{1}},
+		{"uint256[]", 1},
+		{"(uint256,bool)", map[string]interface{}{"arg0": 1}},
+		{"(uint256,bool)", 1},
+		{"(uint256,bool)", struct{ Arg0 int }{1}},
+	}
+	for _, test := range tests {
+		arguments, err := NewArguments(test.typeName)
+		require.Nil(t, err)
+		_, err = arguments.Encode(test.value)
+		require.NotNil(t, err, "%s %v", test.typeName, test.value)
+	}
+
+	arguments, err := NewArguments("uint256")
+	require.Nil(t, err)
+	_, err = arguments.Encode()
+	require.NotNil(t, err)
+	_, err = NewArguments("uint7")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,261 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onrik/ethrpc"
+)
+
+var (
+	// ErrEventMismatch - returned when log topics don't match the event
+	ErrEventMismatch = errors.New("abi: log doesn't match event")
+)
+
+// EventLog - log decoded with event ABI.
+// Indexed values of dynamic types (string, bytes, arrays and tuples) are stored in topics as hashes and decoded as [32]byte.
+type EventLog struct {
+	Event Event
+	Log   ethrpc.Log
+	// Args are values in order of event inputs
+	Args []interface{}
+	// Values are values by input names, tuples are decoded to maps, unnamed inputs have names like "arg0"
+	Values map[string]interface{}
+}
+
+// DecodeLog decodes indexed values from log topics and other values from log data
+func (event Event) DecodeLog(log ethrpc.Log) (*EventLog, error) {
+	topics := log.Topics
+	if !event.Anonymous {
+		if len(topics) == 0 || !strings.EqualFold(topics[0], hexString(event.ID())) {
+			return nil, fmt.Errorf("%w: topic of %s not found", ErrEventMismatch, event.Signature())
+		}
+		topics = topics[1:]
+	}
+
+	indexed := len(event.Inputs) - len(event.Inputs.NonIndexed())
+	if len(topics) != indexed {
+		return nil, fmt.Errorf("%w: %s has %d indexed inputs, log has %d topics", ErrEventMismatch, event.Signature(), indexed, len(topics))
+	}
+
+	data, err := DecodeHex(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log data: %w", err)
+	}
+	values, err := event.Inputs.NonIndexed().Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", event.Signature(), err)
+	}
+
+	result := &EventLog{
+		Event:  event,
+		Log:    log,
+		Args:   make([]interface{}, len(event.Inputs)),
+		Values: make(map[string]interface{}, len(event.Inputs)),
+	}
+	for i, input := range event.Inputs {
+		if !input.Indexed {
+			result.Args[i] = values[0]
+			result.Values[input.name(i)] = toMapValue(input.Type, values[0])
+			values = values[1:]
+			continue
+		}
+
+		topic, err := DecodeHex(topics[0])
+		if err != nil || len(topic) != 32 {
+			return nil, fmt.Errorf("invalid topic %s", topics[0])
+		}
+		topics = topics[1:]
+
+		if isHashedTopic(input.Type) {
+			var hash [32]byte
+			copy(hash[:], topic)
+			result.Args[i] = hash
+			result.Values[input.name(i)] = hash
+			continue
+		}
+
+		value, err := Arguments{input}.Decode(topic)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", event.Signature(), err)
+		}
+		result.Args[i] = value[0]
+		result.Values[input.name(i)] = value[0]
+	}
+
+	return result, nil
+}
+
+// Topics returns topics filter matching the event and indexed values, nil values match any value.
+// Result can be used as FilterParams.Topics.
+func (event Event) Topics(values ...interface{}) ([][]string, error) {
+	alternatives := make([]interface{}, len(values))
+	for i, value := range values {
+		if value != nil {
+			alternatives[i] = []interface{}{value}
+		}
+	}
+
+	return event.FilterTopics(alternatives...)
+}
+
+// FilterTopics returns topics filter matching the event and any of the alternative values of indexed inputs.
+// Every value is a slice of alternatives, nil or empty slice matches any value.
+func (event Event) FilterTopics(values ...interface{}) ([][]string, error) {
+	indexed := Arguments{}
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(values) > len(indexed) {
+		return nil, fmt.Errorf("invalid indexed values number %d, %s has %d indexed inputs", len(values), event.Signature(), len(indexed))
+	}
+
+	topics := [][]string{}
+	if !event.Anonymous {
+		topics = append(topics, []string{hexString(event.ID())})
+	}
+	for i, value := range values {
+		alternatives := toValues(value)
+		if value != nil && alternatives == nil {
+			return nil, fmt.Errorf("%s: invalid alternatives %v", indexed[i].name(i), value)
+		}
+		if len(alternatives) == 0 {
+			topics = append(topics, nil)
+			continue
+		}
+
+		alternativeTopics := make([]string, len(alternatives))
+		for j, alternative := range alternatives {
+			topic, err := encodeTopic(indexed[i].Type, alternative)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", indexed[i].name(i), err)
+			}
+			alternativeTopics[j] = hexString(topic)
+		}
+		topics = append(topics, alternativeTopics)
+	}
+
+	// Trailing wildcards are not required
+	for len(topics) > 0 && topics[len(topics)-1] == nil {
+		topics = topics[:len(topics)-1]
+	}
+
+	return topics, nil
+}
+
+// DecodeLog decodes log of event found by the first topic
+func (abi ABI) DecodeLog(log ethrpc.Log) (*EventLog, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("%w: log without topics", ErrNotFound)
+	}
+
+	topic, err := DecodeHex(log.Topics[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid topic %s", log.Topics[0])
+	}
+	event, err := abi.EventByID(topic)
+	if err != nil {
+		return nil, err
+	}
+
+	return event.DecodeLog(log)
+}
+
+// isHashedTopic reports whether indexed value of type is stored in topic as hash
+func isHashedTopic(t Type) bool {
+	switch t.Kind {
+	case StringKind, BytesKind, SliceKind, ArrayKind, TupleKind:
+		return true
+	}
+
+	return false
+}
+
+// encodeTopic returns topic of indexed value: encoded value for value types, hash of contents for strings and bytes
+// and hash of in place encoding for arrays and tuples
+func encodeTopic(t Type, value interface{}) ([]byte, error) {
+	switch t.Kind {
+	case StringKind:
+		s, ok := indirect(value).(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid string value %v", value)
+		}
+		return ethrpc.Keccak256([]byte(s)), nil
+	case BytesKind:
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return ethrpc.Keccak256(b), nil
+	}
+
+	if !isHashedTopic(t) {
+		return encodeValue(t, value)
+	}
+
+	encoded, err := encodeInPlace(t, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return ethrpc.Keccak256(encoded), nil
+}
+
+// encodeInPlace returns encoding of indexed reference type value: elements are padded to 32 bytes and
+// concatenated without offsets and lengths, strings and bytes are padded without length
+func encodeInPlace(t Type, value interface{}) ([]byte, error) {
+	switch t.Kind {
+	case StringKind:
+		s, ok := indirect(value).(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid string value %v", value)
+		}
+		return padRight([]byte(s)), nil
+	case BytesKind:
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return padRight(b), nil
+	case SliceKind, ArrayKind:
+		items := toValues(value)
+		if items == nil {
+			return nil, fmt.Errorf("invalid %s value %v", t, value)
+		}
+		if t.Kind == ArrayKind && len(items) != t.Size {
+			return nil, fmt.Errorf("invalid %s length %d", t, len(items))
+		}
+		encoded := []byte{}
+		for _, item := range items {
+			itemEncoded, err := encodeInPlace(*t.Elem, item)
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, itemEncoded...)
+		}
+		return encoded, nil
+	case TupleKind:
+		items, err := tupleValues(t.Components, value)
+		if err != nil {
+			return nil, err
+		}
+		encoded := []byte{}
+		for i, component := range t.Components {
+			itemEncoded, err := encodeInPlace(component.Type, items[i])
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, itemEncoded...)
+		}
+		return encoded, nil
+	}
+
+	return encodeValue(t, value)
+}
+
+func hexString(data []byte) string {
+	return fmt.Sprintf("0x%x", data)
+}
@@ -0,0 +1,201 @@
+package abi
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/onrik/ethrpc"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	transferTopic  = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	erc20EventsABI = `[
+		{"type": "event", "name": "Transfer", "inputs": [
+			{"name": "from", "type": "address", "indexed": true},
+			{"name": "to", "type": "address", "indexed": true},
+			{"name": "value", "type": "uint256", "indexed": false}
+		]},
+		{"type": "event", "name": "Approval", "inputs": [
+			{"name": "owner", "type": "address", "indexed": true},
+			{"name": "spender", "type": "address", "indexed": true},
+			{"name": "value", "type": "uint256", "indexed": false}
+		]}
+	]`
+	erc721EventsABI = `[
+		{"type": "event", "name": "Transfer", "inputs": [
+			{"name": "from", "type": "address", "indexed": true},
+			{"name": "to", "type": "address", "indexed": true},
+			{"name": "tokenId", "type": "uint256", "indexed": true}
+		]}
+	]`
+)
+
+func topic(value string) string {
+	return "0x" + words(value)
+}
+
+func TestEventDecodeLog(t *testing.T) {
+	abi := MustParse(erc20EventsABI)
+	log := ethrpc.Log{
+		Address: "0x1111111111111111111111111111111111111111",
+		Topics: []string{
+			transferTopic,
+			topic("3535353535353535353535353535353535353535"),
+			topic("3636363636363636363636363636363636363636"),
+		},
+		Data: "0x" + words("de0b6b3a7640000"),
+	}
+
+	decoded, err := abi.DecodeLog(log)
+	require.Nil(t, err)
+	require.Equal(t, "Transfer", decoded.Event.Name)
+	require.Equal(t, log, decoded.Log)
+	require.Equal(t, []interface{}{
+		"0x3535353535353535353535353535353535353535",
+		"0x3636363636363636363636363636363636363636",
+		big.NewInt(1000000000000000000),
+	}, decoded.Args)
+	require.Equal(t, map[string]interface{}{
+		"from":  "0x3535353535353535353535353535353535353535",
+		"to":    "0x3636363636363636363636363636363636363636",
+		"value": big.NewInt(1000000000000000000),
+	}, decoded.Values)
+
+	// Topics number doesn't match
+	event := abi.Events["Transfer"]
+	_, err = event.DecodeLog(ethrpc.Log{Topics: log.Topics[:2], Data: log.Data})
+	require.True(t, errors.Is(err, ErrEventMismatch))
+	_, err = abi.Events["Approval"].DecodeLog(log)
+	require.True(t, errors.Is(err, ErrEventMismatch))
+
+	for _, log := range []ethrpc.Log{
+		{Topics: []string{transferTopic, topic("1"), topic("2")}, Data: "0x"},
+		{Topics: []string{transferTopic, topic("1"), topic("2")}, Data: "0xzz"},
+		{Topics: []string{transferTopic, "0x01", topic("2")}, Data: log.Data},
+		{Topics: []string{transferTopic, topic("1" + strings.Repeat("0", 40)), topic("2")}, Data: log.Data},
+	} {
+		_, err := event.DecodeLog(log)
+		require.NotNil(t, err)
+	}
+
+	_, err = abi.DecodeLog(ethrpc.Log{})
+	require.True(t, errors.Is(err, ErrNotFound))
+	_, err = abi.DecodeLog(ethrpc.Log{Topics: []string{"zz"}})
+	require.NotNil(t, err)
+	_, err = abi.DecodeLog(ethrpc.Log{Topics: []string{topic("1")}})
+	require.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestEventDecodeLogHashedAndAnonymous(t *testing.T) {
+	abi := MustParse(`[
+		{"type": "event", "name": "Named", "anonymous": true, "inputs": [
+			{"name": "name", "type": "string", "indexed": true},
+			{"name": "ids", "type": "uint256[]", "indexed": true},
+			{"name": "id", "type": "uint8", "indexed": true},
+			{"name": "info", "type": "tuple", "indexed": false, "components": [
+				{"name": "label", "type": "string"},
+				{"name": "value", "type": "int32"}
+			]}
+		]}
+	]`)
+	event := abi.Events["Named"]
+
+	topics, err := event.Topics("hello", []int{1, 2}, 3)
+	require.Nil(t, err)
+	require.Equal(t, [][]string{
+		{hexString(ethrpc.Keccak256([]byte("hello")))},
+		{hexString(ethrpc.Keccak256(unhex(words("1", "2"))))},
+		{topic("3")},
+	}, topics)
+
+	data, err := event.Inputs.NonIndexed().Encode([]interface{}{"label", -5})
+	require.Nil(t, err)
+
+	log := ethrpc.Log{Topics: []string{topics[0][0], topics[1][0], topics[2][0]}, Data: hexString(data)}
+	decoded, err := event.DecodeLog(log)
+	require.Nil(t, err)
+
+	var nameHash [32]byte
+	copy(nameHash[:], ethrpc.Keccak256([]byte("hello")))
+	require.Equal(t, nameHash, decoded.Args[0])
+	require.Equal(t, uint8(3), decoded.Args[2])
+	require.Equal(t, []interface{}{"label", int32(-5)}, decoded.Args[3])
+	require.Equal(t, map[string]interface{}{"label": "label", "value": int32(-5)}, decoded.Values["info"])
+
+	// Anonymous events are not found by topic
+	_, err = abi.DecodeLog(log)
+	require.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestEventTopics(t *testing.T) {
+	event := MustParse(erc20EventsABI).Events["Transfer"]
+
+	topics, err := event.Topics()
+	require.Nil(t, err)
+	require.Equal(t, [][]string{{transferTopic}}, topics)
+
+	topics, err = event.Topics(nil, "0x3636363636363636363636363636363636363636")
+	require.Nil(t, err)
+	require.Equal(t, [][]string{{transferTopic}, nil, {topic("3636363636363636363636363636363636363636")}}, topics)
+
+	topics, err = event.Topics("0x3535353535353535353535353535353535353535", nil)
+	require.Nil(t, err)
+	require.Equal(t, [][]string{{transferTopic}, {topic("3535353535353535353535353535353535353535")}}, topics)
+
+	_, err = event.Topics(nil, nil, nil)
+	require.NotNil(t, err)
+	_, err = event.Topics("0x35")
+	require.NotNil(t, err)
+
+	// Tuples and nested strings are padded in place
+	tuple := Type{Kind: TupleKind, Components: Arguments{{Name: "a", Type: MustNewType("string")}, {Name: "b", Type: MustNewType("bytes2[]")}}}
+	encoded, err := encodeTopic(tuple, []interface{}{"abc", [][]byte{{1, 2}, {3}}})
+	require.Nil(t, err)
+	require.Equal(t, ethrpc.Keccak256(unhex(words("616263*", "0102*", "03*"))), encoded)
+
+	encoded, err = encodeTopic(MustNewType("bytes"), []byte{1, 2})
+	require.Nil(t, err)
+	require.Equal(t, ethrpc.Keccak256([]byte{1, 2}), encoded)
+
+	for _, test := range []struct {
+		typeName string
+		value    interface{}
+	}{
+		{"string", 1},
+		{"bytes", 1},
+		{"string[]", []int{1}},
+		{"bytes[]", []int{1}},
+		{"uint8[2]", []int{1}},
+		{"uint8[]", 1},
+		{"(uint8)", 1},
+	} {
+		_, err := encodeTopic(MustNewType(test.typeName), test.value)
+		require.NotNil(t, err, test.typeName)
+	}
+}
+
+func TestEventFilterTopics(t *testing.T) {
+	event := MustParse(erc20EventsABI).Events["Transfer"]
+
+	topics, err := event.FilterTopics([]string{}, []string{"0x3535353535353535353535353535353535353535", "0x3636363636363636363636363636363636363636"})
+	require.Nil(t, err)
+	require.Equal(t, [][]string{
+		{transferTopic},
+		nil,
+		{topic("3535353535353535353535353535353535353535"), topic("3636363636363636363636363636363636363636")},
+	}, topics)
+
+	topics, err = event.FilterTopics([]interface{}{"0x3535353535353535353535353535353535353535"}, []string(nil))
+	require.Nil(t, err)
+	require.Equal(t, [][]string{{transferTopic}, {topic("3535353535353535353535353535353535353535")}}, topics)
+
+	_, err = event.FilterTopics("0x3535353535353535353535353535353535353535")
+	require.NotNil(t, err)
+	_, err = event.FilterTopics([]string{"0x35"})
+	require.NotNil(t, err)
+	_, err = event.FilterTopics(nil, nil, nil)
+	require.NotNil(t, err)
+}
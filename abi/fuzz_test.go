@@ -0,0 +1,94 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var fuzzArguments = func() Arguments {
+	arguments, err := NewArguments(
+		"uint256", "int64", "bool", "address", "bytes3", "string", "bytes",
+		"uint32[]", "int256[2]", "(uint8,string[])[]", "bytes[2]",
+	)
+	if err != nil {
+		panic(err)
+	}
+	return arguments
+}()
+
+func FuzzDecode(f *testing.F) {
+	seed, err := fuzzArguments.Encode(
+		1, -1, true, "0x3535353535353535353535353535353535353535", []byte("abc"), "string", []byte{1, 2},
+		[]uint32{1, 2}, []int{-1, 1}, []interface{}{[]interface{}{1, []string{"a", "b"}}}, [][]byte{{1}, {}},
+	)
+	require.Nil(f, err)
+	f.Add(seed)
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		values, err := fuzzArguments.Decode(data)
+		if err != nil {
+			return
+		}
+
+		// Decoded values are encoded in canonical form which decodes to the same values
+		encoded, err := fuzzArguments.Encode(values...)
+		require.Nil(t, err)
+		decoded, err := fuzzArguments.Decode(encoded)
+		require.Nil(t, err)
+		require.Equal(t, values, decoded)
+
+		again, err := fuzzArguments.Encode(decoded...)
+		require.Nil(t, err)
+		require.Equal(t, encoded, again)
+	})
+}
+
+func FuzzEncode(f *testing.F) {
+	f.Add(uint64(1), int64(-1), true, "string", []byte{1, 2, 3}, uint8(5))
+	f.Add(uint64(0), int64(0), false, "", []byte{}, uint8(0))
+
+	f.Fuzz(func(t *testing.T, u uint64, i int64, b bool, s string, data []byte, n uint8) {
+		address := make([]byte, 20)
+		copy(address, data)
+		var fixed [3]byte
+		copy(fixed[:], data)
+
+		strings := make([]string, n%4)
+		for j := range strings {
+			strings[j] = s
+		}
+		integers := make([]uint32, n%8)
+		for j := range integers {
+			integers[j] = uint32(u) + uint32(j)
+		}
+
+		bigInt := new(big.Int).Lsh(new(big.Int).SetUint64(u), uint(n%192))
+		values := []interface{}{
+			bigInt, i, b, address, fixed, s, data,
+			integers, []*big.Int{big.NewInt(i), new(big.Int).Neg(bigInt)},
+			[]interface{}{[]interface{}{n, strings}}, [2][]byte{data, {}},
+		}
+
+		encoded, err := fuzzArguments.Encode(values...)
+		require.Nil(t, err)
+
+		decoded, err := fuzzArguments.Decode(encoded)
+		require.Nil(t, err)
+		require.Equal(t, 0, bigInt.Cmp(decoded[0].(*big.Int)))
+		require.Equal(t, i, decoded[1])
+		require.Equal(t, b, decoded[2])
+		require.Equal(t, fixed, decoded[4])
+		require.Equal(t, s, decoded[5])
+		require.Equal(t, string(data), string(decoded[6].([]byte)))
+		require.Equal(t, integers, decoded[7])
+		require.Equal(t, n, decoded[9].([][]interface{})[0][0])
+		require.Equal(t, strings, decoded[9].([][]interface{})[0][1])
+
+		again, err := fuzzArguments.Encode(decoded...)
+		require.Nil(t, err)
+		require.Equal(t, encoded, again)
+	})
+}
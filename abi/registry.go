@@ -0,0 +1,147 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/onrik/ethrpc"
+)
+
+// LogsGetter - client fetching logs, implemented by *ethrpc.EthRPC
+type LogsGetter interface {
+	EthGetLogs(params ethrpc.FilterParams) ([]ethrpc.Log, error)
+}
+
+// Registry - events of many contracts by topic.
+// Events registered for addresses take precedence over events of any contract with the same topic.
+// Events sharing topic but differing in indexed inputs, like ERC-20 and ERC-721 Transfer, are matched by topics number.
+// Anonymous events have no topic and are not registered. Registry is safe for concurrent use.
+type Registry struct {
+	mutex     sync.RWMutex
+	events    map[string][]Event
+	contracts map[string]map[string][]Event
+}
+
+// NewRegistry returns registry with events of ABIs for any contract
+func NewRegistry(abis ...*ABI) *Registry {
+	registry := &Registry{
+		events:    map[string][]Event{},
+		contracts: map[string]map[string][]Event{},
+	}
+	for _, abi := range abis {
+		registry.Register(abi)
+	}
+
+	return registry
+}
+
+// Register adds events of ABI for the contract addresses or for any contract if addresses are not set
+func (registry *Registry) Register(abi *ABI, addresses ...string) {
+	for _, event := range abi.Events {
+		registry.RegisterEvent(event, addresses...)
+	}
+}
+
+// RegisterEvent adds event for the contract addresses or for any contract if addresses are not set
+func (registry *Registry) RegisterEvent(event Event, addresses ...string) {
+	if event.Anonymous {
+		return
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	topic := hexString(event.ID())
+	if len(addresses) == 0 {
+		registry.events[topic] = appendEvent(registry.events[topic], event)
+		return
+	}
+
+	for _, address := range addresses {
+		address = strings.ToLower(address)
+		if registry.contracts[address] == nil {
+			registry.contracts[address] = map[string][]Event{}
+		}
+		registry.contracts[address][topic] = appendEvent(registry.contracts[address][topic], event)
+	}
+}
+
+// appendEvent appends event unless event with the same signature and indexed inputs is present
+func appendEvent(events []Event, event Event) []Event {
+	for _, e := range events {
+		if e.Signature() == event.Signature() && indexedCount(e) == indexedCount(event) {
+			return events
+		}
+	}
+
+	return append(events, event)
+}
+
+func indexedCount(event Event) int {
+	return len(event.Inputs) - len(event.Inputs.NonIndexed())
+}
+
+// Events returns events registered for the topic and contract address
+func (registry *Registry) Events(address, topic string) []Event {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	topic = strings.ToLower(topic)
+	events := append([]Event{}, registry.contracts[strings.ToLower(address)][topic]...)
+
+	return append(events, registry.events[topic]...)
+}
+
+// DecodeLog decodes log with the registered event matching log address and topics
+func (registry *Registry) DecodeLog(log ethrpc.Log) (*EventLog, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("%w: log without topics", ErrNotFound)
+	}
+
+	var err error
+	for _, event := range registry.Events(log.Address, log.Topics[0]) {
+		decoded, decodeErr := event.DecodeLog(log)
+		if decodeErr == nil {
+			return decoded, nil
+		}
+		// Error of event matching topics is more relevant than mismatch of others
+		if err == nil || errors.Is(err, ErrEventMismatch) {
+			err = decodeErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("%w: event with topic %s", ErrNotFound, log.Topics[0])
+}
+
+// DecodeLogs decodes logs of registered events.
+// Logs of unknown events and logs with topics not matching registered events are skipped.
+func (registry *Registry) DecodeLogs(logs []ethrpc.Log) ([]EventLog, error) {
+	result := []EventLog{}
+	for _, log := range logs {
+		decoded, err := registry.DecodeLog(log)
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrEventMismatch) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("log %d of transaction %s: %w", log.LogIndex, log.TransactionHash, err)
+		}
+		result = append(result, *decoded)
+	}
+
+	return result, nil
+}
+
+// GetLogs returns decoded logs of registered events matching filter
+func (registry *Registry) GetLogs(client LogsGetter, params ethrpc.FilterParams) ([]EventLog, error) {
+	logs, err := client.EthGetLogs(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return registry.DecodeLogs(logs)
+}
@@ -0,0 +1,118 @@
+package abi
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/onrik/ethrpc"
+	"github.com/onrik/ethrpc/internal/rpctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	erc20 := MustParse(erc20EventsABI)
+	erc721 := MustParse(erc721EventsABI)
+	custom := MustParse(`[{"type": "event", "name": "Transfer", "inputs": [
+		{"name": "from", "type": "address", "indexed": false},
+		{"name": "to", "type": "address", "indexed": false},
+		{"name": "amount", "type": "uint256", "indexed": false}
+	]}]`)
+
+	registry := NewRegistry(erc20, erc721)
+	registry.Register(erc20)
+	registry.Register(custom, "0x2222222222222222222222222222222222222222")
+	registry.RegisterEvent(Event{Name: "Anonymous", RawName: "Anonymous", Anonymous: true})
+	require.Len(t, registry.Events("0x1111111111111111111111111111111111111111", transferTopic), 2)
+	require.Len(t, registry.Events("0x2222222222222222222222222222222222222222", transferTopic), 3)
+
+	logs := []ethrpc.Log{
+		// ERC-20 transfer
+		{
+			Address: "0x1111111111111111111111111111111111111111",
+			Topics:  []string{transferTopic, topic("35"), topic("36")},
+			Data:    "0x" + words("64"),
+		},
+		// ERC-721 transfer
+		{
+			Address: "0x3333333333333333333333333333333333333333",
+			Topics:  []string{transferTopic, topic("0"), topic("36"), topic("7")},
+			Data:    "0x",
+		},
+		// Unknown event
+		{
+			Address: "0x1111111111111111111111111111111111111111",
+			Topics:  []string{topic("1")},
+		},
+		// Transfer with topics not matching any registered event
+		{
+			Address: "0x1111111111111111111111111111111111111111",
+			Topics:  []string{transferTopic},
+			Data:    "0x" + words("35", "36", "64"),
+		},
+		// Event registered for the contract address
+		{
+			Address: "0x2222222222222222222222222222222222222222",
+			Topics:  []string{transferTopic},
+			Data:    "0x" + words("35", "36", "64"),
+		},
+		// Log without topics
+		{
+			Address: "0x1111111111111111111111111111111111111111",
+		},
+	}
+
+	decoded, err := registry.DecodeLogs(logs)
+	require.Nil(t, err)
+	require.Len(t, decoded, 3)
+	require.Equal(t, big.NewInt(100), decoded[0].Values["value"])
+	require.Equal(t, big.NewInt(7), decoded[1].Values["tokenId"])
+	require.Equal(t, big.NewInt(100), decoded[2].Values["amount"])
+	require.Equal(t, logs[4], decoded[2].Log)
+
+	_, err = registry.DecodeLog(logs[2])
+	require.True(t, errors.Is(err, ErrNotFound))
+	_, err = registry.DecodeLog(logs[3])
+	require.True(t, errors.Is(err, ErrEventMismatch))
+	_, err = registry.DecodeLog(logs[5])
+	require.True(t, errors.Is(err, ErrNotFound))
+
+	// Malformed log of known event
+	_, err = registry.DecodeLogs([]ethrpc.Log{{Topics: logs[0].Topics, Data: "0x01"}})
+	require.True(t, errors.Is(err, ErrShortData))
+
+	getter := &rpctest.LogsGetter{Logs: logs}
+	params := ethrpc.FilterParams{FromBlock: "0x1", Topics: [][]string{{transferTopic}}}
+	decoded, err = registry.GetLogs(getter, params)
+	require.Nil(t, err)
+	require.Len(t, decoded, 3)
+	require.Equal(t, params, getter.Params)
+
+	getter.Err = errors.New("error")
+	_, err = registry.GetLogs(getter, params)
+	require.Equal(t, getter.Err, err)
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	registry := NewRegistry()
+	log := ethrpc.Log{Topics: []string{transferTopic, topic("35"), topic("36")}, Data: "0x" + words("64")}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			registry.Register(MustParse(erc20EventsABI))
+		}()
+		go func() {
+			defer wg.Done()
+			registry.DecodeLog(log)
+		}()
+	}
+	wg.Wait()
+
+	decoded, err := registry.DecodeLog(log)
+	require.Nil(t, err)
+	require.Equal(t, "Transfer", decoded.Event.Name)
+}
@@ -0,0 +1,307 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Kind - kind of ABI type
+type Kind int
+
+// ABI type kinds
+const (
+	UintKind Kind = iota
+	IntKind
+	BoolKind
+	AddressKind
+	StringKind
+	BytesKind
+	FixedBytesKind
+	FunctionKind
+	SliceKind
+	ArrayKind
+	TupleKind
+)
+
+func (k Kind) String() string {
+	switch k {
+	case UintKind:
+		return "uint"
+	case IntKind:
+		return "int"
+	case BoolKind:
+		return "bool"
+	case AddressKind:
+		return "address"
+	case StringKind:
+		return "string"
+	case BytesKind:
+		return "bytes"
+	case FixedBytesKind:
+		return "fixed bytes"
+	case FunctionKind:
+		return "function"
+	case SliceKind:
+		return "slice"
+	case ArrayKind:
+		return "array"
+	case TupleKind:
+		return "tuple"
+	}
+
+	return fmt.Sprintf("Unknown(%d)", int(k))
+}
+
+var (
+	typeArrayRegexp   = regexp.MustCompile(`^(.+)\[(\d*)\]$`)
+	typeIntegerRegexp = regexp.MustCompile(`^(u?)int(\d*)$`)
+	typeBytesRegexp   = regexp.MustCompile(`^bytes(\d+)$`)
+
+	bigIntType    = reflect.TypeOf((*big.Int)(nil))
+	interfaceType = reflect.TypeOf([]interface{}{})
+)
+
+// Type - ABI type
+type Type struct {
+	Kind Kind
+	// Size is bits number of integers, bytes number of fixed bytes and length of arrays
+	Size int
+	// Elem is element type of slices and arrays
+	Elem *Type
+	// Components are tuple fields
+	Components Arguments
+}
+
+// NewType parses ABI type like "uint256", "bytes32[]" or "tuple[2]", components are used for tuples only
+func NewType(typeName string, components Arguments) (Type, error) {
+	if match := typeArrayRegexp.FindStringSubmatch(typeName); match != nil {
+		elem, err := NewType(match[1], components)
+		if err != nil {
+			return Type{}, err
+		}
+		if match[2] == "" {
+			return Type{Kind: SliceKind, Elem: &elem}, nil
+		}
+
+		size, err := strconv.Atoi(match[2])
+		if err != nil || size == 0 {
+			return Type{}, fmt.Errorf("invalid array length in type %s", typeName)
+		}
+		return Type{Kind: ArrayKind, Size: size, Elem: &elem}, nil
+	}
+
+	switch typeName {
+	case "bool":
+		return Type{Kind: BoolKind}, nil
+	case "address":
+		return Type{Kind: AddressKind}, nil
+	case "string":
+		return Type{Kind: StringKind}, nil
+	case "bytes":
+		return Type{Kind: BytesKind}, nil
+	case "function":
+		return Type{Kind: FunctionKind, Size: 24}, nil
+	case "tuple":
+		if len(components) == 0 {
+			return Type{}, fmt.Errorf("tuple without components")
+		}
+		return Type{Kind: TupleKind, Components: components}, nil
+	}
+
+	if strings.HasPrefix(typeName, "(") && strings.HasSuffix(typeName, ")") {
+		return parseTupleType(typeName[1 : len(typeName)-1])
+	}
+
+	if match := typeBytesRegexp.FindStringSubmatch(typeName); match != nil {
+		size, _ := strconv.Atoi(match[1])
+		if size < 1 || size > 32 || match[1][0] == '0' {
+			return Type{}, fmt.Errorf("invalid type %s", typeName)
+		}
+		return Type{Kind: FixedBytesKind, Size: size}, nil
+	}
+
+	if match := typeIntegerRegexp.FindStringSubmatch(typeName); match != nil {
+		size := 256
+		if match[2] != "" {
+			size, _ = strconv.Atoi(match[2])
+		}
+		if size < 8 || size > 256 || size%8 != 0 || (match[2] != "" && match[2][0] == '0') {
+			return Type{}, fmt.Errorf("invalid type %s", typeName)
+		}
+		if match[1] == "u" {
+			return Type{Kind: UintKind, Size: size}, nil
+		}
+		return Type{Kind: IntKind, Size: size}, nil
+	}
+
+	return Type{}, fmt.Errorf("unsupported type %s", typeName)
+}
+
+// MustNewType is like NewType but panics on error
+func MustNewType(typeName string) Type {
+	t, err := NewType(typeName, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// parseTupleType parses components of tuple type in canonical form like "uint256,(address,bytes)[]"
+func parseTupleType(value string) (Type, error) {
+	components := Arguments{}
+	depth := 0
+	start := 0
+	for i := 0; i <= len(value); i++ {
+		if i < len(value) {
+			switch value[i] {
+			case '(':
+				depth++
+				continue
+			case ')':
+				depth--
+				if depth < 0 {
+					return Type{}, fmt.Errorf("invalid tuple type (%s)", value)
+				}
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+
+		component, err := NewType(strings.TrimSpace(value[start:i]), nil)
+		if err != nil {
+			return Type{}, err
+		}
+		components = append(components, Argument{Type: component})
+		start = i + 1
+	}
+	if depth != 0 {
+		return Type{}, fmt.Errorf("invalid tuple type (%s)", value)
+	}
+
+	return Type{Kind: TupleKind, Components: components}, nil
+}
+
+// String returns canonical type name used in signatures
+func (t Type) String() string {
+	switch t.Kind {
+	case UintKind, IntKind:
+		return t.Kind.String() + strconv.Itoa(t.Size)
+	case FixedBytesKind:
+		return "bytes" + strconv.Itoa(t.Size)
+	case SliceKind:
+		return t.Elem.String() + "[]"
+	case ArrayKind:
+		return t.Elem.String() + "[" + strconv.Itoa(t.Size) + "]"
+	case TupleKind:
+		types := make([]string, len(t.Components))
+		for i, component := range t.Components {
+			types[i] = component.Type.String()
+		}
+		return "(" + strings.Join(types, ",") + ")"
+	}
+
+	return t.Kind.String()
+}
+
+// GoType returns Go type of decoded values:
+//
+//	uint8, uint16, uint32, uint64  uint8, uint16, uint32, uint64
+//	int8, int16, int32, int64      int8, int16, int32, int64
+//	other integers                 *big.Int
+//	bool                           bool
+//	address                        string, lower case hex with 0x prefix
+//	string                         string
+//	bytes                          []byte
+//	bytesN, function               [N]byte
+//	T[], T[N]                      slice and array of element Go type
+//	tuple                          []interface{}
+func (t Type) GoType() reflect.Type {
+	switch t.Kind {
+	case UintKind:
+		switch t.Size {
+		case 8:
+			return reflect.TypeOf(uint8(0))
+		case 16:
+			return reflect.TypeOf(uint16(0))
+		case 32:
+			return reflect.TypeOf(uint32(0))
+		case 64:
+			return reflect.TypeOf(uint64(0))
+		}
+		return bigIntType
+	case IntKind:
+		switch t.Size {
+		case 8:
+			return reflect.TypeOf(int8(0))
+		case 16:
+			return reflect.TypeOf(int16(0))
+		case 32:
+			return reflect.TypeOf(int32(0))
+		case 64:
+			return reflect.TypeOf(int64(0))
+		}
+		return bigIntType
+	case BoolKind:
+		return reflect.TypeOf(false)
+	case AddressKind, StringKind:
+		return reflect.TypeOf("")
+	case BytesKind:
+		return reflect.TypeOf([]byte{})
+	case FixedBytesKind, FunctionKind:
+		return reflect.ArrayOf(t.Size, reflect.TypeOf(byte(0)))
+	case SliceKind:
+		return reflect.SliceOf(t.Elem.GoType())
+	case ArrayKind:
+		return reflect.ArrayOf(t.Size, t.Elem.GoType())
+	}
+
+	return interfaceType
+}
+
+// IsDynamic reports whether type is encoded in the tail part with offset in the head
+func (t Type) IsDynamic() bool {
+	switch t.Kind {
+	case StringKind, BytesKind, SliceKind:
+		return true
+	case ArrayKind:
+		return t.Elem.IsDynamic()
+	case TupleKind:
+		for _, component := range t.Components {
+			if component.Type.IsDynamic() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// headSize returns size of the type in the head part of encoding
+func (t Type) headSize() int {
+	if t.IsDynamic() {
+		return 32
+	}
+
+	switch t.Kind {
+	case ArrayKind:
+		return t.Size * t.Elem.headSize()
+	case TupleKind:
+		size := 0
+		for _, component := range t.Components {
+			size += component.Type.headSize()
+		}
+		return size
+	}
+
+	return 32
+}
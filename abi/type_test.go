@@ -0,0 +1,83 @@
+package abi
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewType(t *testing.T) {
+	tests := map[string]string{
+		"uint":                           "uint256",
+		"int":                            "int256",
+		"uint8":                          "uint8",
+		"int24":                          "int24",
+		"bool":                           "bool",
+		"address":                        "address",
+		"string":                         "string",
+		"bytes":                          "bytes",
+		"bytes1":                         "bytes1",
+		"bytes32":                        "bytes32",
+		"function":                       "function",
+		"uint256[]":                      "uint256[]",
+		"bytes32[2][]":                   "bytes32[2][]",
+		"(uint256,(address,bytes)[])[2]": "(uint256,(address,bytes)[])[2]",
+		"(uint, bool)":                   "(uint256,bool)",
+	}
+	for typeName, canonical := range tests {
+		typ, err := NewType(typeName, nil)
+		require.Nil(t, err, typeName)
+		require.Equal(t, canonical, typ.String())
+	}
+
+	for _, typeName := range []string{"", "uint7", "uint264", "uint08", "int0", "bytes0", "bytes33", "bytes01", "fixed128x18", "uint256[0]", "uint256[x]", "tuple", "(uint256", "(uint256))", "(uint256,)"} {
+		_, err := NewType(typeName, nil)
+		require.NotNil(t, err, typeName)
+	}
+
+	tuple, err := NewType("tuple[]", Arguments{{Name: "a", Type: MustNewType("uint256")}, {Name: "b", Type: MustNewType("string[]")}})
+	require.Nil(t, err)
+	require.Equal(t, "(uint256,string[])[]", tuple.String())
+	require.Equal(t, SliceKind, tuple.Kind)
+	require.Equal(t, TupleKind, tuple.Elem.Kind)
+	require.Equal(t, "tuple", tuple.Elem.Kind.String())
+
+	require.Panics(t, func() { MustNewType("uint7") })
+}
+
+func TestTypeIsDynamic(t *testing.T) {
+	require.False(t, MustNewType("uint256").IsDynamic())
+	require.False(t, MustNewType("bytes32[3]").IsDynamic())
+	require.False(t, MustNewType("(uint256,address)").IsDynamic())
+	require.True(t, MustNewType("bytes").IsDynamic())
+	require.True(t, MustNewType("string[2]").IsDynamic())
+	require.True(t, MustNewType("uint256[]").IsDynamic())
+	require.True(t, MustNewType("(uint256,bytes)").IsDynamic())
+
+	require.Equal(t, 96, MustNewType("uint256[3]").headSize())
+	require.Equal(t, 128, MustNewType("(uint256,address[3])").headSize())
+	require.Equal(t, 32, MustNewType("(uint256,bytes)[3]").headSize())
+}
+
+func TestTypeGoType(t *testing.T) {
+	tests := map[string]reflect.Type{
+		"uint8":       reflect.TypeOf(uint8(0)),
+		"uint64":      reflect.TypeOf(uint64(0)),
+		"uint24":      reflect.TypeOf(new(big.Int)),
+		"int32":       reflect.TypeOf(int32(0)),
+		"int256":      reflect.TypeOf(new(big.Int)),
+		"address":     reflect.TypeOf(""),
+		"bytes":       reflect.TypeOf([]byte{}),
+		"bytes4":      reflect.TypeOf([4]byte{}),
+		"function":    reflect.TypeOf([24]byte{}),
+		"bool[]":      reflect.TypeOf([]bool{}),
+		"string[2]":   reflect.TypeOf([2]string{}),
+		"(uint8)[]":   reflect.TypeOf([][]interface{}{}),
+		"address[][]": reflect.TypeOf([][]string{}),
+	}
+	for typeName, goType := range tests {
+		require.Equal(t, goType, MustNewType(typeName).GoType(), typeName)
+	}
+}
@@ -0,0 +1,115 @@
+package ethrpc
+
+import (
+	"encoding/json"
+)
+
+// NodeInfo - information about the running node
+type NodeInfo struct {
+	ID         string                     `json:"id"`
+	Name       string                     `json:"name"`
+	Enode      string                     `json:"enode"`
+	ENR        string                     `json:"enr"`
+	IP         string                     `json:"ip"`
+	Ports      NodePorts                  `json:"ports"`
+	ListenAddr string                     `json:"listenAddr"`
+	Protocols  map[string]json.RawMessage `json:"protocols"`
+}
+
+// NodePorts - node discovery and listener ports
+type NodePorts struct {
+	Discovery int `json:"discovery"`
+	Listener  int `json:"listener"`
+}
+
+// PeerInfo - information about a connected peer
+type PeerInfo struct {
+	ID        string                     `json:"id"`
+	Name      string                     `json:"name"`
+	Enode     string                     `json:"enode"`
+	ENR       string                     `json:"enr"`
+	Caps      []string                   `json:"caps"`
+	Network   PeerNetwork                `json:"network"`
+	Protocols map[string]json.RawMessage `json:"protocols"`
+}
+
+// PeerNetwork - peer connection information
+type PeerNetwork struct {
+	LocalAddress  string `json:"localAddress"`
+	RemoteAddress string `json:"remoteAddress"`
+	Inbound       bool   `json:"inbound"`
+	Trusted       bool   `json:"trusted"`
+	Static        bool   `json:"static"`
+}
+
+// AdminNodeInfo returns information about the running node.
+func (rpc *EthRPC) AdminNodeInfo() (*NodeInfo, error) {
+	info := new(NodeInfo)
+
+	err := rpc.call("admin_nodeInfo", info)
+	return info, err
+}
+
+// AdminPeers returns information about connected peers.
+func (rpc *EthRPC) AdminPeers() ([]PeerInfo, error) {
+	peers := []PeerInfo{}
+
+	err := rpc.call("admin_peers", &peers)
+	return peers, err
+}
+
+// AdminAddPeer requests adding a new remote node to the list of tracked static nodes.
+func (rpc *EthRPC) AdminAddPeer(enode string) (bool, error) {
+	var added bool
+
+	err := rpc.call("admin_addPeer", &added, enode)
+	return added, err
+}
+
+// AdminRemovePeer disconnects from a remote node and removes it from the list of tracked static nodes.
+func (rpc *EthRPC) AdminRemovePeer(enode string) (bool, error) {
+	var removed bool
+
+	err := rpc.call("admin_removePeer", &removed, enode)
+	return removed, err
+}
+
+// AdminAddTrustedPeer allows a remote node to always connect, even if slots are full.
+func (rpc *EthRPC) AdminAddTrustedPeer(enode string) (bool, error) {
+	var added bool
+
+	err := rpc.call("admin_addTrustedPeer", &added, enode)
+	return added, err
+}
+
+// AdminRemoveTrustedPeer removes a remote node from the trusted peer set.
+func (rpc *EthRPC) AdminRemoveTrustedPeer(enode string) (bool, error) {
+	var removed bool
+
+	err := rpc.call("admin_removeTrustedPeer", &removed, enode)
+	return removed, err
+}
+
+// AdminDatadir returns the absolute path the running node uses to store its databases.
+func (rpc *EthRPC) AdminDatadir() (string, error) {
+	var datadir string
+
+	err := rpc.call("admin_datadir", &datadir)
+	return datadir, err
+}
+
+// AdminStartHTTP starts the HTTP RPC API server.
+func (rpc *EthRPC) AdminStartHTTP(host string, port int, cors, apis string) (bool, error) {
+	var started bool
+
+	err := rpc.call("admin_startHTTP", &started, host, port, cors, apis)
+	return started, err
+}
+
+// AdminStopHTTP stops the HTTP RPC API server.
+func (rpc *EthRPC) AdminStopHTTP() (bool, error) {
+	var stopped bool
+
+	err := rpc.call("admin_stopHTTP", &stopped)
+	return stopped, err
+}
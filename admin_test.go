@@ -0,0 +1,115 @@
+package ethrpc
+
+import (
+	"errors"
+	"fmt"
+)
+
+func (s *EthRPCTestSuite) TestAdminNodeInfo() {
+	s.registerResponseError(errors.New("error"))
+	_, err := s.rpc.AdminNodeInfo()
+	s.Require().NotNil(err)
+
+	result := `{
+		"enode": "enode://44826a5d6a55f88a18298bca4773fca5749cdc3a5c9f308aa7d810e9b31123f3e7c5fba0b1d70aac5308426f47df2a128a6747040a3815cc7dd7167d03be320d@[::]:30303",
+		"enr": "enr:-Jy4QHGHKbtU",
+		"id": "44826a5d6a55f88a18298bca4773fca5749cdc3a5c9f308aa7d810e9b31123f3e7c5fba0b1d70aac5308426f47df2a128a6747040a3815cc7dd7167d03be320d",
+		"ip": "::",
+		"listenAddr": "[::]:30303",
+		"name": "Geth/v1.13.5-stable/linux-amd64/go1.21.4",
+		"ports": {"discovery": 30303, "listener": 30303},
+		"protocols": {"eth": {"network": 1}, "snap": {}}
+	}`
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "admin_nodeInfo")
+		s.paramsEqual(body, "null")
+	})
+
+	info, err := s.rpc.AdminNodeInfo()
+	s.Require().Nil(err)
+	s.Require().Equal("Geth/v1.13.5-stable/linux-amd64/go1.21.4", info.Name)
+	s.Require().Equal("[::]:30303", info.ListenAddr)
+	s.Require().Equal(NodePorts{Discovery: 30303, Listener: 30303}, info.Ports)
+	s.Require().Len(info.Protocols, 2)
+	s.JSONEq(`{"network": 1}`, string(info.Protocols["eth"]))
+}
+
+func (s *EthRPCTestSuite) TestAdminPeers() {
+	result := `[{
+		"caps": ["eth/68", "snap/1"],
+		"enode": "enode://8d5f1ba7b1fa0f0bdcb0fae0ba6e9d4a0e0ac8b5e7a0fd6a1a0a9e8e0d0e0b4e@1.2.3.4:30303",
+		"id": "3b5ab5ed1e6ad72a2c8e4ba5b6c9c1d8b8f3c2f8b8e7b7f8b2b1c8c5b4b3c3b2",
+		"name": "Geth/v1.13.5-stable/linux-amd64/go1.21.4",
+		"network": {"inbound": true, "localAddress": "10.0.0.1:30303", "remoteAddress": "1.2.3.4:51234", "static": false, "trusted": true},
+		"protocols": {"eth": {"version": 68}}
+	}]`
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "admin_peers")
+		s.paramsEqual(body, "null")
+	})
+
+	peers, err := s.rpc.AdminPeers()
+	s.Require().Nil(err)
+	s.Require().Len(peers, 1)
+	s.Require().Equal([]string{"eth/68", "snap/1"}, peers[0].Caps)
+	s.Require().Equal(PeerNetwork{
+		LocalAddress:  "10.0.0.1:30303",
+		RemoteAddress: "1.2.3.4:51234",
+		Inbound:       true,
+		Trusted:       true,
+	}, peers[0].Network)
+}
+
+func (s *EthRPCTestSuite) TestAdminPeerManagement() {
+	enode := "enode://8d5f1ba7b1fa0f0bdcb0fae0ba6e9d4a0e0ac8b5e7a0fd6a1a0a9e8e0d0e0b4e@1.2.3.4:30303"
+	methods := map[string]func(string) (bool, error){
+		"admin_addPeer":           s.rpc.AdminAddPeer,
+		"admin_removePeer":        s.rpc.AdminRemovePeer,
+		"admin_addTrustedPeer":    s.rpc.AdminAddTrustedPeer,
+		"admin_removeTrustedPeer": s.rpc.AdminRemoveTrustedPeer,
+	}
+
+	for method, call := range methods {
+		s.registerResponse(`true`, func(body []byte) {
+			s.methodEqual(body, method)
+			s.paramsEqual(body, fmt.Sprintf(`["%s"]`, enode))
+		})
+
+		result, err := call(enode)
+		s.Require().Nil(err)
+		s.Require().True(result)
+	}
+}
+
+func (s *EthRPCTestSuite) TestAdminDatadir() {
+	s.registerResponse(`"/home/geth/.ethereum"`, func(body []byte) {
+		s.methodEqual(body, "admin_datadir")
+		s.paramsEqual(body, "null")
+	})
+
+	datadir, err := s.rpc.AdminDatadir()
+	s.Require().Nil(err)
+	s.Require().Equal("/home/geth/.ethereum", datadir)
+}
+
+func (s *EthRPCTestSuite) TestAdminStartHTTP() {
+	s.registerResponse(`true`, func(body []byte) {
+		s.methodEqual(body, "admin_startHTTP")
+		s.paramsEqual(body, `["127.0.0.1", 8545, "*", "eth,net,web3"]`)
+	})
+
+	started, err := s.rpc.AdminStartHTTP("127.0.0.1", 8545, "*", "eth,net,web3")
+	s.Require().Nil(err)
+	s.Require().True(started)
+}
+
+func (s *EthRPCTestSuite) TestAdminStopHTTP() {
+	s.registerResponse(`true`, func(body []byte) {
+		s.methodEqual(body, "admin_stopHTTP")
+		s.paramsEqual(body, "null")
+	})
+
+	stopped, err := s.rpc.AdminStopHTTP()
+	s.Require().Nil(err)
+	s.Require().True(stopped)
+}
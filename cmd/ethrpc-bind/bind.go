@@ -0,0 +1,437 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/onrik/ethrpc/abi"
+)
+
+// reservedNames are identifiers used in generated methods which parameters must not shadow
+var reservedNames = map[string]bool{
+	"abi": true, "ethrpc": true, "big": true, "c": true, "err": true,
+	"values": true, "result": true, "decoded": true, "event": true, "topics": true,
+}
+
+// initialisms - words written in upper case in Go names, e.g. "tokenId" is TokenID
+var initialisms = map[string]string{
+	"id": "ID", "ids": "IDs", "uri": "URI", "url": "URL", "api": "API", "json": "JSON", "http": "HTTP",
+}
+
+// binder - generates Go binding of contract ABI
+type binder struct {
+	pkg      string
+	typeName string
+	abiVar   string
+	contract *abi.ABI
+	structs  map[string]string
+	order    []string
+	usesBig  bool
+}
+
+// generate returns formatted Go source of contract binding
+func generate(abiJSON []byte, pkg, typeName string) ([]byte, error) {
+	abiJSON, err := extractABI(abiJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := abi.Parse(abiJSON)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ABI: %w", err)
+	}
+	if !token.IsIdentifier(pkg) {
+		return nil, fmt.Errorf("invalid package name %s", pkg)
+	}
+	if !token.IsIdentifier(typeName) || !token.IsExported(typeName) {
+		return nil, fmt.Errorf("invalid type name %s, exported identifier expected", typeName)
+	}
+
+	b := &binder{
+		pkg:      pkg,
+		typeName: typeName,
+		abiVar:   string(unicode.ToLower(rune(typeName[0]))) + typeName[1:] + "ABI",
+		contract: contract,
+		structs:  map[string]string{},
+	}
+
+	body := &bytes.Buffer{}
+	for _, name := range sortedKeys(contract.Methods) {
+		method := contract.Methods[name]
+		if method.IsConstant() {
+			b.writeCall(body, method)
+		} else {
+			b.writeTransaction(body, method)
+		}
+	}
+	for _, name := range sortedKeys(contract.Events) {
+		b.writeEvent(body, contract.Events[name])
+	}
+
+	compact := &bytes.Buffer{}
+	if err := json.Compact(compact, abiJSON); err != nil {
+		return nil, err
+	}
+
+	source := &bytes.Buffer{}
+	fmt.Fprintf(source, "// Code generated by ethrpc-bind. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+	if b.usesBig {
+		fmt.Fprintf(source, "\t\"math/big\"\n\n")
+	}
+	fmt.Fprintf(source, "\t\"github.com/onrik/ethrpc\"\n\t\"github.com/onrik/ethrpc/abi\"\n)\n\n")
+	fmt.Fprintf(source, "// %sABI - ABI of %s contract\nconst %sABI = %s\n\n", typeName, typeName, typeName, quote(compact.String()))
+	fmt.Fprintf(source, "var %s = abi.MustParse(%sABI)\n\n", b.abiVar, typeName)
+	for _, name := range b.order {
+		source.WriteString(b.structs[name])
+	}
+	fmt.Fprintf(source, "// %s - binding of %s contract\ntype %s struct {\n\taddress string\n\tcaller  abi.Caller\n\tblock   string\n}\n\n", typeName, typeName, typeName)
+	fmt.Fprintf(source, "// New%s returns binding of %s contract at address, caller is used for view methods\n", typeName, typeName)
+	fmt.Fprintf(source, "func New%s(address string, caller abi.Caller) *%s {\n\treturn &%s{address: address, caller: caller, block: \"latest\"}\n}\n\n", typeName, typeName, typeName)
+	fmt.Fprintf(source, "// WithBlock returns copy of binding making calls at block number or tag, \"latest\" is used by default\n")
+	fmt.Fprintf(source, "func (c *%s) WithBlock(block string) *%s {\n\tcopied := *c\n\tcopied.block = block\n\treturn &copied\n}\n\n", typeName, typeName)
+	source.Write(body.Bytes())
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// extractABI returns ABI array from ABI JSON or from build artifact with "abi" field
+func extractABI(data []byte) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	if !bytes.HasPrefix(data, []byte("{")) {
+		return data, nil
+	}
+
+	artifact := struct {
+		ABI json.RawMessage `json:"abi"`
+	}{}
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return nil, fmt.Errorf("invalid ABI: %w", err)
+	}
+	if len(artifact.ABI) == 0 {
+		return nil, fmt.Errorf("invalid ABI: artifact without abi field")
+	}
+
+	return artifact.ABI, nil
+}
+
+func (b *binder) writeCall(w *bytes.Buffer, method abi.Method) {
+	name := exportedName(method.Name)
+	params, args := b.params(method.Inputs, name)
+
+	resultType := "error"
+	switch len(method.Outputs) {
+	case 0:
+	case 1:
+		resultType = "(" + b.goType(method.Outputs[0].Type, method.Outputs[0].InternalType, name) + ", error)"
+	default:
+		fields := b.fields(method.Outputs, name, nil)
+		outputType := b.typeName + name + "Output"
+		fmt.Fprintf(w, "// %s - return values of %s\ntype %s struct {\n", outputType, method.Signature(), outputType)
+		for _, f := range fields {
+			fmt.Fprintf(w, "\t%s %s\n", f.name, f.goType)
+		}
+		fmt.Fprintf(w, "}\n\n")
+		resultType = "(" + outputType + ", error)"
+	}
+
+	fmt.Fprintf(w, "// %s calls %s\n", name, method.Signature())
+	fmt.Fprintf(w, "func (c *%s) %s(%s) %s {\n", b.typeName, name, params, resultType)
+	if len(method.Outputs) == 0 {
+		fmt.Fprintf(w, "\t_, err := %s.Call(c.caller, c.address, c.block, %q%s)\n\treturn err\n}\n\n", b.abiVar, method.Name, args)
+		return
+	}
+
+	fmt.Fprintf(w, "\tvar result %s\n", strings.TrimSuffix(strings.TrimPrefix(resultType, "("), ", error)"))
+	fmt.Fprintf(w, "\tvalues, err := %s.Call(c.caller, c.address, c.block, %q%s)\n", b.abiVar, method.Name, args)
+	fmt.Fprintf(w, "\tif err != nil {\n\t\treturn result, err\n\t}\n\n")
+	if len(method.Outputs) == 1 {
+		b.writeAssign(w, "result", method.Outputs[0].Type, "values[0]", "result")
+	} else {
+		for i, f := range b.fields(method.Outputs, name, nil) {
+			b.writeAssign(w, "result."+f.name, method.Outputs[i].Type, fmt.Sprintf("values[%d]", i), "result")
+		}
+	}
+	fmt.Fprintf(w, "\treturn result, nil\n}\n\n")
+}
+
+func (b *binder) writeTransaction(w *bytes.Buffer, method abi.Method) {
+	name := exportedName(method.Name)
+	params, args := b.params(method.Inputs, name)
+
+	comment := "returns transaction calling " + method.Signature()
+	if method.StateMutability == "payable" {
+		comment += ", value can be set to the transaction"
+	}
+	fmt.Fprintf(w, "// %s %s\n", name, comment)
+	fmt.Fprintf(w, "func (c *%s) %s(%s) (ethrpc.T, error) {\n", b.typeName, name, params)
+	fmt.Fprintf(w, "\treturn %s.Transaction(c.address, %q%s)\n}\n\n", b.abiVar, method.Name, args)
+}
+
+func (b *binder) writeEvent(w *bytes.Buffer, event abi.Event) {
+	name := exportedName(event.Name)
+	eventType := b.typeName + name
+	fields := b.fields(event.Inputs, name, map[string]bool{"Log": true})
+
+	fmt.Fprintf(w, "// %s - %s event\ntype %s struct {\n", eventType, event.Signature(), eventType)
+	for _, f := range fields {
+		fmt.Fprintf(w, "\t%s %s\n", f.name, f.goType)
+	}
+	fmt.Fprintf(w, "\tLog ethrpc.Log\n}\n\n")
+
+	params := []string{}
+	args := []string{}
+	used := map[string]bool{}
+	for i, input := range event.Inputs {
+		if !input.Indexed {
+			continue
+		}
+		param := uniqueName(paramName(input.Name, i), used)
+		params = append(params, param+" []"+b.goType(input.Type, input.InternalType, name+fields[i].name))
+		args = append(args, param)
+	}
+
+	if !event.Anonymous {
+		fmt.Fprintf(w, "// Filter%s returns filter of %s events of the contract, empty values match any value\n", name, event.RawName)
+		fmt.Fprintf(w, "func (c *%s) Filter%s(%s) (ethrpc.FilterParams, error) {\n", b.typeName, name, strings.Join(params, ", "))
+		fmt.Fprintf(w, "\ttopics, err := %s.Events[%q].FilterTopics(%s)\n", b.abiVar, event.Name, strings.Join(args, ", "))
+		fmt.Fprintf(w, "\tif err != nil {\n\t\treturn ethrpc.FilterParams{}, err\n\t}\n\n")
+		fmt.Fprintf(w, "\treturn ethrpc.FilterParams{Address: []string{c.address}, Topics: topics}, nil\n}\n\n")
+	}
+
+	fmt.Fprintf(w, "// Parse%s decodes %s event from log\n", name, event.RawName)
+	fmt.Fprintf(w, "func (c *%s) Parse%s(log ethrpc.Log) (*%s, error) {\n", b.typeName, name, eventType)
+	fmt.Fprintf(w, "\tdecoded, err := %s.Events[%q].DecodeLog(log)\n", b.abiVar, event.Name)
+	fmt.Fprintf(w, "\tif err != nil {\n\t\treturn nil, err\n\t}\n\n")
+	fmt.Fprintf(w, "\tevent := &%s{Log: log}\n", eventType)
+	for i, f := range fields {
+		t := event.Inputs[i].Type
+		if event.Inputs[i].Indexed && isHashed(t) {
+			t = abi.MustNewType("bytes32")
+		}
+		b.writeAssign(w, "event."+f.name, t, fmt.Sprintf("decoded.Args[%d]", i), "nil")
+	}
+	fmt.Fprintf(w, "\treturn event, nil\n}\n\n")
+}
+
+// writeAssign writes assignment of decoded value, tuples are assigned with abi.Assign
+func (b *binder) writeAssign(w *bytes.Buffer, dest string, t abi.Type, value, zero string) {
+	if !containsTuple(t) {
+		fmt.Fprintf(w, "\t%s = %s.(%s)\n", dest, value, b.goType(t, "", ""))
+		return
+	}
+
+	fmt.Fprintf(w, "\tif err := abi.Assign(&%s, %s); err != nil {\n\t\treturn %s, err\n\t}\n", dest, value, zero)
+}
+
+type field struct {
+	name   string
+	goType string
+}
+
+// fields returns struct fields of arguments, hashed indexed values have type [32]byte
+func (b *binder) fields(arguments abi.Arguments, context string, reserved map[string]bool) []field {
+	used := map[string]bool{}
+	for name := range reserved {
+		used[name] = true
+	}
+
+	fields := make([]field, len(arguments))
+	for i, argument := range arguments {
+		name := exportedName(argument.Name)
+		if name == "" {
+			name = "Arg" + strconv.Itoa(i)
+		}
+		name = uniqueName(name, used)
+
+		goType := "[32]byte"
+		if !argument.Indexed || !isHashed(argument.Type) {
+			goType = b.goType(argument.Type, argument.InternalType, context+name)
+		}
+		fields[i] = field{name: name, goType: goType}
+	}
+
+	return fields
+}
+
+// params returns Go parameters declaration and call arguments
+func (b *binder) params(arguments abi.Arguments, context string) (string, string) {
+	params := make([]string, len(arguments))
+	args := ""
+	used := map[string]bool{}
+	for i, argument := range arguments {
+		name := uniqueName(paramName(argument.Name, i), used)
+		params[i] = name + " " + b.goType(argument.Type, argument.InternalType, context+exportedName(argument.Name))
+		args += ", " + name
+	}
+
+	return strings.Join(params, ", "), args
+}
+
+// goType returns Go type of ABI type matching abi.Type.GoType, tuples are generated as structs
+func (b *binder) goType(t abi.Type, internalType string, context string) string {
+	switch t.Kind {
+	case abi.UintKind, abi.IntKind:
+		goType := t.GoType().String()
+		if goType == "*big.Int" {
+			b.usesBig = true
+		}
+		return goType
+	case abi.BoolKind:
+		return "bool"
+	case abi.AddressKind, abi.StringKind:
+		return "string"
+	case abi.BytesKind:
+		return "[]byte"
+	case abi.FixedBytesKind, abi.FunctionKind:
+		return "[" + strconv.Itoa(t.Size) + "]byte"
+	case abi.SliceKind:
+		return "[]" + b.goType(*t.Elem, internalType, context)
+	case abi.ArrayKind:
+		return "[" + strconv.Itoa(t.Size) + "]" + b.goType(*t.Elem, internalType, context)
+	}
+
+	return b.structType(t, internalType, context)
+}
+
+// structType registers struct of tuple type and returns its name.
+// Name is taken from internal type like "struct Pool.Position" or from context of the tuple usage.
+func (b *binder) structType(t abi.Type, internalType string, context string) string {
+	name := context
+	if strings.HasPrefix(internalType, "struct ") {
+		name = strings.TrimPrefix(internalType, "struct ")
+		if i := strings.Index(name, "["); i >= 0 {
+			name = name[:i]
+		}
+		name = exportedName(name[strings.LastIndex(name, ".")+1:])
+	}
+	name = b.typeName + name
+
+	fields := b.fields(t.Components, strings.TrimPrefix(name, b.typeName), nil)
+	declaration := &bytes.Buffer{}
+	fmt.Fprintf(declaration, "// %s - %s tuple\ntype %s struct {\n", name, t, name)
+	for _, f := range fields {
+		fmt.Fprintf(declaration, "\t%s %s\n", f.name, f.goType)
+	}
+	fmt.Fprintf(declaration, "}\n\n")
+
+	// The same struct can be used in many places, different structs with the same name get index suffix
+	unique := name
+	for i := 0; ; i++ {
+		existing, ok := b.structs[unique]
+		if !ok {
+			break
+		}
+		if existing == strings.Replace(declaration.String(), name, unique, 3) {
+			return unique
+		}
+		unique = name + strconv.Itoa(i)
+	}
+
+	b.structs[unique] = strings.Replace(declaration.String(), name, unique, 3)
+	b.order = append(b.order, unique)
+	return unique
+}
+
+func containsTuple(t abi.Type) bool {
+	for t.Kind == abi.SliceKind || t.Kind == abi.ArrayKind {
+		t = *t.Elem
+	}
+
+	return t.Kind == abi.TupleKind
+}
+
+// isHashed reports whether indexed value of type is stored in topic as hash
+func isHashed(t abi.Type) bool {
+	switch t.Kind {
+	case abi.StringKind, abi.BytesKind, abi.SliceKind, abi.ArrayKind, abi.TupleKind:
+		return true
+	}
+
+	return false
+}
+
+// exportedName converts Solidity name like "_token_id" or "tokenId" to exported Go name "TokenID"
+func exportedName(name string) string {
+	result := ""
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '$' }) {
+		for _, word := range camelCaseWords(part) {
+			if initialism, ok := initialisms[strings.ToLower(word)]; ok {
+				result += initialism
+			} else {
+				result += strings.ToUpper(word[:1]) + word[1:]
+			}
+		}
+	}
+	if result != "" && unicode.IsDigit(rune(result[0])) {
+		result = "X" + result
+	}
+
+	return result
+}
+
+// camelCaseWords splits name like "tokenURI" to words "token" and "URI"
+func camelCaseWords(name string) []string {
+	words := []string{}
+	start := 0
+	for i := 1; i < len(name); i++ {
+		if unicode.IsUpper(rune(name[i])) && !unicode.IsUpper(rune(name[i-1])) {
+			words = append(words, name[start:i])
+			start = i
+		}
+	}
+
+	return append(words, name[start:])
+}
+
+// paramName converts Solidity name to Go parameter name not conflicting with keywords and generated code
+func paramName(name string, index int) string {
+	name = strings.TrimLeft(strings.ReplaceAll(name, "$", "_"), "_")
+	if name == "" {
+		return "arg" + strconv.Itoa(index)
+	}
+	if token.IsKeyword(name) || reservedNames[name] {
+		return name + "_"
+	}
+
+	return name
+}
+
+func uniqueName(name string, used map[string]bool) string {
+	unique := name
+	for i := 1; used[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	used[unique] = true
+
+	return unique
+}
+
+func quote(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+
+	return "`" + s + "`"
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateToken(t *testing.T) {
+	abiJSON, err := os.ReadFile("internal/token/token.abi.json")
+	require.Nil(t, err)
+	expected, err := os.ReadFile("internal/token/token.go")
+	require.Nil(t, err)
+
+	code, err := generate(abiJSON, "token", "Token")
+	require.Nil(t, err)
+	require.Equal(t, string(expected), string(code), "run go generate ./cmd/ethrpc-bind/...")
+
+	// Build artifact
+	artifact := append(append([]byte(`{"contractName": "Token", "abi": `), abiJSON...), '}')
+	code, err = generate(artifact, "token", "Token")
+	require.Nil(t, err)
+	require.Equal(t, string(expected), string(code))
+}
+
+func TestGenerateNames(t *testing.T) {
+	code, err := generate([]byte(`[
+		{"type": "function", "name": "swap", "stateMutability": "nonpayable", "inputs": [
+			{"name": "", "type": "tuple", "components": [{"name": "a", "type": "uint256"}]},
+			{"name": "c", "type": "tuple", "components": [{"name": "b", "type": "bool"}]},
+			{"name": "func", "type": "address"},
+			{"name": "_func", "type": "address"}
+		], "outputs": []},
+		{"type": "function", "name": "get", "stateMutability": "view", "inputs": [], "outputs": [
+			{"name": "", "type": "tuple[2]", "internalType": "struct Lib.Pair[2]", "components": [{"name": "a", "type": "uint256"}]},
+			{"name": "", "type": "tuple", "internalType": "struct Other.Pair", "components": [{"name": "b", "type": "bool"}]}
+		]},
+		{"type": "event", "name": "Done", "anonymous": true, "inputs": [
+			{"name": "log", "type": "uint8", "indexed": true},
+			{"name": "ids", "type": "uint256[]", "indexed": true}
+		]}
+	]`), "pool", "Pool")
+	require.Nil(t, err)
+
+	source := string(code)
+	for _, s := range []string{
+		"type PoolSwap struct {\n\tA *big.Int\n}",
+		"type PoolSwapC struct {\n\tB bool\n}",
+		"func (c *Pool) Swap(arg0 PoolSwap, c_ PoolSwapC, func_ string, func_1 string) (ethrpc.T, error) {",
+		"type PoolPair struct {\n\tA *big.Int\n}",
+		"type PoolPair0 struct {\n\tB bool\n}",
+		"type PoolGetOutput struct {\n\tArg0 [2]PoolPair\n\tArg1 PoolPair0\n}",
+		"if err := abi.Assign(&result.Arg1, values[1]); err != nil {",
+		"type PoolDone struct {\n\tLog1 uint8\n\tIDs  [32]byte\n\tLog  ethrpc.Log\n}",
+		"func (c *Pool) ParseDone(log ethrpc.Log) (*PoolDone, error) {",
+	} {
+		require.Contains(t, source, s)
+	}
+	// Anonymous events can't be filtered by topic
+	require.NotContains(t, source, "FilterDone")
+}
+
+func TestGenerateErrors(t *testing.T) {
+	for _, test := range []struct {
+		abi      string
+		pkg      string
+		typeName string
+	}{
+		{`[]`, "", "Token"},
+		{`[]`, "token", "token"},
+		{`[]`, "token", "1Token"},
+		{`[`, "token", "Token"},
+		{`{"abi": 1}`, "token", "Token"},
+		{`{"contractName": "Token"}`, "token", "Token"},
+		{`[{"type": "function", "name": "f", "inputs": [{"type": "uint7"}]}]`, "token", "Token"},
+	} {
+		_, err := generate([]byte(test.abi), test.pkg, test.typeName)
+		require.NotNil(t, err, test)
+	}
+}
+
+func TestRun(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	err := run([]string{"-pkg", "empty", "-type", "Empty"}, strings.NewReader(`[]`), stdout)
+	require.Nil(t, err)
+	require.Contains(t, stdout.String(), "package empty")
+
+	out := filepath.Join(t.TempDir(), "token.go")
+	err = run([]string{"-abi", "internal/token/token.abi.json", "-pkg", "token", "-type", "Token", "-out", out}, nil, nil)
+	require.Nil(t, err)
+	code, err := os.ReadFile(out)
+	require.Nil(t, err)
+	require.Contains(t, string(code), "func NewToken(address string, caller abi.Caller) *Token {")
+
+	err = run([]string{"-pkg", "token"}, nil, nil)
+	require.NotNil(t, err)
+	err = run([]string{"-abi", "not_found.json", "-pkg", "token", "-type", "Token"}, nil, nil)
+	require.NotNil(t, err)
+	err = run([]string{"-unknown"}, nil, nil)
+	require.NotNil(t, err)
+}
+
+func TestExportedName(t *testing.T) {
+	require.Equal(t, "TokenID", exportedName("_token_id"))
+	require.Equal(t, "TokenID", exportedName("tokenId"))
+	require.Equal(t, "TokenURI", exportedName("tokenURI"))
+	require.Equal(t, "BaseURL", exportedName("base_url"))
+	require.Equal(t, "BalanceOfBatch", exportedName("balanceOfBatch"))
+	require.Equal(t, "IDs", exportedName("ids"))
+	require.Equal(t, "Identity", exportedName("identity"))
+	require.Equal(t, "ERC20Token", exportedName("ERC20Token"))
+	require.Equal(t, "X1inch", exportedName("1inch"))
+	require.Equal(t, "", exportedName("_"))
+	require.Equal(t, "arg2", paramName("_", 2))
+	require.Equal(t, "type_", paramName("type", 0))
+	require.Equal(t, "values_", paramName("values", 0))
+	require.Equal(t, "amount", paramName("_amount", 0))
+}
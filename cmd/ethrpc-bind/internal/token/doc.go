@@ -0,0 +1,4 @@
+// Package token is binding generated by ethrpc-bind for tests.
+package token
+
+//go:generate go run github.com/onrik/ethrpc/cmd/ethrpc-bind -abi token.abi.json -pkg token -type Token -out token.go
@@ -0,0 +1,191 @@
+// Code generated by ethrpc-bind. DO NOT EDIT.
+
+package token
+
+import (
+	"math/big"
+
+	"github.com/onrik/ethrpc"
+	"github.com/onrik/ethrpc/abi"
+)
+
+// TokenABI - ABI of Token contract
+const TokenABI = `[{"type":"constructor","stateMutability":"nonpayable","inputs":[{"name":"name_","type":"string","internalType":"string"},{"name":"symbol_","type":"string","internalType":"string"}]},{"type":"function","name":"name","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string","internalType":"string"}]},{"type":"function","name":"decimals","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8","internalType":"uint8"}]},{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"account","type":"address","internalType":"address"}],"outputs":[{"name":"","type":"uint256","internalType":"uint256"}]},{"type":"function","name":"getReserves","stateMutability":"view","inputs":[],"outputs":[{"name":"_reserve0","type":"uint112","internalType":"uint112"},{"name":"_reserve1","type":"uint112","internalType":"uint112"},{"name":"_blockTimestampLast","type":"uint32","internalType":"uint32"}]},{"type":"function","name":"positions","stateMutability":"view","inputs":[{"name":"owner","type":"address","internalType":"address"},{"name":"ids","type":"uint256[]","internalType":"uint256[]"}],"outputs":[{"name":"","type":"tuple[]","internalType":"struct Token.Position[]","components":[{"name":"id","type":"uint256","internalType":"uint256"},{"name":"range","type":"int24[2]","internalType":"int24[2]"},{"name":"hash","type":"bytes32","internalType":"bytes32"}]}]},{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address","internalType":"address"},{"name":"amount","type":"uint256","internalType":"uint256"}],"outputs":[{"name":"","type":"bool","internalType":"bool"}]},{"type":"function","name":"deposit","stateMutability":"payable","inputs":[],"outputs":[]},{"type":"function","name":"mint","stateMutability":"nonpayable","inputs":[{"name":"position","type":"tuple","internalType":"struct Token.Position","components":[{"name":"id","type":"uint256","internalType":"uint256"},{"name":"range","type":"int24[2]","internalType":"int24[2]"},{"name":"hash","type":"bytes32","internalType":"bytes32"}]},{"name":"type","type":"uint8","internalType":"uint8"}],"outputs":[]},{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","internalType":"address","indexed":true},{"name":"to","type":"address","internalType":"address","indexed":true},{"name":"value","type":"uint256","internalType":"uint256","indexed":false}]},{"type":"event","name":"Memo","anonymous":false,"inputs":[{"name":"sender","type":"address","internalType":"address","indexed":true},{"name":"topic","type":"string","internalType":"string","indexed":true},{"name":"text","type":"string","internalType":"string","indexed":false}]}]`
+
+var tokenABI = abi.MustParse(TokenABI)
+
+// TokenPosition - (uint256,int24[2],bytes32) tuple
+type TokenPosition struct {
+	ID    *big.Int
+	Range [2]*big.Int
+	Hash  [32]byte
+}
+
+// Token - binding of Token contract
+type Token struct {
+	address string
+	caller  abi.Caller
+	block   string
+}
+
+// NewToken returns binding of Token contract at address, caller is used for view methods
+func NewToken(address string, caller abi.Caller) *Token {
+	return &Token{address: address, caller: caller, block: "latest"}
+}
+
+// WithBlock returns copy of binding making calls at block number or tag, "latest" is used by default
+func (c *Token) WithBlock(block string) *Token {
+	copied := *c
+	copied.block = block
+	return &copied
+}
+
+// BalanceOf calls balanceOf(address)
+func (c *Token) BalanceOf(account string) (*big.Int, error) {
+	var result *big.Int
+	values, err := tokenABI.Call(c.caller, c.address, c.block, "balanceOf", account)
+	if err != nil {
+		return result, err
+	}
+
+	result = values[0].(*big.Int)
+	return result, nil
+}
+
+// Decimals calls decimals()
+func (c *Token) Decimals() (uint8, error) {
+	var result uint8
+	values, err := tokenABI.Call(c.caller, c.address, c.block, "decimals")
+	if err != nil {
+		return result, err
+	}
+
+	result = values[0].(uint8)
+	return result, nil
+}
+
+// Deposit returns transaction calling deposit(), value can be set to the transaction
+func (c *Token) Deposit() (ethrpc.T, error) {
+	return tokenABI.Transaction(c.address, "deposit")
+}
+
+// TokenGetReservesOutput - return values of getReserves()
+type TokenGetReservesOutput struct {
+	Reserve0           *big.Int
+	Reserve1           *big.Int
+	BlockTimestampLast uint32
+}
+
+// GetReserves calls getReserves()
+func (c *Token) GetReserves() (TokenGetReservesOutput, error) {
+	var result TokenGetReservesOutput
+	values, err := tokenABI.Call(c.caller, c.address, c.block, "getReserves")
+	if err != nil {
+		return result, err
+	}
+
+	result.Reserve0 = values[0].(*big.Int)
+	result.Reserve1 = values[1].(*big.Int)
+	result.BlockTimestampLast = values[2].(uint32)
+	return result, nil
+}
+
+// Mint returns transaction calling mint((uint256,int24[2],bytes32),uint8)
+func (c *Token) Mint(position TokenPosition, type_ uint8) (ethrpc.T, error) {
+	return tokenABI.Transaction(c.address, "mint", position, type_)
+}
+
+// Name calls name()
+func (c *Token) Name() (string, error) {
+	var result string
+	values, err := tokenABI.Call(c.caller, c.address, c.block, "name")
+	if err != nil {
+		return result, err
+	}
+
+	result = values[0].(string)
+	return result, nil
+}
+
+// Positions calls positions(address,uint256[])
+func (c *Token) Positions(owner string, ids []*big.Int) ([]TokenPosition, error) {
+	var result []TokenPosition
+	values, err := tokenABI.Call(c.caller, c.address, c.block, "positions", owner, ids)
+	if err != nil {
+		return result, err
+	}
+
+	if err := abi.Assign(&result, values[0]); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Transfer returns transaction calling transfer(address,uint256)
+func (c *Token) Transfer(to string, amount *big.Int) (ethrpc.T, error) {
+	return tokenABI.Transaction(c.address, "transfer", to, amount)
+}
+
+// TokenMemo - Memo(address,string,string) event
+type TokenMemo struct {
+	Sender string
+	Topic  [32]byte
+	Text   string
+	Log    ethrpc.Log
+}
+
+// FilterMemo returns filter of Memo events of the contract, empty values match any value
+func (c *Token) FilterMemo(sender []string, topic []string) (ethrpc.FilterParams, error) {
+	topics, err := tokenABI.Events["Memo"].FilterTopics(sender, topic)
+	if err != nil {
+		return ethrpc.FilterParams{}, err
+	}
+
+	return ethrpc.FilterParams{Address: []string{c.address}, Topics: topics}, nil
+}
+
+// ParseMemo decodes Memo event from log
+func (c *Token) ParseMemo(log ethrpc.Log) (*TokenMemo, error) {
+	decoded, err := tokenABI.Events["Memo"].DecodeLog(log)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &TokenMemo{Log: log}
+	event.Sender = decoded.Args[0].(string)
+	event.Topic = decoded.Args[1].([32]byte)
+	event.Text = decoded.Args[2].(string)
+	return event, nil
+}
+
+// TokenTransfer - Transfer(address,address,uint256) event
+type TokenTransfer struct {
+	From  string
+	To    string
+	Value *big.Int
+	Log   ethrpc.Log
+}
+
+// FilterTransfer returns filter of Transfer events of the contract, empty values match any value
+func (c *Token) FilterTransfer(from []string, to []string) (ethrpc.FilterParams, error) {
+	topics, err := tokenABI.Events["Transfer"].FilterTopics(from, to)
+	if err != nil {
+		return ethrpc.FilterParams{}, err
+	}
+
+	return ethrpc.FilterParams{Address: []string{c.address}, Topics: topics}, nil
+}
+
+// ParseTransfer decodes Transfer event from log
+func (c *Token) ParseTransfer(log ethrpc.Log) (*TokenTransfer, error) {
+	decoded, err := tokenABI.Events["Transfer"].DecodeLog(log)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &TokenTransfer{Log: log}
+	event.From = decoded.Args[0].(string)
+	event.To = decoded.Args[1].(string)
+	event.Value = decoded.Args[2].(*big.Int)
+	return event, nil
+}
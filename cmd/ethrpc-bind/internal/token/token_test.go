@@ -0,0 +1,138 @@
+package token
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/onrik/ethrpc"
+	"github.com/onrik/ethrpc/abi"
+	"github.com/onrik/ethrpc/internal/rpctest"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	address = "0x1111111111111111111111111111111111111111"
+	account = "0x3535353535353535353535353535353535353535"
+)
+
+func encode(t *testing.T, method string, values ...interface{}) string {
+	data, err := tokenABI.Methods[method].Outputs.Encode(values...)
+	require.Nil(t, err)
+
+	return "0x" + hex.EncodeToString(data)
+}
+
+func TestCalls(t *testing.T) {
+	caller := &rpctest.Caller{}
+	token := NewToken(address, caller)
+
+	caller.Result = encode(t, "balanceOf", big.NewInt(100))
+	balance, err := token.BalanceOf(account)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(100), balance)
+	require.Equal(t, address, caller.Transaction.To)
+	require.Equal(t, "0x70a08231000000000000000000000000"+account[2:], caller.Transaction.Data)
+	require.Equal(t, "latest", caller.Block)
+
+	_, err = token.WithBlock("0x10").BalanceOf(account)
+	require.Nil(t, err)
+	require.Equal(t, "0x10", caller.Block)
+
+	caller.Result = encode(t, "decimals", 18)
+	decimals, err := token.Decimals()
+	require.Nil(t, err)
+	require.Equal(t, uint8(18), decimals)
+
+	caller.Result = encode(t, "getReserves", 1, 2, 3)
+	reserves, err := token.GetReserves()
+	require.Nil(t, err)
+	require.Equal(t, TokenGetReservesOutput{Reserve0: big.NewInt(1), Reserve1: big.NewInt(2), BlockTimestampLast: 3}, reserves)
+
+	position := TokenPosition{ID: big.NewInt(7), Range: [2]*big.Int{big.NewInt(-10), big.NewInt(10)}, Hash: [32]byte{1}}
+	caller.Result = encode(t, "positions", []TokenPosition{position})
+	positions, err := token.Positions(account, []*big.Int{big.NewInt(7)})
+	require.Nil(t, err)
+	require.Equal(t, []TokenPosition{position}, positions)
+
+	caller.Err = errors.New("error")
+	_, err = token.Name()
+	require.Equal(t, caller.Err, err)
+
+	caller.Err = nil
+	caller.Result = "0x01"
+	_, err = token.Name()
+	require.NotNil(t, err)
+}
+
+func TestTransactions(t *testing.T) {
+	token := NewToken(address, nil)
+
+	transaction, err := token.Transfer(account, big.NewInt(100))
+	require.Nil(t, err)
+	require.Equal(t, address, transaction.To)
+	require.Equal(t, "0xa9059cbb000000000000000000000000"+account[2:]+"0000000000000000000000000000000000000000000000000000000000000064", transaction.Data)
+
+	transaction, err = token.Deposit()
+	require.Nil(t, err)
+	require.Equal(t, "0xd0e30db0", transaction.Data)
+
+	transaction, err = token.Mint(TokenPosition{ID: big.NewInt(1), Range: [2]*big.Int{big.NewInt(-1), big.NewInt(1)}}, 2)
+	require.Nil(t, err)
+	input, err := abi.DecodeHex(transaction.Data)
+	require.Nil(t, err)
+	method, args, err := tokenABI.DecodeInput(input)
+	require.Nil(t, err)
+	require.Equal(t, "mint", method.Name)
+	require.Equal(t, uint8(2), args[1])
+
+	_, err = token.Mint(TokenPosition{}, 2)
+	require.NotNil(t, err)
+}
+
+func TestEvents(t *testing.T) {
+	token := NewToken(address, nil)
+
+	params, err := token.FilterTransfer(nil, []string{account})
+	require.Nil(t, err)
+	require.Equal(t, []string{address}, params.Address)
+	require.Equal(t, [][]string{
+		{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"},
+		nil,
+		{"0x000000000000000000000000" + account[2:]},
+	}, params.Topics)
+
+	data, err := tokenABI.Events["Transfer"].Inputs.NonIndexed().Encode(big.NewInt(100))
+	require.Nil(t, err)
+	log := ethrpc.Log{
+		Address: address,
+		Topics:  []string{params.Topics[0][0], params.Topics[2][0], params.Topics[2][0]},
+		Data:    "0x" + hex.EncodeToString(data),
+	}
+	transfer, err := token.ParseTransfer(log)
+	require.Nil(t, err)
+	require.Equal(t, &TokenTransfer{From: account, To: account, Value: big.NewInt(100), Log: log}, transfer)
+
+	_, err = token.ParseMemo(log)
+	require.NotNil(t, err)
+
+	params, err = token.FilterMemo(nil, []string{"hello", "world"})
+	require.Nil(t, err)
+	require.Len(t, params.Topics, 3)
+	require.Len(t, params.Topics[2], 2)
+
+	data, err = tokenABI.Events["Memo"].Inputs.NonIndexed().Encode("text")
+	require.Nil(t, err)
+	log = ethrpc.Log{
+		Topics: []string{params.Topics[0][0], "0x000000000000000000000000" + account[2:], params.Topics[2][0]},
+		Data:   "0x" + hex.EncodeToString(data),
+	}
+	memo, err := token.ParseMemo(log)
+	require.Nil(t, err)
+	require.Equal(t, "text", memo.Text)
+	require.Equal(t, ethrpc.Keccak256([]byte("hello")), memo.Topic[:])
+
+	_, err = token.FilterTransfer([]string{"0x01"}, nil)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,62 @@
+// Command ethrpc-bind generates Go bindings of contract ABI using ethrpc and abi packages.
+//
+// Usage:
+//
+//	ethrpc-bind -abi Token.json -pkg token -type Token -out token.go
+//
+// Generated type has method for every function of the contract: view functions are called with
+// abi.Caller (ethrpc.EthRPC implements it) and state-changing functions return ethrpc.T for
+// EthSendTransaction or signing. Every event gets FilterX method building ethrpc.FilterParams
+// and ParseX method decoding ethrpc.Log.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "ethrpc-bind:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("ethrpc-bind", flag.ContinueOnError)
+	abiPath := flags.String("abi", "-", "path of ABI JSON or build artifact with abi field, - for stdin")
+	pkg := flags.String("pkg", "", "package name of generated code")
+	typeName := flags.String("type", "", "name of generated contract type")
+	out := flags.String("out", "", "output file, stdout if empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *pkg == "" || *typeName == "" {
+		return fmt.Errorf("-pkg and -type are required")
+	}
+
+	var data []byte
+	var err error
+	if *abiPath == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(*abiPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	code, err := generate(data, *pkg, *typeName)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = stdout.Write(code)
+		return err
+	}
+
+	return os.WriteFile(*out, code, 0644)
+}
@@ -0,0 +1,234 @@
+package ethrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"unsafe"
+)
+
+// TraceConfig - debug tracer options
+type TraceConfig struct {
+	// Tracer is a name of built-in tracer (callTracer, prestateTracer, ...) or a JavaScript tracer source code
+	Tracer           string      `json:"tracer,omitempty"`
+	TracerConfig     interface{} `json:"tracerConfig,omitempty"`
+	Timeout          string      `json:"timeout,omitempty"`
+	Reexec           int         `json:"reexec,omitempty"`
+	DisableStorage   bool        `json:"disableStorage,omitempty"`
+	DisableStack     bool        `json:"disableStack,omitempty"`
+	EnableMemory     bool        `json:"enableMemory,omitempty"`
+	EnableReturnData bool        `json:"enableReturnData,omitempty"`
+}
+
+// CallTracerConfig - callTracer options
+type CallTracerConfig struct {
+	OnlyTopCall bool `json:"onlyTopCall,omitempty"`
+	WithLog     bool `json:"withLog,omitempty"`
+}
+
+// PrestateTracerConfig - prestateTracer options
+type PrestateTracerConfig struct {
+	DiffMode       bool `json:"diffMode,omitempty"`
+	DisableCode    bool `json:"disableCode,omitempty"`
+	DisableStorage bool `json:"disableStorage,omitempty"`
+}
+
+// CallTracer returns trace config for the built-in callTracer
+func CallTracer(config CallTracerConfig) TraceConfig {
+	return TraceConfig{
+		Tracer:       "callTracer",
+		TracerConfig: config,
+	}
+}
+
+// PrestateTracer returns trace config for the built-in prestateTracer
+func PrestateTracer(config PrestateTracerConfig) TraceConfig {
+	return TraceConfig{
+		Tracer:       "prestateTracer",
+		TracerConfig: config,
+	}
+}
+
+// Trace - raw tracer output
+type Trace json.RawMessage
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Trace) MarshalJSON() ([]byte, error) {
+	return json.RawMessage(t).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Trace) UnmarshalJSON(data []byte) error {
+	return (*json.RawMessage)(t).UnmarshalJSON(data)
+}
+
+// CallFrame decodes output of the callTracer
+func (t Trace) CallFrame() (*CallFrame, error) {
+	frame := new(CallFrame)
+	if err := t.decode(frame); err != nil {
+		return nil, err
+	}
+
+	return frame, nil
+}
+
+// Prestate decodes output of the prestateTracer
+func (t Trace) Prestate() (Prestate, error) {
+	prestate := Prestate{}
+	if err := t.decode(&prestate); err != nil {
+		return nil, err
+	}
+
+	return prestate, nil
+}
+
+// PrestateDiff decodes output of the prestateTracer in diff mode
+func (t Trace) PrestateDiff() (*PrestateDiff, error) {
+	diff := new(PrestateDiff)
+	if err := t.decode(diff); err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+func (t Trace) decode(target interface{}) error {
+	if len(t) == 0 {
+		return errors.New("empty trace")
+	}
+
+	return json.Unmarshal(t, target)
+}
+
+// BlockTrace - trace of a single transaction in a block
+type BlockTrace struct {
+	TxHash string `json:"txHash"`
+	Result Trace  `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// CallFrame - callTracer call frame
+type CallFrame struct {
+	Type         string
+	From         string
+	To           string
+	Value        *big.Int
+	Gas          int
+	GasUsed      int
+	Input        string
+	Output       string
+	Error        string
+	RevertReason string
+	Calls        []CallFrame
+	Logs         []CallLog
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (frame *CallFrame) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyCallFrame)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return err
+	}
+
+	*frame = *(*CallFrame)(unsafe.Pointer(proxy))
+
+	return nil
+}
+
+// CallLog - log emitted in a call frame
+type CallLog struct {
+	Address  string
+	Topics   []string
+	Data     string
+	Position int
+}
+
+// Prestate - accounts state by address
+type Prestate map[string]PrestateAccount
+
+// PrestateDiff - accounts state before and after execution
+type PrestateDiff struct {
+	Pre  Prestate `json:"pre"`
+	Post Prestate `json:"post"`
+}
+
+// PrestateAccount - prestateTracer account state
+type PrestateAccount struct {
+	Balance *big.Int
+	Nonce   int
+	Code    string
+	Storage map[string]string
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (account *PrestateAccount) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyPrestateAccount)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return err
+	}
+
+	*account = *(*PrestateAccount)(unsafe.Pointer(proxy))
+
+	return nil
+}
+
+type proxyCallFrame struct {
+	Type         string           `json:"type"`
+	From         string           `json:"from"`
+	To           string           `json:"to"`
+	Value        *hexBig          `json:"value"`
+	Gas          hexInt           `json:"gas"`
+	GasUsed      hexInt           `json:"gasUsed"`
+	Input        string           `json:"input"`
+	Output       string           `json:"output"`
+	Error        string           `json:"error"`
+	RevertReason string           `json:"revertReason"`
+	Calls        []proxyCallFrame `json:"calls"`
+	Logs         []proxyCallLog   `json:"logs"`
+}
+
+type proxyCallLog struct {
+	Address  string   `json:"address"`
+	Topics   []string `json:"topics"`
+	Data     string   `json:"data"`
+	Position hexInt   `json:"position"`
+}
+
+type proxyPrestateAccount struct {
+	Balance *hexBig           `json:"balance"`
+	Nonce   int               `json:"nonce"`
+	Code    string            `json:"code"`
+	Storage map[string]string `json:"storage"`
+}
+
+// DebugTraceTransaction returns the trace of a transaction produced by the tracer from config.
+func (rpc *EthRPC) DebugTraceTransaction(hash string, config TraceConfig) (Trace, error) {
+	var trace Trace
+
+	err := rpc.call("debug_traceTransaction", &trace, hash, config)
+	return trace, err
+}
+
+// DebugTraceCall returns the trace of a call executed on top of the given block.
+func (rpc *EthRPC) DebugTraceCall(transaction T, block string, config TraceConfig) (Trace, error) {
+	var trace Trace
+
+	err := rpc.call("debug_traceCall", &trace, transaction, block, config)
+	return trace, err
+}
+
+// DebugTraceBlockByNumber returns traces of all transactions in a block by block number.
+func (rpc *EthRPC) DebugTraceBlockByNumber(number int, config TraceConfig) ([]BlockTrace, error) {
+	traces := []BlockTrace{}
+
+	err := rpc.call("debug_traceBlockByNumber", &traces, IntToHex(number), config)
+	return traces, err
+}
+
+// DebugTraceBlockByHash returns traces of all transactions in a block by block hash.
+func (rpc *EthRPC) DebugTraceBlockByHash(hash string, config TraceConfig) ([]BlockTrace, error) {
+	traces := []BlockTrace{}
+
+	err := rpc.call("debug_traceBlockByHash", &traces, hash, config)
+	return traces, err
+}
@@ -0,0 +1,168 @@
+package ethrpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (s *EthRPCTestSuite) TestDebugTraceTransaction() {
+	hash := "0x9c17afa5336d3cfd47e2e795520959b92e627e123e538fd4d5d7ece9025a8dce"
+	s.registerResponseError(errors.New("error"))
+	_, err := s.rpc.DebugTraceTransaction(hash, TraceConfig{})
+	s.Require().NotNil(err)
+
+	result := `{
+		"type": "CALL",
+		"from": "0xa95350d70b18fa29f6b5eb8d627ceeeee499340d",
+		"to": "0x8d12a197cb00d4747a1fe03395095ce2a5cc6819",
+		"value": "0xde0b6b3a7640000",
+		"gas": "0x3d090",
+		"gasUsed": "0x5208",
+		"input": "0x",
+		"calls": [{
+			"type": "STATICCALL",
+			"from": "0x8d12a197cb00d4747a1fe03395095ce2a5cc6819",
+			"to": "0xb595f3390fcec074237c8264b908fc73d4aedc93",
+			"gas": "0x100",
+			"gasUsed": "0x10",
+			"input": "0x70a08231",
+			"output": "0x",
+			"error": "execution reverted",
+			"revertReason": "not allowed",
+			"logs": [{
+				"address": "0xb595f3390fcec074237c8264b908fc73d4aedc93",
+				"topics": ["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"],
+				"data": "0x01",
+				"position": "0x1"
+			}]
+		}]
+	}`
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "debug_traceTransaction")
+		s.paramsEqual(body, fmt.Sprintf(`["%s", {"tracer": "callTracer", "tracerConfig": {"withLog": true}, "timeout": "10s"}]`, hash))
+	})
+
+	config := CallTracer(CallTracerConfig{WithLog: true})
+	config.Timeout = "10s"
+	trace, err := s.rpc.DebugTraceTransaction(hash, config)
+	s.Require().Nil(err)
+	s.JSONEq(result, string(trace))
+
+	frame, err := trace.CallFrame()
+	s.Require().Nil(err)
+	s.Require().Equal("CALL", frame.Type)
+	s.Require().Equal("0xa95350d70b18fa29f6b5eb8d627ceeeee499340d", frame.From)
+	s.Require().Equal(0, Eth1().Cmp(frame.Value))
+	s.Require().Equal(250000, frame.Gas)
+	s.Require().Equal(21000, frame.GasUsed)
+	s.Require().Len(frame.Calls, 1)
+	s.Require().Equal(CallFrame{
+		Type:         "STATICCALL",
+		From:         "0x8d12a197cb00d4747a1fe03395095ce2a5cc6819",
+		To:           "0xb595f3390fcec074237c8264b908fc73d4aedc93",
+		Gas:          256,
+		GasUsed:      16,
+		Input:        "0x70a08231",
+		Output:       "0x",
+		Error:        "execution reverted",
+		RevertReason: "not allowed",
+		Logs: []CallLog{{
+			Address:  "0xb595f3390fcec074237c8264b908fc73d4aedc93",
+			Topics:   []string{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"},
+			Data:     "0x01",
+			Position: 1,
+		}},
+	}, frame.Calls[0])
+}
+
+func (s *EthRPCTestSuite) TestDebugTraceCall() {
+	result := `{
+		"0xa95350d70b18fa29f6b5eb8d627ceeeee499340d": {"balance": "0x10", "nonce": 5},
+		"0x8d12a197cb00d4747a1fe03395095ce2a5cc6819": {"balance": "0x0", "code": "0x6080", "storage": {"0x00": "0x01"}}
+	}`
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "debug_traceCall")
+		s.paramsEqual(body, `[{"from": "0xa95350d70b18fa29f6b5eb8d627ceeeee499340d", "to": "0x8d12a197cb00d4747a1fe03395095ce2a5cc6819"}, "latest", {"tracer": "prestateTracer", "tracerConfig": {}}]`)
+	})
+
+	trace, err := s.rpc.DebugTraceCall(T{
+		From: "0xa95350d70b18fa29f6b5eb8d627ceeeee499340d",
+		To:   "0x8d12a197cb00d4747a1fe03395095ce2a5cc6819",
+	}, "latest", PrestateTracer(PrestateTracerConfig{}))
+	s.Require().Nil(err)
+
+	prestate, err := trace.Prestate()
+	s.Require().Nil(err)
+	s.Require().Len(prestate, 2)
+	sender := prestate["0xa95350d70b18fa29f6b5eb8d627ceeeee499340d"]
+	s.Require().Equal(int64(16), sender.Balance.Int64())
+	s.Require().Equal(5, sender.Nonce)
+	contract := prestate["0x8d12a197cb00d4747a1fe03395095ce2a5cc6819"]
+	s.Require().Equal("0x6080", contract.Code)
+	s.Require().Equal(map[string]string{"0x00": "0x01"}, contract.Storage)
+}
+
+func (s *EthRPCTestSuite) TestDebugTraceBlockByNumber() {
+	result := `[
+		{"txHash": "0xa72743a3608e2ae7b3d1cc1f0e3ceed9a1c78d803eba5f28d5d6908adfaa211c", "result": {
+			"pre": {"0xa95350d70b18fa29f6b5eb8d627ceeeee499340d": {"balance": "0x10", "nonce": 5}},
+			"post": {"0xa95350d70b18fa29f6b5eb8d627ceeeee499340d": {"balance": "0x8", "nonce": 6}}
+		}},
+		{"txHash": "0xf519ca0e9ceeb0405dfeb95544179f557e3221213f07e33709af7ced60ab61b9", "error": "execution timeout"}
+	]`
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "debug_traceBlockByNumber")
+		s.paramsEqual(body, `["0x4055d5", {"tracer": "prestateTracer", "tracerConfig": {"diffMode": true}}]`)
+	})
+
+	traces, err := s.rpc.DebugTraceBlockByNumber(4216277, PrestateTracer(PrestateTracerConfig{DiffMode: true}))
+	s.Require().Nil(err)
+	s.Require().Len(traces, 2)
+	s.Require().Equal("0xa72743a3608e2ae7b3d1cc1f0e3ceed9a1c78d803eba5f28d5d6908adfaa211c", traces[0].TxHash)
+
+	diff, err := traces[0].Result.PrestateDiff()
+	s.Require().Nil(err)
+	s.Require().Equal(6, diff.Post["0xa95350d70b18fa29f6b5eb8d627ceeeee499340d"].Nonce)
+	s.Require().Equal(int64(8), diff.Post["0xa95350d70b18fa29f6b5eb8d627ceeeee499340d"].Balance.Int64())
+
+	s.Require().Equal("execution timeout", traces[1].Error)
+	_, err = traces[1].Result.CallFrame()
+	s.Require().NotNil(err)
+}
+
+func (s *EthRPCTestSuite) TestDebugTraceBlockByHash() {
+	hash := "0x2bdda43f649c564642101fc990f569dd855e60f88bf83e931f509a92c62700f9"
+	tracer := "{data: [], fault: function(log) {}, step: function(log) {}, result: function() { return 42; }}"
+	s.registerResponse(`[{"result": 42}]`, func(body []byte) {
+		s.methodEqual(body, "debug_traceBlockByHash")
+		s.paramsEqual(body, fmt.Sprintf(`["%s", {"tracer": %q}]`, hash, tracer))
+	})
+
+	traces, err := s.rpc.DebugTraceBlockByHash(hash, TraceConfig{Tracer: tracer})
+	s.Require().Nil(err)
+	s.Require().Len(traces, 1)
+	s.Require().Equal("42", string(traces[0].Result))
+}
+
+func TestTraceMarshal(t *testing.T) {
+	trace := BlockTrace{TxHash: "0x1", Result: Trace(`{"type":"CALL"}`)}
+	data, err := trace.Result.MarshalJSON()
+	require.Nil(t, err)
+	require.Equal(t, `{"type":"CALL"}`, string(data))
+
+	frame, err := trace.Result.CallFrame()
+	require.Nil(t, err)
+	require.Equal(t, "CALL", frame.Type)
+	require.Nil(t, frame.Value)
+
+	_, err = Trace(`[]`).Prestate()
+	require.NotNil(t, err)
+
+	account := PrestateAccount{}
+	require.NotNil(t, account.UnmarshalJSON([]byte(`{"balance": "0x1", "nonce": "0x1"}`)))
+	require.Nil(t, account.UnmarshalJSON([]byte(`{"balance": "0x1"}`)))
+	require.Equal(t, int64(1), account.Balance.Int64())
+}
@@ -0,0 +1,309 @@
+package ethrpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// Payload statuses
+const (
+	PayloadStatusValid    = "VALID"
+	PayloadStatusInvalid  = "INVALID"
+	PayloadStatusSyncing  = "SYNCING"
+	PayloadStatusAccepted = "ACCEPTED"
+)
+
+// ExecutionPayload - engine API execution payload object.
+// Withdrawals are set since V2 (nil withdrawals of V1 are omitted, empty ones are sent), BlobGasUsed and ExcessBlobGas since V3.
+type ExecutionPayload struct {
+	ParentHash    string
+	FeeRecipient  string
+	StateRoot     string
+	ReceiptsRoot  string
+	LogsBloom     string
+	PrevRandao    string
+	BlockNumber   int
+	GasLimit      int
+	GasUsed       int
+	Timestamp     int
+	ExtraData     string
+	BaseFeePerGas big.Int
+	BlockHash     string
+	Transactions  []string
+	Withdrawals   []Withdrawal
+	BlobGasUsed   *int
+	ExcessBlobGas *int
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (p ExecutionPayload) MarshalJSON() ([]byte, error) {
+	if p.Transactions == nil {
+		p.Transactions = []string{}
+	}
+
+	proxy := (*proxyExecutionPayload)(unsafe.Pointer(&p))
+	if p.Withdrawals != nil && len(p.Withdrawals) == 0 {
+		// V2 and later payloads require withdrawals field even if it's empty
+		return json.Marshal(struct {
+			proxyExecutionPayload
+			Withdrawals []Withdrawal `json:"withdrawals"`
+		}{*proxy, p.Withdrawals})
+	}
+
+	return json.Marshal(proxy)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *ExecutionPayload) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyExecutionPayload)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return err
+	}
+
+	*p = *(*ExecutionPayload)(unsafe.Pointer(proxy))
+
+	return nil
+}
+
+// ExecutionPayloadEnvelope - result of engine_getPayloadV2 and later
+type ExecutionPayloadEnvelope struct {
+	ExecutionPayload      ExecutionPayload
+	BlockValue            *big.Int
+	BlobsBundle           *BlobsBundle
+	ShouldOverrideBuilder bool
+	ExecutionRequests     []string
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *ExecutionPayloadEnvelope) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyExecutionPayloadEnvelope)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return err
+	}
+
+	*e = *(*ExecutionPayloadEnvelope)(unsafe.Pointer(proxy))
+
+	return nil
+}
+
+// BlobsBundle - blobs with KZG commitments and proofs
+type BlobsBundle struct {
+	Commitments []string `json:"commitments"`
+	Proofs      []string `json:"proofs"`
+	Blobs       []string `json:"blobs"`
+}
+
+// BlobAndProof - blob with KZG proof
+type BlobAndProof struct {
+	Blob  string `json:"blob"`
+	Proof string `json:"proof"`
+}
+
+// ForkchoiceState - engine API fork choice state object
+type ForkchoiceState struct {
+	HeadBlockHash      string `json:"headBlockHash"`
+	SafeBlockHash      string `json:"safeBlockHash"`
+	FinalizedBlockHash string `json:"finalizedBlockHash"`
+}
+
+// PayloadAttributes - attributes of a payload to build.
+// Withdrawals are set since V2 (nil withdrawals of V1 are omitted, empty ones are sent), ParentBeaconBlockRoot since V3.
+type PayloadAttributes struct {
+	Timestamp             int
+	PrevRandao            string
+	SuggestedFeeRecipient string
+	Withdrawals           []Withdrawal
+	ParentBeaconBlockRoot string
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (a PayloadAttributes) MarshalJSON() ([]byte, error) {
+	proxy := (*proxyPayloadAttributes)(unsafe.Pointer(&a))
+	if a.Withdrawals != nil && len(a.Withdrawals) == 0 {
+		// V2 and later attributes require withdrawals field even if it's empty
+		return json.Marshal(struct {
+			proxyPayloadAttributes
+			Withdrawals []Withdrawal `json:"withdrawals"`
+		}{*proxy, a.Withdrawals})
+	}
+
+	return json.Marshal(proxy)
+}
+
+// PayloadStatus - result of payload validation
+type PayloadStatus struct {
+	Status          string `json:"status"`
+	LatestValidHash string `json:"latestValidHash"`
+	ValidationError string `json:"validationError"`
+}
+
+// ForkchoiceUpdatedResult - result of engine_forkchoiceUpdated
+type ForkchoiceUpdatedResult struct {
+	PayloadStatus PayloadStatus `json:"payloadStatus"`
+	PayloadID     string        `json:"payloadId"`
+}
+
+type proxyExecutionPayload struct {
+	ParentHash    string       `json:"parentHash"`
+	FeeRecipient  string       `json:"feeRecipient"`
+	StateRoot     string       `json:"stateRoot"`
+	ReceiptsRoot  string       `json:"receiptsRoot"`
+	LogsBloom     string       `json:"logsBloom"`
+	PrevRandao    string       `json:"prevRandao"`
+	BlockNumber   hexInt       `json:"blockNumber"`
+	GasLimit      hexInt       `json:"gasLimit"`
+	GasUsed       hexInt       `json:"gasUsed"`
+	Timestamp     hexInt       `json:"timestamp"`
+	ExtraData     string       `json:"extraData"`
+	BaseFeePerGas hexBig       `json:"baseFeePerGas"`
+	BlockHash     string       `json:"blockHash"`
+	Transactions  []string     `json:"transactions"`
+	Withdrawals   []Withdrawal `json:"withdrawals,omitempty"`
+	BlobGasUsed   *hexInt      `json:"blobGasUsed,omitempty"`
+	ExcessBlobGas *hexInt      `json:"excessBlobGas,omitempty"`
+}
+
+type proxyExecutionPayloadEnvelope struct {
+	ExecutionPayload      ExecutionPayload `json:"executionPayload"`
+	BlockValue            *hexBig          `json:"blockValue"`
+	BlobsBundle           *BlobsBundle     `json:"blobsBundle"`
+	ShouldOverrideBuilder bool             `json:"shouldOverrideBuilder"`
+	ExecutionRequests     []string         `json:"executionRequests"`
+}
+
+type proxyPayloadAttributes struct {
+	Timestamp             hexInt       `json:"timestamp"`
+	PrevRandao            string       `json:"prevRandao"`
+	SuggestedFeeRecipient string       `json:"suggestedFeeRecipient"`
+	Withdrawals           []Withdrawal `json:"withdrawals,omitempty"`
+	ParentBeaconBlockRoot string       `json:"parentBeaconBlockRoot,omitempty"`
+}
+
+// ParseJWTSecret parse hex encoded 32 bytes engine API secret
+func ParseJWTSecret(value string) ([]byte, error) {
+	secret, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(value), "0x"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(secret) != 32 {
+		return nil, fmt.Errorf("invalid jwt secret length %d, expected 32 bytes", len(secret))
+	}
+
+	return secret, nil
+}
+
+// newJWTToken returns HS256 signed token with issued at claim
+func newJWTToken(secret []byte, issuedAt time.Time) string {
+	encoding := base64.RawURLEncoding
+	header := encoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	claims := encoding.EncodeToString([]byte(fmt.Sprintf(`{"iat":%d}`, issuedAt.Unix())))
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(header + "." + claims))
+
+	return header + "." + claims + "." + encoding.EncodeToString(mac.Sum(nil))
+}
+
+// EngineExchangeCapabilities exchanges lists of supported engine API methods.
+func (rpc *EthRPC) EngineExchangeCapabilities(capabilities []string) ([]string, error) {
+	result := []string{}
+
+	err := rpc.call("engine_exchangeCapabilities", &result, capabilities)
+	return result, err
+}
+
+// EngineNewPayloadV1 validates and imports a Paris execution payload.
+func (rpc *EthRPC) EngineNewPayloadV1(payload ExecutionPayload) (*PayloadStatus, error) {
+	return rpc.newPayload("engine_newPayloadV1", payload)
+}
+
+// EngineNewPayloadV2 validates and imports a Shanghai execution payload.
+func (rpc *EthRPC) EngineNewPayloadV2(payload ExecutionPayload) (*PayloadStatus, error) {
+	return rpc.newPayload("engine_newPayloadV2", payload)
+}
+
+// EngineNewPayloadV3 validates and imports a Cancun execution payload.
+func (rpc *EthRPC) EngineNewPayloadV3(payload ExecutionPayload, versionedHashes []string, parentBeaconBlockRoot string) (*PayloadStatus, error) {
+	return rpc.newPayload("engine_newPayloadV3", payload, versionedHashes, parentBeaconBlockRoot)
+}
+
+// EngineNewPayloadV4 validates and imports a Prague execution payload.
+func (rpc *EthRPC) EngineNewPayloadV4(payload ExecutionPayload, versionedHashes []string, parentBeaconBlockRoot string, executionRequests []string) (*PayloadStatus, error) {
+	return rpc.newPayload("engine_newPayloadV4", payload, versionedHashes, parentBeaconBlockRoot, executionRequests)
+}
+
+func (rpc *EthRPC) newPayload(method string, params ...interface{}) (*PayloadStatus, error) {
+	status := new(PayloadStatus)
+
+	err := rpc.call(method, status, params...)
+	return status, err
+}
+
+// EngineForkchoiceUpdatedV1 updates the fork choice state and optionally starts building a Paris payload.
+func (rpc *EthRPC) EngineForkchoiceUpdatedV1(state ForkchoiceState, attributes *PayloadAttributes) (*ForkchoiceUpdatedResult, error) {
+	return rpc.forkchoiceUpdated("engine_forkchoiceUpdatedV1", state, attributes)
+}
+
+// EngineForkchoiceUpdatedV2 updates the fork choice state and optionally starts building a Shanghai payload.
+func (rpc *EthRPC) EngineForkchoiceUpdatedV2(state ForkchoiceState, attributes *PayloadAttributes) (*ForkchoiceUpdatedResult, error) {
+	return rpc.forkchoiceUpdated("engine_forkchoiceUpdatedV2", state, attributes)
+}
+
+// EngineForkchoiceUpdatedV3 updates the fork choice state and optionally starts building a Cancun or later payload.
+func (rpc *EthRPC) EngineForkchoiceUpdatedV3(state ForkchoiceState, attributes *PayloadAttributes) (*ForkchoiceUpdatedResult, error) {
+	return rpc.forkchoiceUpdated("engine_forkchoiceUpdatedV3", state, attributes)
+}
+
+func (rpc *EthRPC) forkchoiceUpdated(method string, state ForkchoiceState, attributes *PayloadAttributes) (*ForkchoiceUpdatedResult, error) {
+	result := new(ForkchoiceUpdatedResult)
+
+	err := rpc.call(method, result, state, attributes)
+	return result, err
+}
+
+// EngineGetPayloadV1 returns a Paris payload built by the node.
+func (rpc *EthRPC) EngineGetPayloadV1(payloadID string) (*ExecutionPayload, error) {
+	payload := new(ExecutionPayload)
+
+	err := rpc.call("engine_getPayloadV1", payload, payloadID)
+	return payload, err
+}
+
+// EngineGetPayloadV2 returns a Shanghai payload built by the node with its block value.
+func (rpc *EthRPC) EngineGetPayloadV2(payloadID string) (*ExecutionPayloadEnvelope, error) {
+	return rpc.getPayload("engine_getPayloadV2", payloadID)
+}
+
+// EngineGetPayloadV3 returns a Cancun payload built by the node with its block value and blobs bundle.
+func (rpc *EthRPC) EngineGetPayloadV3(payloadID string) (*ExecutionPayloadEnvelope, error) {
+	return rpc.getPayload("engine_getPayloadV3", payloadID)
+}
+
+// EngineGetPayloadV4 returns a Prague payload built by the node with its block value, blobs bundle and execution requests.
+func (rpc *EthRPC) EngineGetPayloadV4(payloadID string) (*ExecutionPayloadEnvelope, error) {
+	return rpc.getPayload("engine_getPayloadV4", payloadID)
+}
+
+func (rpc *EthRPC) getPayload(method string, payloadID string) (*ExecutionPayloadEnvelope, error) {
+	envelope := new(ExecutionPayloadEnvelope)
+
+	err := rpc.call(method, envelope, payloadID)
+	return envelope, err
+}
+
+// EngineGetBlobsV1 returns blobs and proofs from the blob pool by versioned hashes, missing blobs are nil.
+func (rpc *EthRPC) EngineGetBlobsV1(versionedHashes []string) ([]*BlobAndProof, error) {
+	blobs := []*BlobAndProof{}
+
+	err := rpc.call("engine_getBlobsV1", &blobs, versionedHashes)
+	return blobs, err
+}
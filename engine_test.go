@@ -0,0 +1,311 @@
+package ethrpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+const executionPayloadV3 = `{
+	"parentHash": "0x3b8fb240d288781d4aac94d3fd16809ee413bc99294a085798a589dae51ddd4a",
+	"feeRecipient": "0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b",
+	"stateRoot": "0xca3149fa9e37db08d1cd49c9061db1002ef1cd58db2210f2115c8c989b2bdf45",
+	"receiptsRoot": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+	"logsBloom": "0x00",
+	"prevRandao": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"blockNumber": "0x1",
+	"gasLimit": "0x1c9c380",
+	"gasUsed": "0x0",
+	"timestamp": "0x5",
+	"extraData": "0x",
+	"baseFeePerGas": "0x7",
+	"blockHash": "0x6359b8381a370e2f54072a5784ddd78b6ed024991558c511d4452eb4f6ac898c",
+	"transactions": [],
+	"withdrawals": [{"index": "0x0", "validatorIndex": "0x10", "address": "0x00000000000000000000000000000000000010f0", "amount": "0x1"}],
+	"blobGasUsed": "0x0",
+	"excessBlobGas": "0x0"
+}`
+
+func (s *EthRPCTestSuite) TestEngineExchangeCapabilities() {
+	s.registerResponse(`["engine_newPayloadV1", "engine_newPayloadV2"]`, func(body []byte) {
+		s.methodEqual(body, "engine_exchangeCapabilities")
+		s.paramsEqual(body, `[["engine_newPayloadV1"]]`)
+	})
+
+	capabilities, err := s.rpc.EngineExchangeCapabilities([]string{"engine_newPayloadV1"})
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"engine_newPayloadV1", "engine_newPayloadV2"}, capabilities)
+}
+
+func (s *EthRPCTestSuite) TestEngineNewPayload() {
+	payload := ExecutionPayload{}
+	s.Require().Nil(json.Unmarshal([]byte(executionPayloadV3), &payload))
+
+	result := `{"status": "VALID", "latestValidHash": "0x6359b8381a370e2f54072a5784ddd78b6ed024991558c511d4452eb4f6ac898c", "validationError": null}`
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "engine_newPayloadV3")
+		s.paramsEqual(body, fmt.Sprintf(`[%s, [], "0x0000000000000000000000000000000000000000000000000000000000000001"]`, executionPayloadV3))
+	})
+
+	status, err := s.rpc.EngineNewPayloadV3(payload, []string{}, "0x0000000000000000000000000000000000000000000000000000000000000001")
+	s.Require().Nil(err)
+	s.Require().Equal(&PayloadStatus{
+		Status:          PayloadStatusValid,
+		LatestValidHash: "0x6359b8381a370e2f54072a5784ddd78b6ed024991558c511d4452eb4f6ac898c",
+	}, status)
+
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "engine_newPayloadV4")
+		s.paramsEqual(body, fmt.Sprintf(`[%s, [], "0x00", ["0x01"]]`, executionPayloadV3))
+	})
+	_, err = s.rpc.EngineNewPayloadV4(payload, []string{}, "0x00", []string{"0x01"})
+	s.Require().Nil(err)
+
+	// V1 payload has no withdrawals and blob fields
+	payload.Withdrawals = nil
+	payload.BlobGasUsed = nil
+	payload.ExcessBlobGas = nil
+	payload.Transactions = nil
+	s.registerResponse(`{"status": "SYNCING"}`, func(body []byte) {
+		s.methodEqual(body, "engine_newPayloadV1")
+		params := []map[string]interface{}{}
+		s.Require().Nil(json.Unmarshal(s.getParams(body), &params))
+		s.Require().NotContains(params[0], "withdrawals")
+		s.Require().NotContains(params[0], "blobGasUsed")
+		s.Require().Equal([]interface{}{}, params[0]["transactions"])
+		s.Require().Equal("0x7", params[0]["baseFeePerGas"])
+	})
+	status, err = s.rpc.EngineNewPayloadV1(payload)
+	s.Require().Nil(err)
+	s.Require().Equal(PayloadStatusSyncing, status.Status)
+
+	// V2 payload without withdrawals has empty list
+	payload.Withdrawals = []Withdrawal{}
+	s.registerResponse(`{"status": "ACCEPTED"}`, func(body []byte) {
+		s.methodEqual(body, "engine_newPayloadV2")
+		params := []map[string]interface{}{}
+		s.Require().Nil(json.Unmarshal(s.getParams(body), &params))
+		s.Require().Equal([]interface{}{}, params[0]["withdrawals"])
+	})
+	status, err = s.rpc.EngineNewPayloadV2(payload)
+	s.Require().Nil(err)
+	s.Require().Equal(PayloadStatusAccepted, status.Status)
+}
+
+func (s *EthRPCTestSuite) TestEngineForkchoiceUpdated() {
+	state := ForkchoiceState{
+		HeadBlockHash:      "0x3b8fb240d288781d4aac94d3fd16809ee413bc99294a085798a589dae51ddd4a",
+		SafeBlockHash:      "0x3b8fb240d288781d4aac94d3fd16809ee413bc99294a085798a589dae51ddd4a",
+		FinalizedBlockHash: "0x0000000000000000000000000000000000000000000000000000000000000000",
+	}
+	stateJSON := `{
+		"headBlockHash": "0x3b8fb240d288781d4aac94d3fd16809ee413bc99294a085798a589dae51ddd4a",
+		"safeBlockHash": "0x3b8fb240d288781d4aac94d3fd16809ee413bc99294a085798a589dae51ddd4a",
+		"finalizedBlockHash": "0x0000000000000000000000000000000000000000000000000000000000000000"
+	}`
+
+	s.registerResponse(`{"payloadStatus": {"status": "VALID", "latestValidHash": "0x3b8fb240d288781d4aac94d3fd16809ee413bc99294a085798a589dae51ddd4a"}, "payloadId": null}`, func(body []byte) {
+		s.methodEqual(body, "engine_forkchoiceUpdatedV1")
+		s.paramsEqual(body, fmt.Sprintf(`[%s, null]`, stateJSON))
+	})
+	result, err := s.rpc.EngineForkchoiceUpdatedV1(state, nil)
+	s.Require().Nil(err)
+	s.Require().Equal(PayloadStatusValid, result.PayloadStatus.Status)
+	s.Require().Equal("", result.PayloadID)
+
+	// V1 attributes have no withdrawals
+	s.registerResponse(`{"payloadStatus": {"status": "VALID"}, "payloadId": "0xa247243752eb10b4"}`, func(body []byte) {
+		s.methodEqual(body, "engine_forkchoiceUpdatedV1")
+		s.paramsEqual(body, fmt.Sprintf(`[%s, {
+			"timestamp": "0x5",
+			"prevRandao": "0x00",
+			"suggestedFeeRecipient": "0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b"
+		}]`, stateJSON))
+	})
+	_, err = s.rpc.EngineForkchoiceUpdatedV1(state, &PayloadAttributes{
+		Timestamp:             5,
+		PrevRandao:            "0x00",
+		SuggestedFeeRecipient: "0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b",
+	})
+	s.Require().Nil(err)
+
+	s.registerResponse(`{"payloadStatus": {"status": "VALID"}, "payloadId": "0xa247243752eb10b4"}`, func(body []byte) {
+		s.methodEqual(body, "engine_forkchoiceUpdatedV2")
+		s.paramsEqual(body, fmt.Sprintf(`[%s, {
+			"timestamp": "0x5",
+			"prevRandao": "0x00",
+			"suggestedFeeRecipient": "0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b",
+			"withdrawals": [{"index": "0x0", "validatorIndex": "0x10", "address": "0x00000000000000000000000000000000000010f0", "amount": "0x1"}]
+		}]`, stateJSON))
+	})
+	result, err = s.rpc.EngineForkchoiceUpdatedV2(state, &PayloadAttributes{
+		Timestamp:             5,
+		PrevRandao:            "0x00",
+		SuggestedFeeRecipient: "0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b",
+		Withdrawals:           []Withdrawal{{Index: 0, ValidatorIndex: 16, Address: "0x00000000000000000000000000000000000010f0", Amount: 1}},
+	})
+	s.Require().Nil(err)
+	s.Require().Equal("0xa247243752eb10b4", result.PayloadID)
+
+	s.registerResponse(`{"payloadStatus": {"status": "INVALID", "validationError": "invalid timestamp"}}`, func(body []byte) {
+		s.methodEqual(body, "engine_forkchoiceUpdatedV3")
+		s.paramsEqual(body, fmt.Sprintf(`[%s, {
+			"timestamp": "0x5",
+			"prevRandao": "0x00",
+			"suggestedFeeRecipient": "0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b",
+			"withdrawals": [],
+			"parentBeaconBlockRoot": "0x01"
+		}]`, stateJSON))
+	})
+	result, err = s.rpc.EngineForkchoiceUpdatedV3(state, &PayloadAttributes{
+		Timestamp:             5,
+		PrevRandao:            "0x00",
+		SuggestedFeeRecipient: "0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b",
+		Withdrawals:           []Withdrawal{},
+		ParentBeaconBlockRoot: "0x01",
+	})
+	s.Require().Nil(err)
+	s.Require().Equal(PayloadStatusInvalid, result.PayloadStatus.Status)
+	s.Require().Equal("invalid timestamp", result.PayloadStatus.ValidationError)
+}
+
+func (s *EthRPCTestSuite) TestEngineGetPayload() {
+	s.registerResponse(executionPayloadV3, func(body []byte) {
+		s.methodEqual(body, "engine_getPayloadV1")
+		s.paramsEqual(body, `["0xa247243752eb10b4"]`)
+	})
+	payload, err := s.rpc.EngineGetPayloadV1("0xa247243752eb10b4")
+	s.Require().Nil(err)
+	s.Require().Equal(1, payload.BlockNumber)
+	s.Require().Equal(30000000, payload.GasLimit)
+	s.Require().Equal(int64(7), payload.BaseFeePerGas.Int64())
+	s.Require().Equal([]Withdrawal{{Index: 0, ValidatorIndex: 16, Address: "0x00000000000000000000000000000000000010f0", Amount: 1}}, payload.Withdrawals)
+	s.Require().Equal(0, *payload.BlobGasUsed)
+
+	result := fmt.Sprintf(`{
+		"executionPayload": %s,
+		"blockValue": "0x1bc16d674ec80000",
+		"blobsBundle": {"commitments": ["0x01"], "proofs": ["0x02"], "blobs": ["0x03"]},
+		"shouldOverrideBuilder": true,
+		"executionRequests": ["0x00"]
+	}`, executionPayloadV3)
+	methods := map[string]func(string) (*ExecutionPayloadEnvelope, error){
+		"engine_getPayloadV2": s.rpc.EngineGetPayloadV2,
+		"engine_getPayloadV3": s.rpc.EngineGetPayloadV3,
+		"engine_getPayloadV4": s.rpc.EngineGetPayloadV4,
+	}
+	for method, call := range methods {
+		s.registerResponse(result, func(body []byte) {
+			s.methodEqual(body, method)
+			s.paramsEqual(body, `["0xa247243752eb10b4"]`)
+		})
+
+		envelope, err := call("0xa247243752eb10b4")
+		s.Require().Nil(err)
+		s.Require().Equal("0x6359b8381a370e2f54072a5784ddd78b6ed024991558c511d4452eb4f6ac898c", envelope.ExecutionPayload.BlockHash)
+		s.Require().Equal("2000000000000000000", envelope.BlockValue.String())
+		s.Require().Equal(&BlobsBundle{Commitments: []string{"0x01"}, Proofs: []string{"0x02"}, Blobs: []string{"0x03"}}, envelope.BlobsBundle)
+		s.Require().True(envelope.ShouldOverrideBuilder)
+		s.Require().Equal([]string{"0x00"}, envelope.ExecutionRequests)
+	}
+}
+
+func (s *EthRPCTestSuite) TestEngineGetBlobsV1() {
+	s.registerResponse(`[{"blob": "0x01", "proof": "0x02"}, null]`, func(body []byte) {
+		s.methodEqual(body, "engine_getBlobsV1")
+		s.paramsEqual(body, `[["0x0101", "0x0102"]]`)
+	})
+
+	blobs, err := s.rpc.EngineGetBlobsV1([]string{"0x0101", "0x0102"})
+	s.Require().Nil(err)
+	s.Require().Equal([]*BlobAndProof{{Blob: "0x01", Proof: "0x02"}, nil}, blobs)
+}
+
+func (s *EthRPCTestSuite) TestJWTAuthentication() {
+	secret, err := ParseJWTSecret("0x7365637265747365637265747365637265747365637265747365637265747365")
+	s.Require().Nil(err)
+	rpc := New(s.rpc.url, WithJWTSecret(secret))
+
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		s.Require().Equal("application/json", request.Header.Get("Content-Type"))
+
+		token := strings.TrimPrefix(request.Header.Get("Authorization"), "Bearer ")
+		parts := strings.Split(token, ".")
+		s.Require().Len(parts, 3)
+
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(parts[0] + "." + parts[1]))
+		s.Require().Equal(base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), parts[2])
+
+		claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+		s.Require().Nil(err)
+		iat := struct {
+			IssuedAt int64 `json:"iat"`
+		}{}
+		s.Require().Nil(json.Unmarshal(claims, &iat))
+		s.Require().InDelta(time.Now().Unix(), iat.IssuedAt, 5)
+
+		return httpmock.NewStringResponse(200, `{"jsonrpc": "2.0", "id": 1, "result": []}`), nil
+	})
+
+	_, err = rpc.EngineExchangeCapabilities([]string{})
+	s.Require().Nil(err)
+
+	// Test client without Do method
+	rpc = New(s.rpc.url, WithJWTSecret(secret), WithHttpClient(postOnlyClient{}))
+	_, err = rpc.EngineExchangeCapabilities([]string{})
+	s.Require().NotNil(err)
+}
+
+func (s *EthRPCTestSuite) getParams(body []byte) []byte {
+	request := struct {
+		Params json.RawMessage `json:"params"`
+	}{}
+	s.Require().Nil(json.Unmarshal(body, &request))
+
+	return request.Params
+}
+
+type postOnlyClient struct{}
+
+func (postOnlyClient) Post(url string, contentType string, body io.Reader) (*http.Response, error) {
+	return http.DefaultClient.Post(url, contentType, body)
+}
+
+func TestParseJWTSecret(t *testing.T) {
+	secret, err := ParseJWTSecret(" 7365637265747365637265747365637265747365637265747365637265747365\n")
+	require.Nil(t, err)
+	require.Equal(t, []byte("secretsecretsecretsecretsecretse"), secret)
+
+	_, err = ParseJWTSecret("0x1234")
+	require.NotNil(t, err)
+
+	_, err = ParseJWTSecret("zz")
+	require.NotNil(t, err)
+}
+
+func TestNewJWTToken(t *testing.T) {
+	token := newJWTToken([]byte("secretsecretsecretsecretsecretse"), time.Unix(1700000000, 0))
+	require.Equal(t, "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJpYXQiOjE3MDAwMDAwMDB9", token[:strings.LastIndex(token, ".")])
+}
+
+func TestWithdrawalMarshal(t *testing.T) {
+	data, err := json.Marshal(Withdrawal{Index: 1, ValidatorIndex: 2, Address: "0x01", Amount: 3})
+	require.Nil(t, err)
+	require.JSONEq(t, `{"index": "0x1", "validatorIndex": "0x2", "address": "0x01", "amount": "0x3"}`, string(data))
+
+	withdrawal := Withdrawal{}
+	require.NotNil(t, json.Unmarshal([]byte(`{"index": "zz"}`), &withdrawal))
+	require.NotNil(t, json.Unmarshal([]byte(`[]`), &ExecutionPayloadEnvelope{}))
+	require.NotNil(t, json.Unmarshal([]byte(`[]`), &ExecutionPayload{}))
+}
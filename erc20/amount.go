@@ -0,0 +1,66 @@
+package erc20
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// FormatAmount returns decimal representation of token amount like "1.5" for 1500000 with 6 decimals
+func FormatAmount(amount *big.Int, decimals uint8) string {
+	if amount == nil {
+		return "0"
+	}
+
+	digits := new(big.Int).Abs(amount).String()
+	if len(digits) <= int(decimals) {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+
+	integer := digits[:len(digits)-int(decimals)]
+	fraction := strings.TrimRight(digits[len(digits)-int(decimals):], "0")
+
+	result := integer
+	if fraction != "" {
+		result += "." + fraction
+	}
+	if amount.Sign() < 0 {
+		result = "-" + result
+	}
+
+	return result
+}
+
+// ParseAmount returns token amount of decimal representation like "1.5", it's an error if amount has more than decimals digits of fraction
+func ParseAmount(value string, decimals uint8) (*big.Int, error) {
+	integer, fraction, _ := strings.Cut(value, ".")
+	sign := ""
+	if strings.HasPrefix(integer, "-") {
+		sign, integer = "-", integer[1:]
+	}
+	if (integer == "" && fraction == "") || !isDigits(integer) || !isDigits(fraction) || strings.HasSuffix(value, ".") {
+		return nil, fmt.Errorf("invalid amount %s", value)
+	}
+
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > int(decimals) {
+		return nil, fmt.Errorf("amount %s has more than %d decimals", value, decimals)
+	}
+
+	amount, ok := new(big.Int).SetString(sign+integer+fraction+strings.Repeat("0", int(decimals)-len(fraction)), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %s", value)
+	}
+
+	return amount, nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
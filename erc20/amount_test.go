@@ -0,0 +1,59 @@
+package erc20
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatAmount(t *testing.T) {
+	for _, test := range []struct {
+		amount   string
+		decimals uint8
+		expected string
+	}{
+		{"0", 18, "0"},
+		{"1", 0, "1"},
+		{"1500000", 6, "1.5"},
+		{"1000000000000000000", 18, "1"},
+		{"1", 18, "0.000000000000000001"},
+		{"123456789", 4, "12345.6789"},
+		{"-1500000", 6, "-1.5"},
+		{"-1", 2, "-0.01"},
+		{"115792089237316195423570985008687907853269984665640564039457584007913129639935", 18, "115792089237316195423570985008687907853269984665640564039457.584007913129639935"},
+	} {
+		amount, ok := new(big.Int).SetString(test.amount, 10)
+		require.True(t, ok)
+		require.Equal(t, test.expected, FormatAmount(amount, test.decimals))
+
+		parsed, err := ParseAmount(test.expected, test.decimals)
+		require.Nil(t, err)
+		require.Equal(t, amount.String(), parsed.String())
+	}
+
+	require.Equal(t, "0", FormatAmount(nil, 18))
+}
+
+func TestParseAmount(t *testing.T) {
+	for _, test := range []struct {
+		value    string
+		decimals uint8
+		expected string
+	}{
+		{"1.50", 6, "1500000"},
+		{".5", 1, "5"},
+		{"-.5", 1, "-5"},
+		{"001", 2, "100"},
+		{"1.000", 0, "1"},
+	} {
+		amount, err := ParseAmount(test.value, test.decimals)
+		require.Nil(t, err, test.value)
+		require.Equal(t, test.expected, amount.String())
+	}
+
+	for _, value := range []string{"", ".", "-", "1.", "1.2.3", "a", "1e18", "+1", "--1", "1.001"} {
+		_, err := ParseAmount(value, 2)
+		require.NotNil(t, err, value)
+	}
+}
@@ -0,0 +1,152 @@
+// Package erc20 implements client of ERC-20 tokens.
+package erc20
+
+import (
+	"bytes"
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/onrik/ethrpc"
+	"github.com/onrik/ethrpc/abi"
+)
+
+// ABI - ERC-20 token interface
+var ABI = abi.MustParse(`[
+	{"type": "function", "name": "name", "stateMutability": "view", "inputs": [], "outputs": [{"name": "", "type": "string"}]},
+	{"type": "function", "name": "symbol", "stateMutability": "view", "inputs": [], "outputs": [{"name": "", "type": "string"}]},
+	{"type": "function", "name": "decimals", "stateMutability": "view", "inputs": [], "outputs": [{"name": "", "type": "uint8"}]},
+	{"type": "function", "name": "totalSupply", "stateMutability": "view", "inputs": [], "outputs": [{"name": "", "type": "uint256"}]},
+	{"type": "function", "name": "balanceOf", "stateMutability": "view", "inputs": [{"name": "account", "type": "address"}], "outputs": [{"name": "", "type": "uint256"}]},
+	{"type": "function", "name": "allowance", "stateMutability": "view", "inputs": [{"name": "owner", "type": "address"}, {"name": "spender", "type": "address"}], "outputs": [{"name": "", "type": "uint256"}]},
+	{"type": "function", "name": "transfer", "stateMutability": "nonpayable", "inputs": [{"name": "to", "type": "address"}, {"name": "value", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}]},
+	{"type": "function", "name": "approve", "stateMutability": "nonpayable", "inputs": [{"name": "spender", "type": "address"}, {"name": "value", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}]},
+	{"type": "function", "name": "transferFrom", "stateMutability": "nonpayable", "inputs": [{"name": "from", "type": "address"}, {"name": "to", "type": "address"}, {"name": "value", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}]},
+	{"type": "event", "name": "Transfer", "anonymous": false, "inputs": [
+		{"name": "from", "type": "address", "indexed": true},
+		{"name": "to", "type": "address", "indexed": true},
+		{"name": "value", "type": "uint256", "indexed": false}
+	]},
+	{"type": "event", "name": "Approval", "anonymous": false, "inputs": [
+		{"name": "owner", "type": "address", "indexed": true},
+		{"name": "spender", "type": "address", "indexed": true},
+		{"name": "value", "type": "uint256", "indexed": false}
+	]}
+]`)
+
+// Token - client of ERC-20 token contract
+type Token struct {
+	address string
+	caller  abi.Caller
+	block   string
+}
+
+// New returns client of token at address, caller is used for view methods, *ethrpc.EthRPC for example
+func New(address string, caller abi.Caller) *Token {
+	return &Token{address: address, caller: caller, block: "latest"}
+}
+
+// Address returns address of token contract
+func (token *Token) Address() string {
+	return token.address
+}
+
+// WithBlock returns copy of client making calls at block number or tag, "latest" is used by default
+func (token *Token) WithBlock(block string) *Token {
+	copied := *token
+	copied.block = block
+	return &copied
+}
+
+// Name returns name of token, bytes32 names of legacy tokens are supported
+func (token *Token) Name() (string, error) {
+	return token.callString("name")
+}
+
+// Symbol returns symbol of token, bytes32 symbols of legacy tokens like MKR are supported
+func (token *Token) Symbol() (string, error) {
+	return token.callString("symbol")
+}
+
+// Decimals returns number of decimals of token amounts
+func (token *Token) Decimals() (uint8, error) {
+	values, err := ABI.Call(token.caller, token.address, token.block, "decimals")
+	if err != nil {
+		return 0, err
+	}
+
+	return values[0].(uint8), nil
+}
+
+// TotalSupply returns total supply of token
+func (token *Token) TotalSupply() (*big.Int, error) {
+	return token.callBigInt("totalSupply")
+}
+
+// BalanceOf returns token balance of account
+func (token *Token) BalanceOf(account string) (*big.Int, error) {
+	return token.callBigInt("balanceOf", account)
+}
+
+// Allowance returns amount which spender is allowed to transfer from owner account
+func (token *Token) Allowance(owner, spender string) (*big.Int, error) {
+	return token.callBigInt("allowance", owner, spender)
+}
+
+// Transfer returns transaction transferring value to address
+func (token *Token) Transfer(to string, value *big.Int) (ethrpc.T, error) {
+	return ABI.Transaction(token.address, "transfer", to, value)
+}
+
+// Approve returns transaction allowing spender to transfer value from sender account
+func (token *Token) Approve(spender string, value *big.Int) (ethrpc.T, error) {
+	return ABI.Transaction(token.address, "approve", spender, value)
+}
+
+// TransferFrom returns transaction transferring value from allowed account to address
+func (token *Token) TransferFrom(from, to string, value *big.Int) (ethrpc.T, error) {
+	return ABI.Transaction(token.address, "transferFrom", from, to, value)
+}
+
+func (token *Token) callBigInt(method string, args ...interface{}) (*big.Int, error) {
+	values, err := ABI.Call(token.caller, token.address, token.block, method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return values[0].(*big.Int), nil
+}
+
+func (token *Token) callString(method string) (string, error) {
+	transaction, err := ABI.Transaction(token.address, method)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := token.caller.EthCall(transaction, token.block)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := abi.DecodeHex(result)
+	if err != nil {
+		return "", err
+	}
+
+	return decodeString(method, data)
+}
+
+// decodeString decodes string result or bytes32 result of legacy tokens
+func decodeString(method string, data []byte) (string, error) {
+	values, err := ABI.DecodeOutput(method, data)
+	if err == nil {
+		return values[0].(string), nil
+	}
+
+	if len(data) == 32 {
+		if value := bytes.TrimRight(data, "\x00"); utf8.Valid(value) {
+			return string(value), nil
+		}
+	}
+
+	return "", err
+}
@@ -0,0 +1,143 @@
+package erc20
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/onrik/ethrpc"
+	"github.com/onrik/ethrpc/abi"
+	"github.com/onrik/ethrpc/internal/rpctest"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	tokenAddress = "0x6b175474e89094c44da98b954eedeac495271d0f"
+	owner        = "0x1111111111111111111111111111111111111111"
+	spender      = "0x2222222222222222222222222222222222222222"
+)
+
+// testCaller returns caller with results of token methods by selector
+func testCaller(results map[string]string) *rpctest.Caller {
+	return &rpctest.Caller{Handler: func(transaction ethrpc.T) (string, error) {
+		data, err := abi.DecodeHex(transaction.Data)
+		if err != nil {
+			return "", err
+		}
+		method, err := ABI.MethodByID(data)
+		if err != nil {
+			return "", err
+		}
+
+		return results[method.Name], nil
+	}}
+}
+
+func encode(t *testing.T, method string, values ...interface{}) string {
+	data, err := ABI.Methods[method].Outputs.Encode(values...)
+	require.Nil(t, err)
+
+	return "0x" + hex.EncodeToString(data)
+}
+
+func TestToken(t *testing.T) {
+	caller := testCaller(map[string]string{
+		"name":        encode(t, "name", "Dai Stablecoin"),
+		"symbol":      encode(t, "symbol", "DAI"),
+		"decimals":    encode(t, "decimals", 18),
+		"totalSupply": encode(t, "totalSupply", big.NewInt(1000)),
+		"balanceOf":   encode(t, "balanceOf", big.NewInt(100)),
+		"allowance":   encode(t, "allowance", big.NewInt(50)),
+	})
+	token := New(tokenAddress, caller)
+	require.Equal(t, tokenAddress, token.Address())
+
+	name, err := token.Name()
+	require.Nil(t, err)
+	require.Equal(t, "Dai Stablecoin", name)
+
+	symbol, err := token.Symbol()
+	require.Nil(t, err)
+	require.Equal(t, "DAI", symbol)
+
+	decimals, err := token.Decimals()
+	require.Nil(t, err)
+	require.Equal(t, uint8(18), decimals)
+
+	supply, err := token.TotalSupply()
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(1000), supply)
+
+	balance, err := token.BalanceOf(owner)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(100), balance)
+	require.Equal(t, "0x70a08231000000000000000000000000"+owner[2:], caller.Transaction.Data)
+	require.Equal(t, tokenAddress, caller.Transaction.To)
+	require.Equal(t, "latest", caller.Block)
+
+	_, err = token.WithBlock("pending").Name()
+	require.Nil(t, err)
+	require.Equal(t, "pending", caller.Block)
+	_, err = token.WithBlock("0x10").BalanceOf(owner)
+	require.Nil(t, err)
+	require.Equal(t, "0x10", caller.Block)
+
+	allowance, err := token.Allowance(owner, spender)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(50), allowance)
+	require.Equal(t, "0xdd62ed3e000000000000000000000000"+owner[2:]+"000000000000000000000000"+spender[2:], caller.Transaction.Data)
+
+	caller.Err = errors.New("error")
+	for _, f := range []func() error{
+		func() error { _, err := token.Name(); return err },
+		func() error { _, err := token.Decimals(); return err },
+		func() error { _, err := token.BalanceOf(owner); return err },
+	} {
+		require.Equal(t, caller.Err, f())
+	}
+}
+
+func TestTokenLegacy(t *testing.T) {
+	// MKR returns bytes32 name and symbol
+	results := map[string]string{
+		"name":   "0x4d616b6572000000000000000000000000000000000000000000000000000000",
+		"symbol": "0x4d4b520000000000000000000000000000000000000000000000000000000000",
+	}
+	token := New("0x9f8f72aa9304c8b593d555f12ef6589cc3a579a2", testCaller(results))
+
+	name, err := token.Name()
+	require.Nil(t, err)
+	require.Equal(t, "Maker", name)
+
+	symbol, err := token.Symbol()
+	require.Nil(t, err)
+	require.Equal(t, "MKR", symbol)
+
+	for _, result := range []string{"0x", "0x01", "0xff" + strings.Repeat("0", 62), "0xzz"} {
+		results["symbol"] = result
+		_, err = token.Symbol()
+		require.NotNil(t, err, result)
+	}
+}
+
+func TestTokenTransactions(t *testing.T) {
+	token := New(tokenAddress, nil)
+	value := "0000000000000000000000000000000000000000000000000000000000000064"
+
+	transaction, err := token.Transfer(spender, big.NewInt(100))
+	require.Nil(t, err)
+	require.Equal(t, ethrpc.T{To: tokenAddress, Data: "0xa9059cbb000000000000000000000000" + spender[2:] + value}, transaction)
+
+	transaction, err = token.Approve(spender, big.NewInt(100))
+	require.Nil(t, err)
+	require.Equal(t, ethrpc.T{To: tokenAddress, Data: "0x095ea7b3000000000000000000000000" + spender[2:] + value}, transaction)
+
+	transaction, err = token.TransferFrom(owner, spender, big.NewInt(100))
+	require.Nil(t, err)
+	require.Equal(t, ethrpc.T{To: tokenAddress, Data: "0x23b872dd000000000000000000000000" + owner[2:] + "000000000000000000000000" + spender[2:] + value}, transaction)
+
+	_, err = token.Transfer("0x01", big.NewInt(100))
+	require.NotNil(t, err)
+}
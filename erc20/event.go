@@ -0,0 +1,125 @@
+package erc20
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/onrik/ethrpc"
+	"github.com/onrik/ethrpc/abi"
+)
+
+// TransferEvent - Transfer(address,address,uint256) event
+type TransferEvent struct {
+	Token string
+	From  string
+	To    string
+	Value *big.Int
+	Log   ethrpc.Log
+}
+
+// ApprovalEvent - Approval(address,address,uint256) event
+type ApprovalEvent struct {
+	Token   string
+	Owner   string
+	Spender string
+	Value   *big.Int
+	Log     ethrpc.Log
+}
+
+// DecodeTransfer decodes Transfer event from log, ERC-721 transfers with indexed token id return abi.ErrEventMismatch
+func DecodeTransfer(log ethrpc.Log) (*TransferEvent, error) {
+	decoded, err := ABI.Events["Transfer"].DecodeLog(log)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransferEvent{
+		Token: log.Address,
+		From:  decoded.Args[0].(string),
+		To:    decoded.Args[1].(string),
+		Value: decoded.Args[2].(*big.Int),
+		Log:   log,
+	}, nil
+}
+
+// DecodeApproval decodes Approval event from log, ERC-721 approvals with indexed token id return abi.ErrEventMismatch
+func DecodeApproval(log ethrpc.Log) (*ApprovalEvent, error) {
+	decoded, err := ABI.Events["Approval"].DecodeLog(log)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApprovalEvent{
+		Token:   log.Address,
+		Owner:   decoded.Args[0].(string),
+		Spender: decoded.Args[1].(string),
+		Value:   decoded.Args[2].(*big.Int),
+		Log:     log,
+	}, nil
+}
+
+// FilterTransfers returns filter of token transfers from any of from addresses to any of to addresses, empty addresses match any address.
+// FromBlock and ToBlock of the filter should be set before use.
+func (token *Token) FilterTransfers(from, to []string) (ethrpc.FilterParams, error) {
+	return token.filter("Transfer", from, to)
+}
+
+// FilterApprovals returns filter of token approvals of any of owners to any of spenders, empty addresses match any address.
+// FromBlock and ToBlock of the filter should be set before use.
+func (token *Token) FilterApprovals(owners, spenders []string) (ethrpc.FilterParams, error) {
+	return token.filter("Approval", owners, spenders)
+}
+
+func (token *Token) filter(event string, first, second []string) (ethrpc.FilterParams, error) {
+	topics, err := ABI.Events[event].FilterTopics(first, second)
+	if err != nil {
+		return ethrpc.FilterParams{}, err
+	}
+
+	return ethrpc.FilterParams{Address: []string{token.address}, Topics: topics}, nil
+}
+
+// GetTransfers returns Transfer events matching the filter, logs of other events are skipped.
+// Filter without address returns transfers of all tokens.
+func GetTransfers(getter abi.LogsGetter, params ethrpc.FilterParams) ([]TransferEvent, error) {
+	logs, err := getter.EthGetLogs(params)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := []TransferEvent{}
+	for _, log := range logs {
+		transfer, err := DecodeTransfer(log)
+		if errors.Is(err, abi.ErrEventMismatch) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, *transfer)
+	}
+
+	return transfers, nil
+}
+
+// GetApprovals returns Approval events matching the filter, logs of other events are skipped
+func GetApprovals(getter abi.LogsGetter, params ethrpc.FilterParams) ([]ApprovalEvent, error) {
+	logs, err := getter.EthGetLogs(params)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals := []ApprovalEvent{}
+	for _, log := range logs {
+		approval, err := DecodeApproval(log)
+		if errors.Is(err, abi.ErrEventMismatch) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, *approval)
+	}
+
+	return approvals, nil
+}
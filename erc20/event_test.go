@@ -0,0 +1,94 @@
+package erc20
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/onrik/ethrpc"
+	"github.com/onrik/ethrpc/abi"
+	"github.com/onrik/ethrpc/internal/rpctest"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	transferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	approvalTopic = "0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"
+	ownerTopic    = "0x000000000000000000000000" + "1111111111111111111111111111111111111111"
+	spenderTopic  = "0x000000000000000000000000" + "2222222222222222222222222222222222222222"
+	valueData     = "0x0000000000000000000000000000000000000000000000000de0b6b3a7640000"
+)
+
+func TestDecodeEvents(t *testing.T) {
+	log := ethrpc.Log{Address: tokenAddress, Topics: []string{transferTopic, ownerTopic, spenderTopic}, Data: valueData}
+	transfer, err := DecodeTransfer(log)
+	require.Nil(t, err)
+	require.Equal(t, &TransferEvent{Token: tokenAddress, From: owner, To: spender, Value: big.NewInt(1000000000000000000), Log: log}, transfer)
+	require.Equal(t, "1", FormatAmount(transfer.Value, 18))
+
+	log.Topics[0] = approvalTopic
+	approval, err := DecodeApproval(log)
+	require.Nil(t, err)
+	require.Equal(t, &ApprovalEvent{Token: tokenAddress, Owner: owner, Spender: spender, Value: big.NewInt(1000000000000000000), Log: log}, approval)
+
+	_, err = DecodeTransfer(log)
+	require.True(t, errors.Is(err, abi.ErrEventMismatch))
+
+	// ERC-721 approval with indexed token id
+	log.Topics = append(log.Topics, ownerTopic)
+	_, err = DecodeApproval(log)
+	require.True(t, errors.Is(err, abi.ErrEventMismatch))
+}
+
+func TestFilterEvents(t *testing.T) {
+	token := New(tokenAddress, nil)
+
+	params, err := token.FilterTransfers(nil, []string{owner, spender})
+	require.Nil(t, err)
+	require.Equal(t, ethrpc.FilterParams{
+		Address: []string{tokenAddress},
+		Topics:  [][]string{{transferTopic}, nil, {ownerTopic, spenderTopic}},
+	}, params)
+
+	params, err = token.FilterApprovals([]string{owner}, nil)
+	require.Nil(t, err)
+	require.Equal(t, [][]string{{approvalTopic}, {ownerTopic}}, params.Topics)
+
+	_, err = token.FilterTransfers([]string{"0x01"}, nil)
+	require.NotNil(t, err)
+}
+
+func TestGetEvents(t *testing.T) {
+	getter := &rpctest.LogsGetter{Logs: []ethrpc.Log{
+		{Address: tokenAddress, Topics: []string{transferTopic, ownerTopic, spenderTopic}, Data: valueData},
+		// ERC-721 transfer
+		{Address: tokenAddress, Topics: []string{transferTopic, ownerTopic, spenderTopic, ownerTopic}, Data: "0x"},
+		{Address: tokenAddress, Topics: []string{approvalTopic, ownerTopic, spenderTopic}, Data: valueData},
+	}}
+	params := ethrpc.FilterParams{FromBlock: "0x1", ToBlock: "latest"}
+
+	transfers, err := GetTransfers(getter, params)
+	require.Nil(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, getter.Logs[0], transfers[0].Log)
+	require.Equal(t, params, getter.Params)
+
+	approvals, err := GetApprovals(getter, params)
+	require.Nil(t, err)
+	require.Len(t, approvals, 1)
+	require.Equal(t, spender, approvals[0].Spender)
+
+	// Malformed logs
+	getter.Logs = []ethrpc.Log{{Topics: []string{transferTopic, ownerTopic, spenderTopic}, Data: "0x01"}}
+	_, err = GetTransfers(getter, params)
+	require.NotNil(t, err)
+	getter.Logs[0].Topics[0] = approvalTopic
+	_, err = GetApprovals(getter, params)
+	require.NotNil(t, err)
+
+	getter.Err = errors.New("error")
+	_, err = GetTransfers(getter, params)
+	require.Equal(t, getter.Err, err)
+	_, err = GetApprovals(getter, params)
+	require.Equal(t, getter.Err, err)
+}
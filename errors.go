@@ -0,0 +1,124 @@
+package ethrpc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// Errors of node responses, use errors.Is to check class of EthError, abi.RevertError or HTTPError
+var (
+	// ErrNotFound - block, transaction or other requested resource not found
+	ErrNotFound = errors.New("ethrpc: not found")
+	// ErrNonceTooLow - transaction nonce is lower than the account nonce
+	ErrNonceTooLow = errors.New("ethrpc: nonce too low")
+	// ErrUnderpriced - transaction or replacement fee is too low
+	ErrUnderpriced = errors.New("ethrpc: transaction underpriced")
+	// ErrInsufficientFunds - account balance doesn't cover transaction value and fee
+	ErrInsufficientFunds = errors.New("ethrpc: insufficient funds")
+	// ErrRateLimited - node or provider limits requests rate
+	ErrRateLimited = errors.New("ethrpc: rate limited")
+	// ErrMethodNotFound - node doesn't support the method
+	ErrMethodNotFound = errors.New("ethrpc: method not found")
+	// ErrExecutionReverted - call or transaction reverted
+	ErrExecutionReverted = errors.New("ethrpc: execution reverted")
+	// ErrInvalidResponse - response is not JSON-RPC response, html page of proxy for example
+	ErrInvalidResponse = errors.New("ethrpc: invalid response")
+	// ErrResponseTooLarge - response body exceeds limit set with WithMaxResponseSize
+	ErrResponseTooLarge = errors.New("ethrpc: response too large")
+)
+
+// errorCodes - codes of EIP-1474 and node specific errors
+var errorCodes = map[error][]int{
+	ErrMethodNotFound: {-32601},
+	// -32005 is EIP-1474 limit exceeded code used by Infura, Alchemy returns 429 as JSON-RPC error code,
+	// HTTP 429 status is classified by HTTPError
+	ErrRateLimited:       {-32005, 429},
+	ErrExecutionReverted: {3},
+}
+
+// errorMessages - lowercase fragments of error messages of geth, erigon, nethermind and besu
+var errorMessages = map[error][]string{
+	ErrNotFound: {
+		"header not found", "block not found", "unknown block", "transaction not found",
+		"receipt not found", "resource not found", "unknown transaction",
+	},
+	ErrNonceTooLow: {
+		"nonce too low", "nonce is too low", "oldnonce", "nonce_too_low",
+	},
+	ErrUnderpriced: {
+		"underpriced", "fee too low", "feetoolow", "gas price too low", "gas_price_too_low",
+		"less than block base fee", "gas price below",
+	},
+	ErrInsufficientFunds: {
+		"insufficient funds", "insufficientfunds", "insufficient balance", "exceeds account balance",
+		"upfront_cost_exceeds_balance", "not enough funds",
+	},
+	ErrRateLimited: {
+		"rate limit", "ratelimit", "too many requests", "request limit", "exceeded the quota",
+	},
+	ErrMethodNotFound: {
+		"method not found", "does not exist/is not available", "method not supported", "unsupported method",
+	},
+	ErrExecutionReverted: {
+		"revert",
+	},
+}
+
+// Is reports whether the error belongs to one of the Err* classes by error code or message
+func (err EthError) Is(target error) bool {
+	for _, code := range errorCodes[target] {
+		if err.Code == code {
+			return true
+		}
+	}
+
+	message := strings.ToLower(err.Message)
+	for _, fragment := range errorMessages[target] {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+
+	// Nethermind returns "VM execution error." with "Reverted 0x..." data
+	if target == ErrExecutionReverted {
+		return strings.Contains(strings.ToLower(err.Data), "revert")
+	}
+
+	return false
+}
+
+// HTTPError - error of http response with unsuccessful status code and without JSON-RPC error
+type HTTPError struct {
+	StatusCode int
+	// Body is response body, error message contains its excerpt
+	Body []byte
+}
+
+func (err HTTPError) Error() string {
+	return fmt.Sprintf("HTTP error %d (%s)", err.StatusCode, bodyExcerpt(err.Body))
+}
+
+// Is reports whether the error is ErrRateLimited for 429 Too Many Requests status
+func (err HTTPError) Is(target error) bool {
+	return target == ErrRateLimited && err.StatusCode == http.StatusTooManyRequests
+}
+
+// bodyExcerpt returns beginning of response body with collapsed whitespaces for error messages
+func bodyExcerpt(body []byte) string {
+	const maxLength = 200
+
+	excerpt := strings.Join(strings.Fields(string(body)), " ")
+	if len(excerpt) <= maxLength {
+		return excerpt
+	}
+
+	i := maxLength
+	for i > 0 && !utf8.RuneStart(excerpt[i]) {
+		i--
+	}
+
+	return excerpt[:i] + "..."
+}
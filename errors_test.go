@@ -0,0 +1,74 @@
+package ethrpc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthErrorIs(t *testing.T) {
+	for _, test := range []struct {
+		err      EthError
+		expected error
+	}{
+		// geth and erigon
+		{EthError{Code: -32000, Message: "header not found"}, ErrNotFound},
+		{EthError{Code: -32000, Message: "nonce too low: address 0x01, tx: 1 state: 2"}, ErrNonceTooLow},
+		{EthError{Code: -32000, Message: "replacement transaction underpriced"}, ErrUnderpriced},
+		{EthError{Code: -32000, Message: "transaction underpriced: tip needed 1, tip permitted 0"}, ErrUnderpriced},
+		{EthError{Code: -32000, Message: "max fee per gas less than block base fee"}, ErrUnderpriced},
+		{EthError{Code: -32000, Message: "insufficient funds for gas * price + value"}, ErrInsufficientFunds},
+		{EthError{Code: -32601, Message: "the method eth_foo does not exist/is not available"}, ErrMethodNotFound},
+		{EthError{Code: 3, Message: "execution reverted: Not enough Ether provided."}, ErrExecutionReverted},
+		{EthError{Code: -32000, Message: "execution reverted"}, ErrExecutionReverted},
+		// nethermind
+		{EthError{Code: -32001, Message: "resource not found"}, ErrNotFound},
+		{EthError{Code: -32010, Message: "OldNonce"}, ErrNonceTooLow},
+		{EthError{Code: -32010, Message: "FeeTooLow"}, ErrUnderpriced},
+		{EthError{Code: -32010, Message: "InsufficientFunds, Account balance: 0"}, ErrInsufficientFunds},
+		{EthError{Code: -32015, Message: "VM execution error.", Data: `"Reverted 0x"`}, ErrExecutionReverted},
+		{EthError{Code: -32005, Message: "Number of requests exceeded"}, ErrRateLimited},
+		// besu
+		{EthError{Code: -32000, Message: "Block not found"}, ErrNotFound},
+		{EthError{Code: -32001, Message: "Nonce too low"}, ErrNonceTooLow},
+		{EthError{Code: -32000, Message: "Gas price below configured minimum gas price"}, ErrUnderpriced},
+		{EthError{Code: -32004, Message: "Upfront cost exceeds account balance"}, ErrInsufficientFunds},
+		{EthError{Code: -32601, Message: "Method not found"}, ErrMethodNotFound},
+		{EthError{Code: -32000, Message: "Execution reverted"}, ErrExecutionReverted},
+		// providers
+		{EthError{Code: 429, Message: "Too Many Requests"}, ErrRateLimited},
+		{EthError{Code: -32000, Message: "daily request limit reached"}, ErrRateLimited},
+	} {
+		require.True(t, errors.Is(test.err, test.expected), test.err.Message)
+		require.True(t, errors.Is(fmt.Errorf("send: %w", test.err), test.expected), test.err.Message)
+
+		for _, other := range []error{ErrNotFound, ErrNonceTooLow, ErrUnderpriced, ErrInsufficientFunds, ErrRateLimited, ErrMethodNotFound, ErrExecutionReverted} {
+			if other != test.expected {
+				require.False(t, errors.Is(test.err, other), "%s is %s", test.err.Message, other)
+			}
+		}
+	}
+
+	require.False(t, errors.Is(EthError{Code: -32000, Message: "out of gas"}, ErrExecutionReverted))
+	require.False(t, errors.Is(EthError{Code: -32000, Message: "out of gas"}, errors.New("out of gas")))
+}
+
+func TestHTTPError(t *testing.T) {
+	err := HTTPError{StatusCode: 429, Body: []byte("rate limited\n")}
+	require.Equal(t, "HTTP error 429 (rate limited)", err.Error())
+	require.True(t, errors.Is(err, ErrRateLimited))
+	require.True(t, errors.Is(fmt.Errorf("call: %w", err), ErrRateLimited))
+
+	err = HTTPError{StatusCode: 502}
+	require.False(t, errors.Is(err, ErrRateLimited))
+}
+
+func TestBodyExcerpt(t *testing.T) {
+	require.Equal(t, "", bodyExcerpt(nil))
+	require.Equal(t, "a b c", bodyExcerpt([]byte(" a\n\tb  c\n")))
+	require.Equal(t, strings.Repeat("a", 200)+"...", bodyExcerpt([]byte(strings.Repeat("a", 300))))
+	require.Equal(t, strings.Repeat("a", 199)+"...", bodyExcerpt([]byte(strings.Repeat("a", 199)+strings.Repeat("ж", 10))))
+}
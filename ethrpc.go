@@ -3,31 +3,116 @@ package ethrpc
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"math/big"
+	"mime"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // EthError - ethereum error
 type EthError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
+	// Data is raw JSON of additional information about the error like revert data of failed call, see RevertData
+	Data string `json:"-"`
 }
 
 func (err EthError) Error() string {
 	return fmt.Sprintf("Error %d (%s)", err.Code, err.Message)
 }
 
+type proxyEthError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (err EthError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(proxyEthError{Code: err.Code, Message: err.Message, Data: json.RawMessage(err.Data)})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (err *EthError) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyEthError)
+	if e := json.Unmarshal(data, proxy); e != nil {
+		return e
+	}
+
+	*err = EthError{Code: proxy.Code, Message: proxy.Message}
+	if string(proxy.Data) != "null" {
+		err.Data = string(proxy.Data)
+	}
+
+	return nil
+}
+
 type ethResponse struct {
-	ID      int             `json:"id"`
+	ID      json.RawMessage `json:"id"`
 	JSONRPC string          `json:"jsonrpc"`
 	Result  json.RawMessage `json:"result"`
 	Error   *EthError       `json:"error"`
 }
 
+// id returns response id, numeric strings are accepted in lenient mode
+func (resp ethResponse) id(strict bool) (int, bool) {
+	id := 0
+	if err := json.Unmarshal(resp.ID, &id); err == nil && string(resp.ID) != "null" {
+		return id, true
+	}
+	if strict {
+		return 0, false
+	}
+
+	value := ""
+	if err := json.Unmarshal(resp.ID, &value); err != nil {
+		return 0, false
+	}
+	id, err := strconv.Atoi(value)
+
+	return id, err == nil
+}
+
+// isNullIDError reports whether the response is an error with null id,
+// JSON-RPC 2.0 requires null id in errors of requests which id can't be detected like parse errors
+func (resp ethResponse) isNullIDError() bool {
+	return resp.Error != nil && string(resp.ID) == "null"
+}
+
+// validate checks response of request with the id.
+// Strict validation requires jsonrpc version 2.0, matching id and either result or error,
+// lenient validation tolerates missing id and version and prefers error if both result and error are present.
+// Errors with null id are accepted in both modes.
+func (resp ethResponse) validate(id int, strict bool) error {
+	if strict && resp.JSONRPC != "2.0" {
+		return fmt.Errorf("%w: jsonrpc version %q, expected 2.0", ErrInvalidResponse, resp.JSONRPC)
+	}
+
+	respID, ok := resp.id(strict)
+	if ok && respID != id {
+		return fmt.Errorf("%w: response id %s doesn't match request id %d", ErrInvalidResponse, resp.ID, id)
+	}
+	if !ok && !resp.isNullIDError() && (strict || (len(resp.ID) > 0 && string(resp.ID) != "null")) {
+		return fmt.Errorf("%w: invalid response id %s, expected %d", ErrInvalidResponse, resp.ID, id)
+	}
+
+	if resp.Result == nil && resp.Error == nil {
+		return fmt.Errorf("%w: response without result and error", ErrInvalidResponse)
+	}
+	if strict && resp.Result != nil && resp.Error != nil {
+		return fmt.Errorf("%w: response with both result and error", ErrInvalidResponse)
+	}
+
+	return nil
+}
+
 type ethRequest struct {
 	ID      int           `json:"id"`
 	JSONRPC string        `json:"jsonrpc"`
@@ -35,20 +120,27 @@ type ethRequest struct {
 	Params  []interface{} `json:"params"`
 }
 
+// DefaultMaxResponseSize - default limit of response body size
+const DefaultMaxResponseSize = 128 << 20
+
 // EthRPC - Ethereum rpc client
 type EthRPC struct {
-	url    string
-	client httpClient
-	log    logger
-	Debug  bool
+	url             string
+	client          httpClient
+	log             logger
+	jwtSecret       []byte
+	maxResponseSize int64
+	strict          bool
+	Debug           bool
 }
 
 // New create new rpc client with given url
 func New(url string, options ...func(rpc *EthRPC)) *EthRPC {
 	rpc := &EthRPC{
-		url:    url,
-		client: http.DefaultClient,
-		log:    log.New(os.Stderr, "", log.LstdFlags),
+		url:             url,
+		client:          http.DefaultClient,
+		log:             log.New(os.Stderr, "", log.LstdFlags),
+		maxResponseSize: DefaultMaxResponseSize,
 	}
 	for _, option := range options {
 		option(rpc)
@@ -94,7 +186,123 @@ func (rpc *EthRPC) Call(method string, params ...interface{}) (json.RawMessage,
 		return nil, err
 	}
 
-	response, err := rpc.client.Post(rpc.url, "application/json", bytes.NewBuffer(body))
+	data, err := rpc.post(method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(ethResponse)
+	if err := json.Unmarshal(data, resp); err != nil {
+		return nil, fmt.Errorf("%w: %s (%s)", ErrInvalidResponse, err, bodyExcerpt(data))
+	}
+
+	if err := resp.validate(request.ID, rpc.strict); err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, *resp.Error
+	}
+
+	return resp.Result, nil
+
+}
+
+// BatchElem - element of batch request
+type BatchElem struct {
+	Method string
+	Params []interface{}
+	// Result is unmarshaled from the response if not nil
+	Result interface{}
+	// Error is set if the request failed
+	Error error
+}
+
+// BatchCall sends all given requests in a single http request.
+// Per-request errors are stored in the Error field of each element.
+func (rpc *EthRPC) BatchCall(elems []BatchElem) error {
+	if len(elems) == 0 {
+		return nil
+	}
+
+	requests := make([]ethRequest, len(elems))
+	for i, elem := range elems {
+		requests[i] = ethRequest{
+			ID:      i + 1,
+			JSONRPC: "2.0",
+			Method:  elem.Method,
+			Params:  elem.Params,
+		}
+	}
+
+	body, err := json.Marshal(requests)
+	if err != nil {
+		return err
+	}
+
+	data, err := rpc.post("batch", body)
+	if err != nil {
+		return err
+	}
+
+	responses := []ethResponse{}
+	if err := json.Unmarshal(data, &responses); err != nil {
+		// Nodes without batch support reply with a single error object
+		resp := new(ethResponse)
+		if json.Unmarshal(data, resp) == nil && resp.Error != nil {
+			return *resp.Error
+		}
+		return fmt.Errorf("%w: %s (%s)", ErrInvalidResponse, err, bodyExcerpt(data))
+	}
+
+	received := make([]bool, len(elems))
+	for _, resp := range responses {
+		id, ok := resp.id(rpc.strict)
+		i := id - 1
+		if !ok || i < 0 || i >= len(elems) || received[i] {
+			// Error with null id can't be matched to the request, e.g. invalid request error
+			if rpc.strict && resp.isNullIDError() {
+				return *resp.Error
+			}
+			if rpc.strict {
+				return fmt.Errorf("%w: unexpected response id %s", ErrInvalidResponse, resp.ID)
+			}
+			continue
+		}
+		received[i] = true
+
+		if err := resp.validate(id, rpc.strict); err != nil {
+			if rpc.strict {
+				return err
+			}
+			elems[i].Error = err
+			continue
+		}
+		if resp.Error != nil {
+			elems[i].Error = *resp.Error
+			continue
+		}
+		if elems[i].Result != nil {
+			elems[i].Error = json.Unmarshal(resp.Result, elems[i].Result)
+		}
+	}
+
+	for i := range elems {
+		if !received[i] {
+			elems[i].Error = fmt.Errorf("no response for request %d (%s)", i+1, elems[i].Method)
+		}
+	}
+
+	return nil
+}
+
+func (rpc *EthRPC) post(method string, body []byte) ([]byte, error) {
+	var response *http.Response
+	var err error
+	if rpc.jwtSecret != nil {
+		response, err = rpc.postWithJWT(body)
+	} else {
+		response, err = rpc.client.Post(rpc.url, "application/json", bytes.NewBuffer(body))
+	}
 	if response != nil {
 		defer response.Body.Close()
 	}
@@ -102,26 +310,89 @@ func (rpc *EthRPC) Call(method string, params ...interface{}) (json.RawMessage,
 		return nil, err
 	}
 
-	data, err := io.ReadAll(response.Body)
+	if rpc.maxResponseSize > 0 && response.ContentLength > rpc.maxResponseSize {
+		return nil, fmt.Errorf("%w: content length %d exceeds %d bytes", ErrResponseTooLarge, response.ContentLength, rpc.maxResponseSize)
+	}
+
+	reader := io.Reader(response.Body)
+	if rpc.maxResponseSize > 0 {
+		reader = io.LimitReader(response.Body, rpc.maxResponseSize+1)
+	}
+	data, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, err
 	}
+	if rpc.maxResponseSize > 0 && int64(len(data)) > rpc.maxResponseSize {
+		return nil, fmt.Errorf("%w: body exceeds %d bytes", ErrResponseTooLarge, rpc.maxResponseSize)
+	}
 
 	if rpc.Debug {
 		rpc.log.Println(fmt.Sprintf("%s\nRequest: %s\nResponse: %s\n", method, body, data))
 	}
 
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		// Some nodes and providers return JSON-RPC errors with 4xx and 5xx statuses
+		if isErrorResponse(data) {
+			return data, nil
+		}
+		return nil, HTTPError{StatusCode: response.StatusCode, Body: data}
+	}
+
+	if !isJSONResponse(response.Header.Get("Content-Type"), data) {
+		return nil, fmt.Errorf("%w: unexpected content type %s (%s)", ErrInvalidResponse, response.Header.Get("Content-Type"), bodyExcerpt(data))
+	}
+
+	return data, nil
+}
+
+// isErrorResponse reports whether data is JSON-RPC error response or batch response with errors
+func isErrorResponse(data []byte) bool {
 	resp := new(ethResponse)
-	if err := json.Unmarshal(data, resp); err != nil {
-		return nil, err
+	if json.Unmarshal(data, resp) == nil {
+		return resp.Error != nil
 	}
 
-	if resp.Error != nil {
-		return nil, *resp.Error
+	responses := []ethResponse{}
+	if json.Unmarshal(data, &responses) != nil {
+		return false
+	}
+	for _, resp := range responses {
+		if resp.Error != nil {
+			return true
+		}
 	}
 
-	return resp.Result, nil
+	return false
+}
+
+// isJSONResponse reports whether response has JSON content type.
+// Servers with wrong content type are tolerated if body looks like JSON.
+func isJSONResponse(contentType string, data []byte) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if contentType == "" || (err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))) {
+		return true
+	}
+
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && (data[0] == '{' || data[0] == '[')
+}
+
+func (rpc *EthRPC) postWithJWT(body []byte) (*http.Response, error) {
+	client, ok := rpc.client.(interface {
+		Do(req *http.Request) (*http.Response, error)
+	})
+	if !ok {
+		return nil, errors.New("http client doesn't support request headers required for jwt authentication")
+	}
+
+	request, err := http.NewRequest(http.MethodPost, rpc.url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+newJWTToken(rpc.jwtSecret, time.Now()))
 
+	return client.Do(request)
 }
 
 // RawCall returns raw response of method call (Deprecated)
@@ -230,6 +501,26 @@ func (rpc *EthRPC) EthGasPrice() (big.Int, error) {
 	return ParseBigInt(response)
 }
 
+// EthMaxPriorityFeePerGas returns the current priority fee per gas in wei suggested for EIP-1559 transactions.
+func (rpc *EthRPC) EthMaxPriorityFeePerGas() (big.Int, error) {
+	var response string
+	if err := rpc.call("eth_maxPriorityFeePerGas", &response); err != nil {
+		return big.Int{}, err
+	}
+
+	return ParseBigInt(response)
+}
+
+// EthChainID returns the chain id used for signing replay-protected transactions.
+func (rpc *EthRPC) EthChainID() (int, error) {
+	var response string
+	if err := rpc.call("eth_chainId", &response); err != nil {
+		return 0, err
+	}
+
+	return ParseInt(response)
+}
+
 // EthAccounts returns a list of addresses owned by client.
 func (rpc *EthRPC) EthAccounts() ([]string, error) {
 	accounts := []string{}
@@ -338,6 +629,22 @@ func (rpc *EthRPC) EthSign(address, data string) (string, error) {
 	return signature, err
 }
 
+// EthSignTransaction signs a transaction that can be submitted to the network at a later time using with EthSendRawTransaction.
+func (rpc *EthRPC) EthSignTransaction(transaction T) (*SignedTransaction, error) {
+	signed := new(SignedTransaction)
+
+	err := rpc.call("eth_signTransaction", signed, transaction)
+	return signed, err
+}
+
+// EthSignTypedDataV4 signs EIP-712 typed structured data with a given address.
+func (rpc *EthRPC) EthSignTypedDataV4(address string, data TypedData) (string, error) {
+	var signature string
+
+	err := rpc.call("eth_signTypedData_v4", &signature, address, data)
+	return signature, err
+}
+
 // EthSendTransaction creates new message call transaction or a contract creation, if the data field contains code.
 func (rpc *EthRPC) EthSendTransaction(transaction T) (string, error) {
 	var hash string
@@ -355,6 +662,7 @@ func (rpc *EthRPC) EthSendRawTransaction(data string) (string, error) {
 }
 
 // EthCall executes a new message call immediately without creating a transaction on the block chain.
+// Revert data of reverted call is returned in EthError.Data, see EthError.RevertData.
 func (rpc *EthRPC) EthCall(transaction T, tag string) (string, error) {
 	var data string
 
@@ -363,6 +671,7 @@ func (rpc *EthRPC) EthCall(transaction T, tag string) (string, error) {
 }
 
 // EthEstimateGas makes a call or transaction, which won't be added to the blockchain and returns the used gas, which can be used for estimating the used gas.
+// Revert data of reverted transaction is returned in EthError.Data, see EthError.RevertData.
 func (rpc *EthRPC) EthEstimateGas(transaction T) (int, error) {
 	var response string
 
@@ -409,6 +718,50 @@ func (rpc *EthRPC) EthGetBlockByNumber(number int, withTransactions bool) (*Bloc
 	return rpc.getBlock("eth_getBlockByNumber", withTransactions, IntToHex(number), withTransactions)
 }
 
+// EthGetUncleByBlockHashAndIndex returns information about an uncle of a block by hash and uncle index position.
+func (rpc *EthRPC) EthGetUncleByBlockHashAndIndex(hash string, index int) (*Block, error) {
+	return rpc.getBlock("eth_getUncleByBlockHashAndIndex", false, hash, IntToHex(index))
+}
+
+// EthGetUncleByBlockNumberAndIndex returns information about an uncle of a block by number and uncle index position.
+func (rpc *EthRPC) EthGetUncleByBlockNumberAndIndex(number, index int) (*Block, error) {
+	return rpc.getBlock("eth_getUncleByBlockNumberAndIndex", false, IntToHex(number), IntToHex(index))
+}
+
+// GetUncles returns all uncles of a block in a single batch request.
+func (rpc *EthRPC) GetUncles(block *Block) ([]Block, error) {
+	if len(block.Uncles) == 0 {
+		return []Block{}, nil
+	}
+
+	elems := make([]BatchElem, len(block.Uncles))
+	for i := range block.Uncles {
+		elems[i] = BatchElem{
+			Method: "eth_getUncleByBlockHashAndIndex",
+			Params: []interface{}{block.Hash, IntToHex(i)},
+			Result: new(*proxyBlockWithoutTransactions),
+		}
+	}
+
+	if err := rpc.BatchCall(elems); err != nil {
+		return nil, err
+	}
+
+	uncles := make([]Block, len(elems))
+	for i, elem := range elems {
+		if elem.Error != nil {
+			return nil, elem.Error
+		}
+		proxy := *elem.Result.(**proxyBlockWithoutTransactions)
+		if proxy == nil {
+			return nil, fmt.Errorf("uncle %s of block %s not found", block.Uncles[i], block.Hash)
+		}
+		uncles[i] = proxy.toBlock()
+	}
+
+	return uncles, nil
+}
+
 func (rpc *EthRPC) getTransaction(method string, params ...interface{}) (*Transaction, error) {
 	transaction := new(Transaction)
 
@@ -444,6 +797,65 @@ func (rpc *EthRPC) EthGetTransactionReceipt(hash string) (*TransactionReceipt, e
 	return transactionReceipt, nil
 }
 
+// EthGetBlockReceipts returns receipts of all transactions in a block by block number, tag or hash.
+func (rpc *EthRPC) EthGetBlockReceipts(block string) ([]TransactionReceipt, error) {
+	var receipts []TransactionReceipt
+
+	err := rpc.call("eth_getBlockReceipts", &receipts, block)
+	return receipts, err
+}
+
+// GetBlockReceipts returns receipts of all transactions in a block in transaction order.
+// It uses eth_getBlockReceipts and falls back to batched eth_getTransactionReceipt calls if the node doesn't support it.
+// Block must be fetched with transactions, it's an error if number of receipts doesn't match number of transactions or the node returns null for unknown block.
+func (rpc *EthRPC) GetBlockReceipts(block *Block) ([]TransactionReceipt, error) {
+	receipts, err := rpc.EthGetBlockReceipts(block.Hash)
+	if err == nil {
+		if receipts == nil {
+			// null result of unknown block
+			return nil, fmt.Errorf("receipts of block %s not found", block.Hash)
+		}
+		if len(receipts) != len(block.Transactions) {
+			return nil, fmt.Errorf("node returned %d receipts of block %s with %d transactions", len(receipts), block.Hash, len(block.Transactions))
+		}
+		return receipts, nil
+	}
+	if !errors.Is(err, ErrMethodNotFound) {
+		return nil, err
+	}
+
+	if len(block.Transactions) == 0 {
+		return []TransactionReceipt{}, nil
+	}
+
+	elems := make([]BatchElem, len(block.Transactions))
+	for i, transaction := range block.Transactions {
+		elems[i] = BatchElem{
+			Method: "eth_getTransactionReceipt",
+			Params: []interface{}{transaction.Hash},
+			Result: new(*TransactionReceipt),
+		}
+	}
+
+	if err := rpc.BatchCall(elems); err != nil {
+		return nil, err
+	}
+
+	receipts = make([]TransactionReceipt, len(elems))
+	for i, elem := range elems {
+		if elem.Error != nil {
+			return nil, elem.Error
+		}
+		receipt := *elem.Result.(**TransactionReceipt)
+		if receipt == nil {
+			return nil, fmt.Errorf("receipt of transaction %s not found", block.Transactions[i].Hash)
+		}
+		receipts[i] = *receipt
+	}
+
+	return receipts, nil
+}
+
 // EthGetCompilers returns a list of available compilers in the client.
 func (rpc *EthRPC) EthGetCompilers() ([]string, error) {
 	compilers := []string{}
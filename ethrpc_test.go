@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"net/http"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
@@ -29,6 +30,24 @@ func (s *EthRPCTestSuite) registerResponse(result string, callback func([]byte))
 	})
 }
 
+func (s *EthRPCTestSuite) registerResponses(results map[string]string) {
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := struct {
+			Method string `json:"method"`
+		}{}
+		s.Require().Nil(json.Unmarshal(s.getBody(request), &body))
+
+		result, ok := results[body.Method]
+		s.Require().True(ok, "unexpected method %s", body.Method)
+
+		if strings.HasPrefix(result, `{"code"`) {
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`{"jsonrpc":"2.0", "id":1, "error": %s}`, result)), nil
+		}
+		return httpmock.NewStringResponse(200, fmt.Sprintf(`{"jsonrpc":"2.0", "id":1, "result": %s}`, result)), nil
+	})
+}
+
 func (s *EthRPCTestSuite) registerResponseError(err error) {
 	httpmock.Reset()
 	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
@@ -132,6 +151,101 @@ func (s *EthRPCTestSuite) TestCall() {
 	s.Require().True(ok)
 	s.Require().Equal(21, ethError.Code)
 	s.Require().Equal("eee", ethError.Message)
+	httpmock.Reset()
+
+	// Test http status error
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(429, `too many requests`), nil
+	})
+	_, err = s.rpc.Call("test")
+	s.Require().Equal(HTTPError{StatusCode: 429, Body: []byte("too many requests")}, err)
+	s.Require().True(errors.Is(err, ErrRateLimited))
+	httpmock.Reset()
+
+	// Test html page of proxy
+	page := "<html>\n<head><title>502 Bad Gateway</title></head>\n<body>" + strings.Repeat("nginx ", 100) + "</body>\n</html>"
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		response := httpmock.NewStringResponse(502, page)
+		response.Header.Set("Content-Type", "text/html")
+		return response, nil
+	})
+	_, err = s.rpc.Call("test")
+	httpErr, ok := err.(HTTPError)
+	s.Require().True(ok)
+	s.Require().Equal(502, httpErr.StatusCode)
+	s.Require().Equal([]byte(page), httpErr.Body)
+	s.Require().True(strings.HasPrefix(err.Error(), "HTTP error 502 (<html> <head><title>502 Bad Gateway</title></head> <body>nginx nginx"))
+	s.Require().True(strings.HasSuffix(err.Error(), "...)"))
+	httpmock.Reset()
+
+	// Test JSON-RPC error with http error status
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(400, `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32602, "message": "invalid argument 0"}}`), nil
+	})
+	_, err = s.rpc.Call("test")
+	s.Require().Equal(EthError{Code: -32602, Message: "invalid argument 0"}, err)
+	httpmock.Reset()
+
+	// Test not JSON response with success status
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		response := httpmock.NewStringResponse(200, "<html>maintenance</html>")
+		response.Header.Set("Content-Type", "text/html; charset=utf-8")
+		return response, nil
+	})
+	_, err = s.rpc.Call("test")
+	s.Require().True(errors.Is(err, ErrInvalidResponse))
+	s.Require().Equal("ethrpc: invalid response: unexpected content type text/html; charset=utf-8 (<html>maintenance</html>)", err.Error())
+	httpmock.Reset()
+
+	// Test wrong content type of JSON response
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		response := httpmock.NewStringResponse(200, `{"jsonrpc": "2.0", "id": 1, "result": "0x1"}`)
+		response.Header.Set("Content-Type", "text/plain")
+		return response, nil
+	})
+	result, err := s.rpc.Call("test")
+	s.Require().Nil(err)
+	s.Require().Equal(json.RawMessage(`"0x1"`), result)
+	httpmock.Reset()
+
+	// Test invalid JSON
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		response := httpmock.NewStringResponse(200, `{"result": `)
+		response.Header.Set("Content-Type", "application/json")
+		return response, nil
+	})
+	_, err = s.rpc.Call("test")
+	s.Require().True(errors.Is(err, ErrInvalidResponse))
+}
+
+func (s *EthRPCTestSuite) TestMaxResponseSize() {
+	rpc := New(s.rpc.url, WithMaxResponseSize(50))
+	response := `{"jsonrpc": "2.0", "id": 1, "result": "0x1"}`
+
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, response), nil
+	})
+	result, err := rpc.Call("test")
+	s.Require().Nil(err)
+	s.Require().Equal(json.RawMessage(`"0x1"`), result)
+
+	response = `{"jsonrpc": "2.0", "id": 1, "result": "0x` + strings.Repeat("0", 50) + `"}`
+	_, err = rpc.Call("test")
+	s.Require().True(errors.Is(err, ErrResponseTooLarge))
+	s.Require().Equal("ethrpc: response too large: body exceeds 50 bytes", err.Error())
+
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		response := httpmock.NewStringResponse(200, response)
+		response.ContentLength = 1000
+		return response, nil
+	})
+	_, err = rpc.Call("test")
+	s.Require().True(errors.Is(err, ErrResponseTooLarge))
+
+	// Limit is disabled
+	result, err = New(s.rpc.url, WithMaxResponseSize(0)).Call("test")
+	s.Require().Nil(err)
+	s.Require().Equal(json.RawMessage(`"0x`+strings.Repeat("0", 50)+`"`), result)
 }
 
 func (s *EthRPCTestSuite) Test_call() {
@@ -167,6 +281,15 @@ func (s *EthRPCTestSuite) TestWeb3Sha3() {
 	result, err := s.rpc.Web3Sha3([]byte("data"))
 	s.Require().Nil(err)
 	s.Require().Equal("sha3result", result)
+
+	// Example response from the JSON-RPC specification matches the local hash
+	s.registerResponse(`"0x47173285a8d7341e5e972fc677286384f802f8ef42a5ec5f03bbfa254cb01fad"`, func(body []byte) {
+		s.methodEqual(body, "web3_sha3")
+		s.paramsEqual(body, `["0x68656c6c6f20776f726c64"]`)
+	})
+	result, err = s.rpc.Web3Sha3([]byte("hello world"))
+	s.Require().Nil(err)
+	s.Require().Equal(fmt.Sprintf("0x%x", Keccak256([]byte("hello world"))), result)
 }
 
 func (s *EthRPCTestSuite) TestNetVersion() {
@@ -339,6 +462,36 @@ func (s *EthRPCTestSuite) TestEthGasPrice() {
 	s.Require().Equal(*expected, gasPrice)
 }
 
+func (s *EthRPCTestSuite) TestEthMaxPriorityFeePerGas() {
+	s.registerResponse(`"0x3b9aca00"`, func(body []byte) {
+		s.methodEqual(body, "eth_maxPriorityFeePerGas")
+		s.paramsEqual(body, "null")
+	})
+
+	tip, err := s.rpc.EthMaxPriorityFeePerGas()
+	s.Require().Nil(err)
+	s.Require().Equal(int64(1000000000), tip.Int64())
+
+	s.registerResponseError(errors.New("error"))
+	_, err = s.rpc.EthMaxPriorityFeePerGas()
+	s.Require().NotNil(err)
+}
+
+func (s *EthRPCTestSuite) TestEthChainID() {
+	s.registerResponse(`"0xaa36a7"`, func(body []byte) {
+		s.methodEqual(body, "eth_chainId")
+		s.paramsEqual(body, "null")
+	})
+
+	chainID, err := s.rpc.EthChainID()
+	s.Require().Nil(err)
+	s.Require().Equal(11155111, chainID)
+
+	s.registerResponseError(errors.New("error"))
+	_, err = s.rpc.EthChainID()
+	s.Require().NotNil(err)
+}
+
 func (s *EthRPCTestSuite) TestEthAccounts() {
 	s.registerResponse(`["0x407d73d8a49eeb85d32cf465507dd71d507100c1"]`, func(body []byte) {
 		s.methodEqual(body, "eth_accounts")
@@ -504,6 +657,107 @@ func (s *EthRPCTestSuite) TestEthSign() {
 	s.Require().Equal(result, signed)
 }
 
+func (s *EthRPCTestSuite) TestEthSignTransaction() {
+	s.registerResponseError(errors.New("error"))
+	_, err := s.rpc.EthSignTransaction(T{})
+	s.Require().NotNil(err)
+
+	result := `{
+		"raw": "0xf86c808504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83",
+		"tx": {
+			"nonce": "0x0",
+			"gasPrice": "0x4a817c800",
+			"gas": "0x5208",
+			"to": "0x3535353535353535353535353535353535353535",
+			"value": "0xde0b6b3a7640000",
+			"input": "0x",
+			"hash": "0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788"
+		}
+	}`
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "eth_signTransaction")
+		s.paramsEqual(body, `[{"from": "0x9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f", "to": "0x3535353535353535353535353535353535353535", "gas": "0x5208", "gasPrice": "0x4a817c800", "value": "0xde0b6b3a7640000"}]`)
+	})
+
+	signed, err := s.rpc.EthSignTransaction(T{
+		From:     "0x9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f",
+		To:       "0x3535353535353535353535353535353535353535",
+		Gas:      21000,
+		GasPrice: big.NewInt(20000000000),
+		Value:    Eth1(),
+	})
+	s.Require().Nil(err)
+	s.Require().Equal("0xf86c808504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83", signed.Raw)
+	s.Require().Equal("0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788", signed.Tx.Hash)
+	s.Require().Equal(21000, signed.Tx.Gas)
+	s.Require().Equal(0, Eth1().Cmp(&signed.Tx.Value))
+}
+
+func (s *EthRPCTestSuite) TestEthSignTypedDataV4() {
+	address := "0xcd2a3d9f938e13cd947ec05abc7fe734df8dd826"
+	result := "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c"
+	s.registerResponse(fmt.Sprintf(`"%s"`, result), func(body []byte) {
+		s.methodEqual(body, "eth_signTypedData_v4")
+		s.paramsEqual(body, fmt.Sprintf(`["%s", {
+			"types": {
+				"EIP712Domain": [
+					{"name": "name", "type": "string"},
+					{"name": "version", "type": "string"},
+					{"name": "chainId", "type": "uint256"},
+					{"name": "verifyingContract", "type": "address"}
+				],
+				"Mail": [
+					{"name": "from", "type": "address"},
+					{"name": "to", "type": "address"},
+					{"name": "contents", "type": "string"}
+				]
+			},
+			"primaryType": "Mail",
+			"domain": {
+				"name": "Ether Mail",
+				"version": "1",
+				"chainId": 1,
+				"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+			},
+			"message": {
+				"from": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826",
+				"to": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB",
+				"contents": "Hello, Bob!"
+			}
+		}]`, address))
+	})
+
+	signature, err := s.rpc.EthSignTypedDataV4(address, TypedData{
+		Types: map[string][]TypedDataField{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Mail": {
+				{Name: "from", Type: "address"},
+				{Name: "to", Type: "address"},
+				{Name: "contents", Type: "string"},
+			},
+		},
+		PrimaryType: "Mail",
+		Domain: TypedDataDomain{
+			Name:              "Ether Mail",
+			Version:           "1",
+			ChainID:           big.NewInt(1),
+			VerifyingContract: "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
+		},
+		Message: map[string]interface{}{
+			"from":     "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826",
+			"to":       "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB",
+			"contents": "Hello, Bob!",
+		},
+	})
+	s.Require().Nil(err)
+	s.Require().Equal(result, signature)
+}
+
 func (s *EthRPCTestSuite) TestSendTransaction() {
 	t := T{
 		From:     "0x3cc1a3c082944b9dba70e490e481dd56",
@@ -801,6 +1055,74 @@ func (s *EthRPCTestSuite) TestEthGetBlockByNumber() {
 	s.Require().Nil(err)
 }
 
+func (s *EthRPCTestSuite) TestEthGetUncleByBlockHashAndIndex() {
+	hash := "0x2bdda43f649c564642101fc990f569dd855e60f88bf83e931f509a92c62700f9"
+	s.registerResponse(`{"number": "0x4055d4", "hash": "0x913f938dcb4ff83b2b6b42a0cf6517d438a3ce95174e9342c780fd20c84dfd03", "uncles": []}`, func(body []byte) {
+		s.methodEqual(body, "eth_getUncleByBlockHashAndIndex")
+		s.paramsEqual(body, fmt.Sprintf(`["%s", "0x1"]`, hash))
+	})
+
+	uncle, err := s.rpc.EthGetUncleByBlockHashAndIndex(hash, 1)
+	s.Require().Nil(err)
+	s.Require().Equal(4216276, uncle.Number)
+	s.Require().Equal("0x913f938dcb4ff83b2b6b42a0cf6517d438a3ce95174e9342c780fd20c84dfd03", uncle.Hash)
+
+	s.registerResponse(`null`, func(body []byte) {})
+	uncle, err = s.rpc.EthGetUncleByBlockHashAndIndex(hash, 2)
+	s.Require().Nil(err)
+	s.Require().Nil(uncle)
+}
+
+func (s *EthRPCTestSuite) TestEthGetUncleByBlockNumberAndIndex() {
+	s.registerResponse(`{"number": "0x4055d4"}`, func(body []byte) {
+		s.methodEqual(body, "eth_getUncleByBlockNumberAndIndex")
+		s.paramsEqual(body, `["0x4055d5", "0x0"]`)
+	})
+
+	uncle, err := s.rpc.EthGetUncleByBlockNumberAndIndex(4216277, 0)
+	s.Require().Nil(err)
+	s.Require().Equal(4216276, uncle.Number)
+}
+
+func (s *EthRPCTestSuite) TestGetUncles() {
+	block := &Block{
+		Hash: "0x2bdda43f649c564642101fc990f569dd855e60f88bf83e931f509a92c62700f9",
+		Uncles: []string{
+			"0x913f938dcb4ff83b2b6b42a0cf6517d438a3ce95174e9342c780fd20c84dfd03",
+			"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+		},
+	}
+
+	uncles, err := s.rpc.GetUncles(&Block{})
+	s.Require().Nil(err)
+	s.Require().Len(uncles, 0)
+
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := s.getBody(request)
+		s.JSONEq(fmt.Sprintf(`[
+			{"id": 1, "jsonrpc": "2.0", "method": "eth_getUncleByBlockHashAndIndex", "params": ["%s", "0x0"]},
+			{"id": 2, "jsonrpc": "2.0", "method": "eth_getUncleByBlockHashAndIndex", "params": ["%s", "0x1"]}
+		]`, block.Hash, block.Hash), string(body))
+
+		return httpmock.NewStringResponse(200, fmt.Sprintf(`[
+			{"jsonrpc": "2.0", "id": 1, "result": {"hash": "%s", "number": "0x4055d4", "miner": "0x1e9939daaad6924ad004c2560e90804164900341"}},
+			{"jsonrpc": "2.0", "id": 2, "result": {"hash": "%s", "number": "0x4055d3"}}
+		]`, block.Uncles[0], block.Uncles[1])), nil
+	})
+
+	uncles, err = s.rpc.GetUncles(block)
+	s.Require().Nil(err)
+	s.Require().Len(uncles, 2)
+	s.Require().Equal(block.Uncles[0], uncles[0].Hash)
+	s.Require().Equal("0x1e9939daaad6924ad004c2560e90804164900341", uncles[0].Miner)
+	s.Require().Equal(block.Uncles[1], uncles[1].Hash)
+	s.Require().Equal(4216275, uncles[1].Number)
+
+	s.registerResponse(`null`, func(body []byte) {})
+	_, err = s.rpc.GetUncles(block)
+	s.Require().NotNil(err)
+}
+
 func (s *EthRPCTestSuite) TestEthCall() {
 	s.registerResponse(`"0x11"`, func(body []byte) {
 		s.methodEqual(body, "eth_call")
@@ -835,6 +1157,32 @@ func (s *EthRPCTestSuite) TestEthEstimateGas() {
 	s.Require().Equal(20514, result)
 }
 
+func (s *EthRPCTestSuite) TestEthCallRevert() {
+	s.registerResponses(map[string]string{
+		"eth_call": `{"code": 3, "message": "execution reverted: Not enough Ether provided.", "data": "` + revertReasonData + `"}`,
+	})
+	_, err := s.rpc.EthCall(T{To: "0x222"}, "latest")
+	ethErr, ok := err.(EthError)
+	s.Require().True(ok)
+	s.Require().Equal(EthError{Code: 3, Message: "execution reverted: Not enough Ether provided.", Data: `"` + revertReasonData + `"`}, ethErr)
+	data, ok := ethErr.RevertData()
+	s.Require().True(ok)
+	s.Require().Equal(hexToBytesMust(revertReasonData), data)
+	s.Require().True(errors.Is(err, ErrExecutionReverted))
+
+	s.registerResponses(map[string]string{
+		"eth_estimateGas": `{"code": 3, "message": "execution reverted", "data": "` + panicData + `"}`,
+	})
+	_, err = s.rpc.EthEstimateGas(T{To: "0x222"})
+	s.Require().Equal(EthError{Code: 3, Message: "execution reverted", Data: `"` + panicData + `"`}, err)
+
+	s.registerResponses(map[string]string{
+		"eth_call": `{"code": -32000, "message": "out of gas"}`,
+	})
+	_, err = s.rpc.EthCall(T{To: "0x222"}, "latest")
+	s.Require().Equal(EthError{Code: -32000, Message: "out of gas"}, err)
+}
+
 func (s *EthRPCTestSuite) TestEthGetTransactionReceipt() {
 	hash := "0x9c17afa5336d3cfd47e2e795520959b92e627e123e538fd4d5d7ece9025a8dce"
 	s.registerResponseError(errors.New("error"))
@@ -896,6 +1244,239 @@ func (s *EthRPCTestSuite) TestEthGetTransactionReceipt() {
 	}, receipt.Logs[0])
 }
 
+func (s *EthRPCTestSuite) TestBatchCall() {
+	s.registerResponseError(errors.New("error"))
+	err := s.rpc.BatchCall([]BatchElem{{Method: "eth_blockNumber"}})
+	s.Require().NotNil(err)
+
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := s.getBody(request)
+		s.JSONEq(`[
+			{"id": 1, "jsonrpc": "2.0", "method": "eth_blockNumber", "params": null},
+			{"id": 2, "jsonrpc": "2.0", "method": "eth_getBalance", "params": ["0x407d73d8a49eeb85d32cf465507dd71d507100c1", "latest"]},
+			{"id": 3, "jsonrpc": "2.0", "method": "eth_coinbase", "params": null}
+		]`, string(body))
+
+		return httpmock.NewStringResponse(200, `[
+			{"jsonrpc": "2.0", "id": 2, "error": {"code": -32000, "message": "header not found"}},
+			{"jsonrpc": "2.0", "id": 1, "result": "0x4b7"}
+		]`), nil
+	})
+
+	var number string
+	elems := []BatchElem{
+		{Method: "eth_blockNumber", Result: &number},
+		{Method: "eth_getBalance", Params: []interface{}{"0x407d73d8a49eeb85d32cf465507dd71d507100c1", "latest"}},
+		{Method: "eth_coinbase"},
+	}
+	err = s.rpc.BatchCall(elems)
+	s.Require().Nil(err)
+	s.Require().Nil(elems[0].Error)
+	s.Require().Equal("0x4b7", number)
+	s.Require().Equal(EthError{Code: -32000, Message: "header not found"}, elems[1].Error)
+	s.Require().NotNil(elems[2].Error)
+
+	// Test batch is not supported
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, `{"jsonrpc": "2.0", "id": null, "error": {"code": -32600, "message": "batch not supported"}}`), nil
+	})
+	err = s.rpc.BatchCall(elems)
+	s.Require().Equal(EthError{Code: -32600, Message: "batch not supported"}, err)
+}
+
+func (s *EthRPCTestSuite) TestResponseValidation() {
+	strict := New(s.rpc.url, WithStrictValidation(true))
+	response := ""
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, response), nil
+	})
+
+	for _, test := range []struct {
+		response string
+		lenient  bool
+		strict   bool
+	}{
+		{`{"jsonrpc": "2.0", "id": 1, "result": "0x1"}`, true, true},
+		{`{"jsonrpc": "2.0", "id": 1, "result": null}`, true, true},
+		{`{"id": 1, "result": "0x1"}`, true, false},
+		{`{"jsonrpc": "1.0", "id": 1, "result": "0x1"}`, true, false},
+		{`{"jsonrpc": "2.0", "result": "0x1"}`, true, false},
+		{`{"jsonrpc": "2.0", "id": null, "result": "0x1"}`, true, false},
+		{`{"jsonrpc": "2.0", "id": "1", "result": "0x1"}`, true, false},
+		{`{"jsonrpc": "2.0", "id": 2, "result": "0x1"}`, false, false},
+		{`{"jsonrpc": "2.0", "id": "2", "result": "0x1"}`, false, false},
+		{`{"jsonrpc": "2.0", "id": "a", "result": "0x1"}`, false, false},
+		{`{"jsonrpc": "2.0", "id": 1}`, false, false},
+		{`{"jsonrpc": "2.0", "id": 1, "error": null}`, false, false},
+	} {
+		response = test.response
+		_, err := s.rpc.Call("test")
+		s.Require().Equal(test.lenient, err == nil, "lenient %s: %v", test.response, err)
+		if err != nil {
+			s.Require().True(errors.Is(err, ErrInvalidResponse))
+		}
+
+		_, err = strict.Call("test")
+		s.Require().Equal(test.strict, err == nil, "strict %s: %v", test.response, err)
+		if err != nil {
+			s.Require().True(errors.Is(err, ErrInvalidResponse))
+		}
+	}
+
+	// Error is preferred in lenient mode
+	response = `{"jsonrpc": "2.0", "id": 1, "result": "0x1", "error": {"code": 1, "message": "error"}}`
+	_, err := s.rpc.Call("test")
+	s.Require().Equal(EthError{Code: 1, Message: "error"}, err)
+	_, err = strict.Call("test")
+	s.Require().True(errors.Is(err, ErrInvalidResponse))
+
+	// Parse and invalid request errors have null id
+	response = `{"jsonrpc": "2.0", "id": null, "error": {"code": -32700, "message": "parse error"}}`
+	_, err = s.rpc.Call("test")
+	s.Require().Equal(EthError{Code: -32700, Message: "parse error"}, err)
+	_, err = strict.Call("test")
+	s.Require().Equal(EthError{Code: -32700, Message: "parse error"}, err)
+
+	response = `[{"jsonrpc": "2.0", "id": null, "error": {"code": -32600, "message": "invalid request"}}]`
+	err = strict.BatchCall([]BatchElem{{Method: "eth_blockNumber"}})
+	s.Require().Equal(EthError{Code: -32600, Message: "invalid request"}, err)
+
+	// Error with mismatched id is still rejected
+	response = `{"jsonrpc": "2.0", "id": 2, "error": {"code": -32700, "message": "parse error"}}`
+	_, err = strict.Call("test")
+	s.Require().True(errors.Is(err, ErrInvalidResponse))
+
+	elems := []BatchElem{{Method: "eth_blockNumber"}, {Method: "eth_coinbase"}}
+	response = `[{"id": 1, "result": "0x1"}, {"jsonrpc": "2.0", "id": 2}]`
+	err = s.rpc.BatchCall(elems)
+	s.Require().Nil(err)
+	s.Require().Nil(elems[0].Error)
+	s.Require().True(errors.Is(elems[1].Error, ErrInvalidResponse))
+
+	err = strict.BatchCall(elems)
+	s.Require().True(errors.Is(err, ErrInvalidResponse))
+
+	response = `[{"jsonrpc": "2.0", "id": 1, "result": "0x1"}, {"jsonrpc": "2.0", "id": 1, "result": "0x2"}]`
+	err = s.rpc.BatchCall(elems)
+	s.Require().Nil(err)
+	s.Require().NotNil(elems[1].Error)
+
+	err = strict.BatchCall(elems)
+	s.Require().True(errors.Is(err, ErrInvalidResponse))
+}
+
+func (s *EthRPCTestSuite) TestEthGetBlockReceipts() {
+	hash := "0x11537af16aec572bb72d6d52e2c801dbfc10f42ab6ea849fd8e31b57d7099eea"
+	result := `[{
+		"blockHash": "0x11537af16aec572bb72d6d52e2c801dbfc10f42ab6ea849fd8e31b57d7099eea",
+		"blockNumber": "0x3919d3",
+		"cumulativeGasUsed": "0x5208",
+		"gasUsed": "0x5208",
+		"logs": [],
+		"transactionHash": "0x9c17afa5336d3cfd47e2e795520959b92e627e123e538fd4d5d7ece9025a8dce",
+		"transactionIndex": "0x0",
+		"status": "0x1"
+	}]`
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "eth_getBlockReceipts")
+		s.paramsEqual(body, fmt.Sprintf(`["%s"]`, hash))
+	})
+
+	receipts, err := s.rpc.EthGetBlockReceipts(hash)
+	s.Require().Nil(err)
+	s.Require().Len(receipts, 1)
+	s.Require().Equal("0x9c17afa5336d3cfd47e2e795520959b92e627e123e538fd4d5d7ece9025a8dce", receipts[0].TransactionHash)
+	s.Require().Equal(3742163, receipts[0].BlockNumber)
+	s.Require().Equal(21000, receipts[0].GasUsed)
+	s.Require().Equal("0x1", receipts[0].Status)
+}
+
+func (s *EthRPCTestSuite) TestGetBlockReceipts() {
+	block := &Block{
+		Hash: "0x11537af16aec572bb72d6d52e2c801dbfc10f42ab6ea849fd8e31b57d7099eea",
+		Transactions: []Transaction{
+			{Hash: "0x9c17afa5336d3cfd47e2e795520959b92e627e123e538fd4d5d7ece9025a8dce"},
+			{Hash: "0xa72743a3608e2ae7b3d1cc1f0e3ceed9a1c78d803eba5f28d5d6908adfaa211c"},
+		},
+	}
+
+	// Test http error
+	s.registerResponseError(errors.New("error"))
+	_, err := s.rpc.GetBlockReceipts(block)
+	s.Require().NotNil(err)
+
+	// Test fallback to eth_getTransactionReceipt
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := s.getBody(request)
+		if body[0] == '{' {
+			s.methodEqual(body, "eth_getBlockReceipts")
+			return httpmock.NewStringResponse(200, `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32601, "message": "the method eth_getBlockReceipts does not exist/is not available"}}`), nil
+		}
+
+		s.JSONEq(`[
+			{"id": 1, "jsonrpc": "2.0", "method": "eth_getTransactionReceipt", "params": ["0x9c17afa5336d3cfd47e2e795520959b92e627e123e538fd4d5d7ece9025a8dce"]},
+			{"id": 2, "jsonrpc": "2.0", "method": "eth_getTransactionReceipt", "params": ["0xa72743a3608e2ae7b3d1cc1f0e3ceed9a1c78d803eba5f28d5d6908adfaa211c"]}
+		]`, string(body))
+
+		return httpmock.NewStringResponse(200, `[
+			{"jsonrpc": "2.0", "id": 2, "result": {"transactionHash": "0xa72743a3608e2ae7b3d1cc1f0e3ceed9a1c78d803eba5f28d5d6908adfaa211c", "transactionIndex": "0x1", "logs": []}},
+			{"jsonrpc": "2.0", "id": 1, "result": {"transactionHash": "0x9c17afa5336d3cfd47e2e795520959b92e627e123e538fd4d5d7ece9025a8dce", "transactionIndex": "0x0", "logs": []}}
+		]`), nil
+	})
+
+	receipts, err := s.rpc.GetBlockReceipts(block)
+	s.Require().Nil(err)
+	s.Require().Len(receipts, 2)
+	s.Require().Equal(block.Transactions[0].Hash, receipts[0].TransactionHash)
+	s.Require().Equal(block.Transactions[1].Hash, receipts[1].TransactionHash)
+	s.Require().Equal(1, receipts[1].TransactionIndex)
+
+	// Test missing receipt
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := s.getBody(request)
+		if body[0] == '{' {
+			return httpmock.NewStringResponse(200, `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32601, "message": "method not found"}}`), nil
+		}
+
+		return httpmock.NewStringResponse(200, `[{"jsonrpc": "2.0", "id": 1, "result": null}, {"jsonrpc": "2.0", "id": 2, "result": null}]`), nil
+	})
+	_, err = s.rpc.GetBlockReceipts(block)
+	s.Require().NotNil(err)
+
+	// Test no fallback on other node errors
+	s.registerResponses(map[string]string{
+		"eth_getBlockReceipts": `{"code": -32000, "message": "header not found"}`,
+	})
+	_, err = s.rpc.GetBlockReceipts(block)
+	s.Require().True(errors.Is(err, ErrNotFound))
+
+	// Test receipts number mismatch
+	s.registerResponse(`[{"transactionHash": "0x9c17afa5336d3cfd47e2e795520959b92e627e123e538fd4d5d7ece9025a8dce", "logs": []}]`, func(body []byte) {
+		s.methodEqual(body, "eth_getBlockReceipts")
+	})
+	_, err = s.rpc.GetBlockReceipts(block)
+	s.Require().NotNil(err)
+
+	// Test null receipts of unknown block
+	s.registerResponse(`null`, func(body []byte) {
+		s.methodEqual(body, "eth_getBlockReceipts")
+	})
+	_, err = s.rpc.GetBlockReceipts(&Block{Hash: block.Hash})
+	s.Require().NotNil(err)
+
+	// Test block without transactions
+	s.registerResponse(`[]`, func(body []byte) {
+		s.methodEqual(body, "eth_getBlockReceipts")
+	})
+	receipts, err = s.rpc.GetBlockReceipts(&Block{Hash: block.Hash})
+	s.Require().Nil(err)
+	s.Require().Equal([]TransactionReceipt{}, receipts)
+}
+
 func (s *EthRPCTestSuite) TestGetTransaction() {
 	result := `{
         "blockHash": "0x8b0404b2e5173e7abdbfc98f521d50808486ccaff3cd0a6344e0bb6c7aa8cef0",
@@ -1162,10 +1743,10 @@ func TestEthRPCTestSuite(t *testing.T) {
 
 func TestEthError(t *testing.T) {
 	var err error
-	err = EthError{-32555, "Messg"}
+	err = EthError{Code: -32555, Message: "Messg"}
 	require.Equal(t, "Error -32555 (Messg)", err.Error())
 
-	err = EthError{32847, "Kuku"}
+	err = EthError{Code: 32847, Message: "Kuku"}
 	require.Equal(t, "Error 32847 (Kuku)", err.Error())
 }
 
@@ -0,0 +1,175 @@
+package ethrpc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onrik/ethrpc/rlp"
+)
+
+var (
+	// ErrBlockHashMismatch - returned when block hash doesn't match the header fields
+	ErrBlockHashMismatch = errors.New("block hash mismatch")
+	// ErrParentHashMismatch - returned when block parent hash doesn't match the hash of the previous block
+	ErrParentHashMismatch = errors.New("parent hash mismatch")
+)
+
+// HeaderHash returns the block hash computed from the header fields.
+// Header layout (legacy, London, Shanghai, Cancun or Prague) is detected by the fields present in the block.
+func (b Block) HeaderHash() (string, error) {
+	fields, err := b.headerFields()
+	if err != nil {
+		return "", err
+	}
+
+	encoded, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("0x%x", Keccak256(encoded)), nil
+}
+
+// VerifyHash checks that the block hash matches the header fields
+func (b Block) VerifyHash() error {
+	hash, err := b.HeaderHash()
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(hash, b.Hash) {
+		return fmt.Errorf("%w: block %d has hash %s, computed %s", ErrBlockHashMismatch, b.Number, b.Hash, hash)
+	}
+
+	return nil
+}
+
+// VerifyChain checks hashes of the consecutive blocks and that every block references the previous one as parent
+func VerifyChain(blocks []Block) error {
+	for i, block := range blocks {
+		if err := block.VerifyHash(); err != nil {
+			return err
+		}
+		if i == 0 {
+			continue
+		}
+
+		parent := blocks[i-1]
+		if block.Number != parent.Number+1 {
+			return fmt.Errorf("%w: block %d follows block %d", ErrParentHashMismatch, block.Number, parent.Number)
+		}
+		if !strings.EqualFold(block.ParentHash, parent.Hash) {
+			return fmt.Errorf("%w: block %d has parent hash %s, previous block hash %s", ErrParentHashMismatch, block.Number, block.ParentHash, parent.Hash)
+		}
+	}
+
+	return nil
+}
+
+// headerFields returns header fields in RLP order
+func (b Block) headerFields() ([]interface{}, error) {
+	decode := func(name, value string, size int) ([]byte, error) {
+		data, err := hexToBytes(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		if size > 0 && len(data) != size {
+			return nil, fmt.Errorf("invalid %s length %d, expected %d", name, len(data), size)
+		}
+		return data, nil
+	}
+
+	values := []struct {
+		name  string
+		value string
+		size  int
+	}{
+		{"parent hash", b.ParentHash, 32},
+		{"uncles hash", b.Sha3Uncles, 32},
+		{"miner", b.Miner, 20},
+		{"state root", b.StateRoot, 32},
+		{"transactions root", b.TransactionsRoot, 32},
+		{"receipts root", b.ReceiptsRoot, 32},
+		{"logs bloom", b.LogsBloom, 256},
+	}
+
+	fields := []interface{}{}
+	for _, v := range values {
+		data, err := decode(v.name, v.value, v.size)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, data)
+	}
+
+	extraData, err := decode("extra data", b.ExtraData, 0)
+	if err != nil {
+		return nil, err
+	}
+	mixHash, err := decode("mix hash", b.MixHash, 32)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := decode("nonce", b.Nonce, 8)
+	if err != nil {
+		return nil, err
+	}
+
+	fields = append(fields,
+		&b.Difficulty,
+		uint64(b.Number),
+		uint64(b.GasLimit),
+		uint64(b.GasUsed),
+		uint64(b.Timestamp),
+		extraData,
+		mixHash,
+		nonce,
+	)
+
+	// Fork fields are appended in order, every fork requires the fields of previous ones
+	if b.BaseFeePerGas == nil {
+		if b.WithdrawalsRoot != "" || b.BlobGasUsed != nil || b.RequestsHash != "" {
+			return nil, errors.New("invalid header: base fee is missing")
+		}
+		return fields, nil
+	}
+	fields = append(fields, b.BaseFeePerGas)
+
+	if b.WithdrawalsRoot == "" {
+		if b.BlobGasUsed != nil || b.RequestsHash != "" {
+			return nil, errors.New("invalid header: withdrawals root is missing")
+		}
+		return fields, nil
+	}
+	withdrawalsRoot, err := decode("withdrawals root", b.WithdrawalsRoot, 32)
+	if err != nil {
+		return nil, err
+	}
+	fields = append(fields, withdrawalsRoot)
+
+	if b.BlobGasUsed == nil {
+		if b.RequestsHash != "" {
+			return nil, errors.New("invalid header: blob gas used is missing")
+		}
+		return fields, nil
+	}
+	if b.ExcessBlobGas == nil {
+		return nil, errors.New("invalid header: excess blob gas is missing")
+	}
+	parentBeaconBlockRoot, err := decode("parent beacon block root", b.ParentBeaconBlockRoot, 32)
+	if err != nil {
+		return nil, err
+	}
+	fields = append(fields, uint64(*b.BlobGasUsed), uint64(*b.ExcessBlobGas), parentBeaconBlockRoot)
+
+	if b.RequestsHash == "" {
+		return fields, nil
+	}
+	requestsHash, err := decode("requests hash", b.RequestsHash, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(fields, requestsHash), nil
+}
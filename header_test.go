@@ -0,0 +1,296 @@
+package ethrpc
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var emptyBloom = "0x" + strings.Repeat("00", 256)
+
+// mainnet genesis and first block
+func mainnetBlocks() []Block {
+	return []Block{
+		{
+			Number:           0,
+			Hash:             "0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+			ParentHash:       "0x0000000000000000000000000000000000000000000000000000000000000000",
+			Nonce:            "0x0000000000000042",
+			Sha3Uncles:       "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+			LogsBloom:        emptyBloom,
+			TransactionsRoot: "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+			ReceiptsRoot:     "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+			StateRoot:        "0xd7f8974fb5ac78d9ac099b9ad5018bedc2ce0a72dad1827a1709da30580f0544",
+			Miner:            "0x0000000000000000000000000000000000000000",
+			Difficulty:       *big.NewInt(17179869184),
+			ExtraData:        "0x11bbe8db4e347b4e8c937c1c8370e4b5ed33adb3db69cbdb7a38e1e50b1b82fa",
+			GasLimit:         5000,
+			MixHash:          "0x0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			Number:           1,
+			Hash:             "0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6",
+			ParentHash:       "0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+			Nonce:            "0x539bd4979fef1ec4",
+			Sha3Uncles:       "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+			LogsBloom:        emptyBloom,
+			TransactionsRoot: "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+			ReceiptsRoot:     "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+			StateRoot:        "0xd67e4d450343046425ae4271474353857ab860dbc0a1dde64b41b5cd3a532bf3",
+			Miner:            "0x05a56e2d52c817161883f50c441c3228cfe54d9f",
+			Difficulty:       *big.NewInt(17171480576),
+			ExtraData:        "0x476574682f76312e302e302f6c696e75782f676f312e342e32",
+			GasLimit:         5000,
+			Timestamp:        1438269988,
+			MixHash:          "0x969b900de27b6ac6a67742365dd65f55a0526c41fd18e1b16f1a1215c2e66f59",
+		},
+	}
+}
+
+// blocks of London, Shanghai, Cancun and Prague forks,
+// London block is from Sepolia and Prague block is from Pectra devnet (chain id 7072151312)
+func forkBlocks() []Block {
+	intPtr := func(value int) *int { return &value }
+
+	return []Block{
+		// London, Sepolia block 175881
+		{
+			Number:           175881,
+			Hash:             "0x39723cd3caf2b11067d5a95564c802ed6504bb48ed3e70bb7ebff341d181ca13",
+			ParentHash:       "0x8b699bb417a17d96550319721e7baf1da8a995d6c1515484017435a827626389",
+			Nonce:            "0x50b71dc8e657a786",
+			Sha3Uncles:       "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+			LogsBloom:        "0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+			TransactionsRoot: "0x35ec65e8eb9fb5c1d05922960dfe266d17a766c16b19822e7f0c99c9eb843173",
+			ReceiptsRoot:     "0x09e41ef90db5a42e8a4d9a5ccdfe58c208534b3d45111bdcf92f969a3abb1581",
+			StateRoot:        "0x2cf027cd924a550979acdc10f48a13bf474c0b269133843ee55523abf7c89d15",
+			Miner:            "0x2f14582947e292a2ecd20c430b46f2d27cfe213c",
+			Difficulty:       *big.NewInt(1115553),
+			ExtraData:        "0x",
+			GasLimit:         8000000,
+			GasUsed:          429279,
+			Timestamp:        1637532103,
+			MixHash:          "0xad34a99a92b099822b2078e6efb686f8d4c7d319ab246e9d3b6c31556e2fa049",
+			BaseFeePerGas:    big.NewInt(7),
+		},
+		// Shanghai, mainnet block 18189758
+		{
+			Number:           18189758,
+			Hash:             "0x802acf5c350f4252e31d83c431fcb259470250fa0edf49e8391cfee014239820",
+			ParentHash:       "0xf08c1d3dd9cc49d708e89dfe8543dead59bda12ebc714c9df0a5902259dd4fb4",
+			Nonce:            "0x0000000000000000",
+			Sha3Uncles:       "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+			LogsBloom:        "0xdaa17125c458582c508070b48993d338a9aaab4f0f902129981d200a8110108262b67dd54282243420d2138b013505390a9333083f917cc0d660958ab12ea300e013a1dc040bdc18890f7a19d95a80e43e8326e289c79c880ddaecc69e62a0c019087924d209c18730c210b24c265c0f02974088880844b29754921a52793855874822d02a468aa0114dc4c84a230c96600e6485ed1d8c8eee6900ce14d8166d82a0f0c14aac2042e10600e851d68c31260a0ea844b32833244d056711105941c7c1129239c51d395142886aac98f20748382938044ea6534a04513a42303063a83eb1960b326db1c3a7609a8881c801aaa09a9b5b0038f3806bbd475f971c43",
+			TransactionsRoot: "0x1d7757cb83f4a319a23490400ddca36c92685217b4d98c6b86a6fe8929cc8ed7",
+			ReceiptsRoot:     "0x4e30ab0d1b712b4b4b93864f956287dfcd688f3c077dd356d1b78b6d316d1622",
+			StateRoot:        "0x7a4d9731f6fbcb9135225b82edb9418b8bf9407957a524cd3d3f0e60dd520974",
+			Miner:            "0x4838b106fce9647bdf1e7877bf73ce8b0bad5f97",
+			Difficulty:       *big.NewInt(0),
+			ExtraData:        "0x546974616e2028746974616e6275696c6465722e78797a29",
+			GasLimit:         29970705,
+			GasUsed:          10355584,
+			Timestamp:        1695365963,
+			MixHash:          "0xf25f7763261cdf5ba7a89b400998a1403f12dde232c5d9ed85caeac1f30974b2",
+			BaseFeePerGas:    big.NewInt(8339352708),
+			WithdrawalsRoot:  "0x2000a17ef6773049d73297ceffc1d2c67444c02b49681cd5101561af43454b14",
+		},
+		// Cancun, mainnet block 19431837
+		{
+			Number:                19431837,
+			Hash:                  "0x4cf7d9108fc01b50023ab7cab9b372a96068fddcadec551630393b65acb1f34c",
+			ParentHash:            "0x5cb0f2822e542e2c6fbc0099aa8f996509c178bfaa634e04b728add8da42c65d",
+			Nonce:                 "0x0000000000000000",
+			Sha3Uncles:            "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+			LogsBloom:             "0xbffdca4be5945bfbba8a8ed5eadb7ff2dcefce7f6cb67b94cf81ad38dc9a943b76e541efe10b2768ded9de385ffdd9596b79a4ecffbafd407ffca3453cff2d9ebf7f57ffe3069abb7eebf66eddc460ecd9ef7ded9c67de1b1ccb7ce9e9f9cf7e3fdcdc2fbe974ae2be4cd35271d47b5bda4459fde93d3f0bead5c558997b18386ef38ff77e234f6eb7cda7d47bee4ab6b273b8f9ffb37d5be6ffb7dac9ffbd36ffc6eb33ffaa7f832f264dc5f9966fed1fc7c0fdf6fb719e7fb39b6e38dddfe3defbde6a7668fb7f2166e79fb8df91adbd73545fbf3ae59caeedf7df6937fc5039fafaff21fd720fd9f5d6a3e85798e0d7abde86f3a6afff6383fb0beefcdc0f",
+			TransactionsRoot:      "0xacf2110d276ab7a6d550c184f6beee5bd9832ec7443b55df09d49f529fa1899f",
+			ReceiptsRoot:          "0x09fdee17a2dafb2328798f9e47b44e50a5a8e5d9951929afa51f70fc222846c2",
+			StateRoot:             "0xca4e0ab986d29ee5bddd8b4b9d9481e90d7bbd1ce7ee9e0d077c89ba03cdcf32",
+			Miner:                 "0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5",
+			Difficulty:            *big.NewInt(0),
+			ExtraData:             "0x6265617665726275696c642e6f7267",
+			GasLimit:              30000000,
+			GasUsed:               28138718,
+			Timestamp:             1710402179,
+			MixHash:               "0xb48f684132ba484557c07ea6964d6b3841607a44a540a24dd31cbbccb14f06a5",
+			BaseFeePerGas:         big.NewInt(44330915133),
+			WithdrawalsRoot:       "0x4b74822fc47c7ff8368d8b0b99aa39ea8f451f2cf4de7fae6b901309a94de4ca",
+			BlobGasUsed:           intPtr(131072),
+			ExcessBlobGas:         intPtr(0),
+			ParentBeaconBlockRoot: "0x5a585679198d1bae7f337f987496d22c9f0db95fb1bcd4d8069a74be0e76a5ae",
+		},
+		// Prague, Pectra devnet block 141654
+		{
+			Number:                141654,
+			Hash:                  "0xf6730485a38be5ada3e110990a2c7adaabd2e8d4a49782134f1a8bfbc246a5d7",
+			ParentHash:            "0xef54f75df413929ddfa60638b93feab47a0ad57e7585069308dc3b31beb42e05",
+			Nonce:                 "0x0000000000000000",
+			Sha3Uncles:            "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+			LogsBloom:             "0x00200000008000000000000080000040000000000000100000000000200000000000000000800000000080000000000000000000000010000000000000000000000020000000010001800408000000220000000000000000000000000000000000000000000000000000000000000008000000000000000000000810000040000000000000000000008000000000000000000000000000080000004010000000800000000000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000001000000000000000000000000000000000010200000000000000000000000000000000000000000000",
+			TransactionsRoot:      "0xae07639d665d8b60d0493284710db3b16957d8f2f4fb36a005535fc55f5e39cc",
+			ReceiptsRoot:          "0x72a0eed2e520b8f791fc8dcafa8a94c3e411cba82097af3fb0287d3698c7bf0a",
+			StateRoot:             "0xa694a1983a7427b1ee0524a1619573db4e8f48368d13dde2a1103142e1e77cbb",
+			Miner:                 "0xf97e180c050e5ab072211ad2c213eb5aee4df134",
+			Difficulty:            *big.NewInt(0),
+			ExtraData:             "0xd883010f01846765746888676f312e32332e36856c696e7578",
+			GasLimit:              30000000,
+			GasUsed:               2716694,
+			Timestamp:             1740426060,
+			MixHash:               "0xbad3a8687ee866a509e9b22c4bd16d16ac2fc5a134fe8ce3477552604e5870c6",
+			BaseFeePerGas:         big.NewInt(7),
+			WithdrawalsRoot:       "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+			BlobGasUsed:           intPtr(1179648),
+			ExcessBlobGas:         intPtr(68812800),
+			ParentBeaconBlockRoot: "0xcedd94fbf2ebaf371384911b85bb3073eadcca25eeb4ab29d14acd95cd88bcfb",
+			RequestsHash:          "0xe89e36f697c18e0337e5534f6fdad0806b45fe14adf656be9690e8bfc25aa03b",
+		},
+	}
+}
+
+func TestHeaderHash(t *testing.T) {
+	for _, block := range append(mainnetBlocks(), forkBlocks()...) {
+		hash, err := block.HeaderHash()
+		require.Nil(t, err)
+		require.Equal(t, block.Hash, hash)
+		require.Nil(t, block.VerifyHash())
+	}
+
+	block := mainnetBlocks()[1]
+	block.GasUsed = 1
+	require.True(t, errors.Is(block.VerifyHash(), ErrBlockHashMismatch))
+
+	block.Nonce = "0x01"
+	_, err := block.HeaderHash()
+	require.NotNil(t, err)
+}
+
+func TestHeaderFields(t *testing.T) {
+	blobGasUsed := 131072
+	excessBlobGas := 0
+	hash := "0x" + strings.Repeat("11", 32)
+
+	block := mainnetBlocks()[1]
+	fields, err := block.headerFields()
+	require.Nil(t, err)
+	require.Len(t, fields, 15)
+
+	// London
+	block.BaseFeePerGas = big.NewInt(1000000000)
+	fields, err = block.headerFields()
+	require.Nil(t, err)
+	require.Len(t, fields, 16)
+	require.Equal(t, block.BaseFeePerGas, fields[15])
+
+	// Shanghai
+	block.WithdrawalsRoot = hash
+	fields, err = block.headerFields()
+	require.Nil(t, err)
+	require.Len(t, fields, 17)
+
+	// Cancun
+	block.BlobGasUsed = &blobGasUsed
+	block.ExcessBlobGas = &excessBlobGas
+	block.ParentBeaconBlockRoot = hash
+	fields, err = block.headerFields()
+	require.Nil(t, err)
+	require.Len(t, fields, 20)
+	require.Equal(t, uint64(131072), fields[17])
+	require.Equal(t, uint64(0), fields[18])
+
+	// Prague
+	block.RequestsHash = hash
+	fields, err = block.headerFields()
+	require.Nil(t, err)
+	require.Len(t, fields, 21)
+
+	pragueHash, err := block.HeaderHash()
+	require.Nil(t, err)
+	block.RequestsHash = ""
+	cancunHash, err := block.HeaderHash()
+	require.Nil(t, err)
+	require.NotEqual(t, pragueHash, cancunHash)
+
+	// Real blocks of every fork
+	for i, block := range forkBlocks() {
+		fields, err = block.headerFields()
+		require.Nil(t, err)
+		require.Len(t, fields, []int{16, 17, 20, 21}[i])
+
+		headerHash, err := block.HeaderHash()
+		require.Nil(t, err)
+		require.Equal(t, block.Hash, headerHash)
+		require.Nil(t, block.VerifyHash())
+	}
+
+	// Fork fields without fields of previous forks
+	block.ExcessBlobGas = nil
+	_, err = block.headerFields()
+	require.NotNil(t, err)
+
+	block.BaseFeePerGas = nil
+	_, err = block.headerFields()
+	require.NotNil(t, err)
+
+	block = mainnetBlocks()[1]
+	block.BaseFeePerGas = big.NewInt(1)
+	block.RequestsHash = hash
+	_, err = block.headerFields()
+	require.NotNil(t, err)
+}
+
+func TestVerifyChain(t *testing.T) {
+	blocks := mainnetBlocks()
+	require.Nil(t, VerifyChain(blocks))
+	require.Nil(t, VerifyChain(nil))
+
+	blocks[1].ParentHash = "0x" + strings.Repeat("00", 32)
+	blocks[1].Hash, _ = blocks[1].HeaderHash()
+	require.True(t, errors.Is(VerifyChain(blocks), ErrParentHashMismatch))
+
+	blocks = mainnetBlocks()
+	require.True(t, errors.Is(VerifyChain([]Block{blocks[1], blocks[0]}), ErrParentHashMismatch))
+
+	blocks[0].Hash = blocks[1].Hash
+	require.True(t, errors.Is(VerifyChain(blocks), ErrBlockHashMismatch))
+}
+
+func (s *EthRPCTestSuite) TestGetBlockHeaderFields() {
+	result := `{
+		"number": "0x1",
+		"hash": "0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6",
+		"mixHash": "0x969b900de27b6ac6a67742365dd65f55a0526c41fd18e1b16f1a1215c2e66f59",
+		"receiptsRoot": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+		"baseFeePerGas": "0x3b9aca00",
+		"withdrawalsRoot": "0x1111111111111111111111111111111111111111111111111111111111111111",
+		"withdrawals": [{"index": "0x1", "validatorIndex": "0x2", "address": "0x3535353535353535353535353535353535353535", "amount": "0x3"}],
+		"blobGasUsed": "0x20000",
+		"excessBlobGas": "0x0",
+		"parentBeaconBlockRoot": "0x2222222222222222222222222222222222222222222222222222222222222222",
+		"requestsHash": "0x3333333333333333333333333333333333333333333333333333333333333333",
+		"transactions": []
+	}`
+
+	for _, withTransactions := range []bool{false, true} {
+		s.registerResponse(result, func(body []byte) {})
+
+		block, err := s.rpc.EthGetBlockByNumber(1, withTransactions)
+		s.Require().Nil(err)
+		s.Require().Equal("0x969b900de27b6ac6a67742365dd65f55a0526c41fd18e1b16f1a1215c2e66f59", block.MixHash)
+		s.Require().Equal("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421", block.ReceiptsRoot)
+		s.Require().Equal(int64(1000000000), block.BaseFeePerGas.Int64())
+		s.Require().Equal("0x1111111111111111111111111111111111111111111111111111111111111111", block.WithdrawalsRoot)
+		s.Require().Equal([]Withdrawal{{Index: 1, ValidatorIndex: 2, Address: "0x3535353535353535353535353535353535353535", Amount: 3}}, block.Withdrawals)
+		s.Require().Equal(131072, *block.BlobGasUsed)
+		s.Require().Equal(0, *block.ExcessBlobGas)
+		s.Require().Equal("0x2222222222222222222222222222222222222222222222222222222222222222", block.ParentBeaconBlockRoot)
+		s.Require().Equal("0x3333333333333333333333333333333333333333333333333333333333333333", block.RequestsHash)
+	}
+
+	s.registerResponse(`{"number": "0x1"}`, func(body []byte) {})
+	block, err := s.rpc.EthGetBlockByNumber(1, false)
+	s.Require().Nil(err)
+	s.Require().Nil(block.BaseFeePerGas)
+	s.Require().Nil(block.BlobGasUsed)
+}
@@ -1,6 +1,7 @@
 package ethrpc
 
 import (
+	"encoding/hex"
 	"fmt"
 	"math/big"
 	"strconv"
@@ -38,3 +39,13 @@ func BigToHex(bigInt big.Int) string {
 
 	return "0x" + strings.TrimPrefix(fmt.Sprintf("%x", bigInt.Bytes()), "0")
 }
+
+// hexToBytes decode hex string with optional 0x prefix to bytes
+func hexToBytes(value string) ([]byte, error) {
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+	if len(value)%2 == 1 {
+		value = "0" + value
+	}
+
+	return hex.DecodeString(value)
+}
@@ -16,6 +16,8 @@ type EthereumAPI interface {
 	EthMining() (bool, error)
 	EthHashrate() (int, error)
 	EthGasPrice() (big.Int, error)
+	EthMaxPriorityFeePerGas() (big.Int, error)
+	EthChainID() (int, error)
 	EthAccounts() ([]string, error)
 	EthBlockNumber() (int, error)
 	EthGetBalance(address, block string) (big.Int, error)
@@ -27,16 +29,21 @@ type EthereumAPI interface {
 	EthGetUncleCountByBlockNumber(number int) (int, error)
 	EthGetCode(address, block string) (string, error)
 	EthSign(address, data string) (string, error)
+	EthSignTransaction(transaction T) (*SignedTransaction, error)
+	EthSignTypedDataV4(address string, data TypedData) (string, error)
 	EthSendTransaction(transaction T) (string, error)
 	EthSendRawTransaction(data string) (string, error)
 	EthCall(transaction T, tag string) (string, error)
 	EthEstimateGas(transaction T) (int, error)
 	EthGetBlockByHash(hash string, withTransactions bool) (*Block, error)
 	EthGetBlockByNumber(number int, withTransactions bool) (*Block, error)
+	EthGetUncleByBlockHashAndIndex(hash string, index int) (*Block, error)
+	EthGetUncleByBlockNumberAndIndex(number, index int) (*Block, error)
 	EthGetTransactionByHash(hash string) (*Transaction, error)
 	EthGetTransactionByBlockHashAndIndex(blockHash string, transactionIndex int) (*Transaction, error)
 	EthGetTransactionByBlockNumberAndIndex(blockNumber, transactionIndex int) (*Transaction, error)
 	EthGetTransactionReceipt(hash string) (*TransactionReceipt, error)
+	EthGetBlockReceipts(block string) ([]TransactionReceipt, error)
 	EthGetCompilers() ([]string, error)
 	EthNewFilter(params FilterParams) (string, error)
 	EthNewBlockFilter() (string, error)
@@ -45,6 +52,75 @@ type EthereumAPI interface {
 	EthGetFilterChanges(filterID string) ([]Log, error)
 	EthGetFilterLogs(filterID string) ([]Log, error)
 	EthGetLogs(params FilterParams) ([]Log, error)
+	DebugTraceTransaction(hash string, config TraceConfig) (Trace, error)
+	DebugTraceCall(transaction T, block string, config TraceConfig) (Trace, error)
+	DebugTraceBlockByNumber(number int, config TraceConfig) ([]BlockTrace, error)
+	DebugTraceBlockByHash(hash string, config TraceConfig) ([]BlockTrace, error)
+}
+
+// EngineAPI - consensus layer methods of execution client, they are served on authenticated engine endpoint
+// (port 8551 by default) and require client created with WithJWTSecret
+type EngineAPI interface {
+	EngineExchangeCapabilities(capabilities []string) ([]string, error)
+	EngineNewPayloadV1(payload ExecutionPayload) (*PayloadStatus, error)
+	EngineNewPayloadV2(payload ExecutionPayload) (*PayloadStatus, error)
+	EngineNewPayloadV3(payload ExecutionPayload, versionedHashes []string, parentBeaconBlockRoot string) (*PayloadStatus, error)
+	EngineNewPayloadV4(payload ExecutionPayload, versionedHashes []string, parentBeaconBlockRoot string, executionRequests []string) (*PayloadStatus, error)
+	EngineForkchoiceUpdatedV1(state ForkchoiceState, attributes *PayloadAttributes) (*ForkchoiceUpdatedResult, error)
+	EngineForkchoiceUpdatedV2(state ForkchoiceState, attributes *PayloadAttributes) (*ForkchoiceUpdatedResult, error)
+	EngineForkchoiceUpdatedV3(state ForkchoiceState, attributes *PayloadAttributes) (*ForkchoiceUpdatedResult, error)
+	EngineGetPayloadV1(payloadID string) (*ExecutionPayload, error)
+	EngineGetPayloadV2(payloadID string) (*ExecutionPayloadEnvelope, error)
+	EngineGetPayloadV3(payloadID string) (*ExecutionPayloadEnvelope, error)
+	EngineGetPayloadV4(payloadID string) (*ExecutionPayloadEnvelope, error)
+	EngineGetBlobsV1(versionedHashes []string) ([]*BlobAndProof, error)
+}
+
+// TraceAPI - trace_ namespace methods of Parity compatible tracing, served by Erigon, Nethermind and Reth
+type TraceAPI interface {
+	TraceBlock(number int) ([]ActionTrace, error)
+	TraceTransaction(hash string) ([]ActionTrace, error)
+	TraceFilter(params TraceFilterParams) ([]ActionTrace, error)
+	TraceCall(transaction T, traceTypes []string, block string) (*TraceResults, error)
+	TraceReplayTransaction(hash string, traceTypes []string) (*TraceResults, error)
+	TraceReplayBlockTransactions(number int, traceTypes []string) ([]TraceResults, error)
+}
+
+// TxpoolAPI - txpool_ namespace methods of pending and queued transactions of the node
+type TxpoolAPI interface {
+	TxpoolContent() (*TxpoolContent, error)
+	TxpoolContentFrom(address string) (*TxpoolContentFrom, error)
+	TxpoolInspect() (*TxpoolInspect, error)
+	TxpoolStatus() (*TxpoolStatus, error)
+}
+
+// AdminAPI - admin_ namespace methods of node and peers management, usually served only on IPC or local endpoint
+type AdminAPI interface {
+	AdminNodeInfo() (*NodeInfo, error)
+	AdminPeers() ([]PeerInfo, error)
+	AdminAddPeer(enode string) (bool, error)
+	AdminRemovePeer(enode string) (bool, error)
+	AdminAddTrustedPeer(enode string) (bool, error)
+	AdminRemoveTrustedPeer(enode string) (bool, error)
+	AdminDatadir() (string, error)
+	AdminStartHTTP(host string, port int, cors, apis string) (bool, error)
+	AdminStopHTTP() (bool, error)
+}
+
+// PersonalAPI - personal_ namespace methods of accounts managed by the node, deprecated by geth in favor of external signers
+type PersonalAPI interface {
+	PersonalNewAccount(password string) (string, error)
+	PersonalUnlockAccount(address, password string, duration int) (bool, error)
+	PersonalLockAccount(address string) (bool, error)
+	PersonalListAccounts() ([]string, error)
+	PersonalSign(data, address, password string) (string, error)
+	PersonalEcRecover(data, signature string) (string, error)
+	PersonalSendTransaction(transaction T, password string) (string, error)
 }
 
 var _ EthereumAPI = (*EthRPC)(nil)
+var _ EngineAPI = (*EthRPC)(nil)
+var _ TraceAPI = (*EthRPC)(nil)
+var _ TxpoolAPI = (*EthRPC)(nil)
+var _ AdminAPI = (*EthRPC)(nil)
+var _ PersonalAPI = (*EthRPC)(nil)
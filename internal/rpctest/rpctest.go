@@ -0,0 +1,41 @@
+// Package rpctest implements stubs of node client methods for tests of contract packages.
+package rpctest
+
+import "github.com/onrik/ethrpc"
+
+// Caller - eth_call stub, it records the last call and returns Result and Err or result of Handler if it's set
+type Caller struct {
+	Transaction ethrpc.T
+	Block       string
+	Result      string
+	Err         error
+	// Handler returns result of call instead of Result, it isn't called if Err is set
+	Handler func(transaction ethrpc.T) (string, error)
+}
+
+// EthCall records transaction and block of the call
+func (c *Caller) EthCall(transaction ethrpc.T, block string) (string, error) {
+	c.Transaction = transaction
+	c.Block = block
+	if c.Err != nil {
+		return "", c.Err
+	}
+	if c.Handler != nil {
+		return c.Handler(transaction)
+	}
+
+	return c.Result, nil
+}
+
+// LogsGetter - eth_getLogs stub, it records the last filter params and returns Logs and Err
+type LogsGetter struct {
+	Params ethrpc.FilterParams
+	Logs   []ethrpc.Log
+	Err    error
+}
+
+// EthGetLogs records filter params
+func (g *LogsGetter) EthGetLogs(params ethrpc.FilterParams) ([]ethrpc.Log, error) {
+	g.Params = params
+	return g.Logs, g.Err
+}
@@ -0,0 +1,34 @@
+package ethrpc
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Keccak256 returns legacy Keccak-256 (not the standardized SHA3-256) hash of the concatenated data
+func Keccak256(data ...[]byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	for _, chunk := range data {
+		hash.Write(chunk)
+	}
+
+	return hash.Sum(nil)
+}
+
+// EventTopic returns hex encoded topic of event signature like "Transfer(address,address,uint256)".
+// Whitespace in signature is ignored.
+func EventTopic(signature string) string {
+	return fmt.Sprintf("0x%x", Keccak256([]byte(normalizeSignature(signature))))
+}
+
+// FunctionSelector returns hex encoded 4 bytes selector of function signature like "transfer(address,uint256)".
+// Whitespace in signature is ignored.
+func FunctionSelector(signature string) string {
+	return fmt.Sprintf("0x%x", Keccak256([]byte(normalizeSignature(signature)))[:4])
+}
+
+func normalizeSignature(signature string) string {
+	return strings.Join(strings.Fields(signature), "")
+}
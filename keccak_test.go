@@ -0,0 +1,34 @@
+package ethrpc
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeccak256(t *testing.T) {
+	require.Equal(t, "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470", hex.EncodeToString(Keccak256()))
+	require.Equal(t, "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470", hex.EncodeToString(Keccak256([]byte{})))
+	require.Equal(t, "1c8aff950685c2ed4bc3174f3472287b56d9517b9c948127319a09a7a36deac8", hex.EncodeToString(Keccak256([]byte("hello"))))
+	require.Equal(t, "1c8aff950685c2ed4bc3174f3472287b56d9517b9c948127319a09a7a36deac8", hex.EncodeToString(Keccak256([]byte("he"), []byte("llo"))))
+
+	// Input longer than rate is absorbed in several blocks regardless of chunks
+	data := make([]byte, 500)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	require.Equal(t, Keccak256(data), Keccak256(data[:100], data[100:136], data[136:137], data[137:]))
+	require.NotEqual(t, Keccak256(data[:136]), Keccak256(data[:135]))
+}
+
+func TestEventTopic(t *testing.T) {
+	require.Equal(t, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", EventTopic("Transfer(address,address,uint256)"))
+	require.Equal(t, "0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925", EventTopic("Approval(address, address, uint256)"))
+}
+
+func TestFunctionSelector(t *testing.T) {
+	require.Equal(t, "0xa9059cbb", FunctionSelector("transfer(address,uint256)"))
+	require.Equal(t, "0x70a08231", FunctionSelector("balanceOf(address)"))
+	require.Equal(t, "0x095ea7b3", FunctionSelector(" approve(address, uint256) "))
+}
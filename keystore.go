@@ -0,0 +1,234 @@
+package ethrpc
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Scrypt parameters of encrypted keystore
+const (
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+	LightScryptN    = 1 << 12
+	LightScryptP    = 6
+
+	keystoreScryptR       = 8
+	keystoreDerivedKeyLen = 32
+)
+
+// ErrKeystorePassword - returned when keystore MAC doesn't match the password
+var ErrKeystorePassword = errors.New("could not decrypt key with given password")
+
+// Keystore - encrypted key in Web3 Secret Storage (keystore v3) format
+type Keystore struct {
+	Address string         `json:"address"`
+	Crypto  KeystoreCrypto `json:"crypto"`
+	ID      string         `json:"id"`
+	Version int            `json:"version"`
+}
+
+// KeystoreCrypto - encryption parameters of keystore
+type KeystoreCrypto struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams KeystoreCipherParams   `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+// KeystoreCipherParams - cipher parameters of keystore
+type KeystoreCipherParams struct {
+	IV string `json:"iv"`
+}
+
+// DecryptKeystore decrypts keystore v3 JSON with the password.
+// Supported key derivation functions are scrypt and pbkdf2 (hmac-sha256), cipher is aes-128-ctr.
+func DecryptKeystore(data []byte, password string) (*PrivateKey, error) {
+	keystore := Keystore{}
+	if err := json.Unmarshal(data, &keystore); err != nil {
+		return nil, err
+	}
+
+	if keystore.Version != 3 {
+		return nil, fmt.Errorf("unsupported keystore version %d", keystore.Version)
+	}
+	if keystore.Crypto.Cipher != "aes-128-ctr" {
+		return nil, fmt.Errorf("unsupported keystore cipher %s", keystore.Crypto.Cipher)
+	}
+
+	derivedKey, err := keystore.Crypto.derivedKey(password)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText, err := hex.DecodeString(keystore.Crypto.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore ciphertext: %w", err)
+	}
+	mac, err := hex.DecodeString(keystore.Crypto.MAC)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore mac: %w", err)
+	}
+	if !hmac.Equal(Keccak256(derivedKey[16:32], cipherText), mac) {
+		return nil, ErrKeystorePassword
+	}
+
+	iv, err := hex.DecodeString(keystore.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore iv: %w", err)
+	}
+	keyBytes, err := aesCTR(derivedKey[:16], iv, cipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := PrivateKeyFromBytes(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if keystore.Address != "" && !strings.EqualFold(strings.TrimPrefix(keystore.Address, "0x"), key.Address()[2:]) {
+		return nil, fmt.Errorf("keystore address %s doesn't match the key address %s", keystore.Address, key.Address())
+	}
+
+	return key, nil
+}
+
+func (c KeystoreCrypto) derivedKey(password string) ([]byte, error) {
+	salt, err := hex.DecodeString(fmt.Sprint(c.KDFParams["salt"]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore salt: %w", err)
+	}
+
+	param := func(name string) (int, error) {
+		value, ok := c.KDFParams[name].(float64)
+		if !ok || value <= 0 || value != float64(int(value)) {
+			return 0, fmt.Errorf("invalid keystore kdf param %s", name)
+		}
+		return int(value), nil
+	}
+
+	dkLen, err := param("dklen")
+	if err != nil {
+		return nil, err
+	}
+	if dkLen < keystoreDerivedKeyLen {
+		return nil, fmt.Errorf("invalid keystore derived key length %d", dkLen)
+	}
+
+	switch c.KDF {
+	case "scrypt":
+		n, err := param("n")
+		if err != nil {
+			return nil, err
+		}
+		r, err := param("r")
+		if err != nil {
+			return nil, err
+		}
+		p, err := param("p")
+		if err != nil {
+			return nil, err
+		}
+		return scrypt.Key([]byte(password), salt, n, r, p, dkLen)
+	case "pbkdf2":
+		if prf := fmt.Sprint(c.KDFParams["prf"]); prf != "hmac-sha256" {
+			return nil, fmt.Errorf("unsupported keystore pbkdf2 prf %s", prf)
+		}
+		iterations, err := param("c")
+		if err != nil {
+			return nil, err
+		}
+		return pbkdf2.Key([]byte(password), salt, iterations, dkLen, sha256.New), nil
+	}
+
+	return nil, fmt.Errorf("unsupported keystore kdf %s", c.KDF)
+}
+
+// EncryptKeystore encrypts the key with the password into keystore v3 JSON using scrypt with given N and P parameters.
+// Use StandardScryptN, StandardScryptP for stored keys and LightScryptN, LightScryptP when speed matters.
+func EncryptKeystore(key *PrivateKey, password string, scryptN, scryptP int) ([]byte, error) {
+	random := make([]byte, 32+aes.BlockSize+16)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+	salt, iv, id := random[:32], random[32:32+aes.BlockSize], random[32+aes.BlockSize:]
+
+	derivedKey, err := scrypt.Key([]byte(password), salt, scryptN, keystoreScryptR, scryptP, keystoreDerivedKeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText, err := aesCTR(derivedKey[:16], iv, key.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// random UUID version 4
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+
+	return json.Marshal(Keystore{
+		Address: key.Address()[2:],
+		Crypto: KeystoreCrypto{
+			Cipher:     "aes-128-ctr",
+			CipherText: hex.EncodeToString(cipherText),
+			CipherParams: KeystoreCipherParams{
+				IV: hex.EncodeToString(iv),
+			},
+			KDF: "scrypt",
+			KDFParams: map[string]interface{}{
+				"dklen": keystoreDerivedKeyLen,
+				"n":     scryptN,
+				"p":     scryptP,
+				"r":     keystoreScryptR,
+				"salt":  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(Keccak256(derivedKey[16:32], cipherText)),
+		},
+		ID:      fmt.Sprintf("%x-%x-%x-%x-%x", id[:4], id[4:6], id[6:8], id[8:10], id[10:]),
+		Version: 3,
+	})
+}
+
+func aesCTR(key, iv, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, fmt.Errorf("invalid iv length %d", len(iv))
+	}
+
+	result := make([]byte, len(data))
+	cipher.NewCTR(block, iv).XORKeyStream(result, data)
+
+	return result, nil
+}
+
+// KeystoreSigner - signer using the key from encrypted keystore
+type KeystoreSigner struct {
+	*PrivateKey
+}
+
+// NewKeystoreSigner decrypts keystore v3 JSON with the password and returns signer using the key
+func NewKeystoreSigner(data []byte, password string) (*KeystoreSigner, error) {
+	key, err := DecryptKeystore(data, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeystoreSigner{key}, nil
+}
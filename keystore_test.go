@@ -0,0 +1,94 @@
+package ethrpc
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKDF(t *testing.T) {
+	// RFC 7914 test vectors
+	kdf := KeystoreCrypto{KDF: "pbkdf2", KDFParams: map[string]interface{}{
+		"c": float64(1), "dklen": float64(64), "prf": "hmac-sha256", "salt": hex.EncodeToString([]byte("salt")),
+	}}
+	key, err := kdf.derivedKey("passwd")
+	require.Nil(t, err)
+	require.Equal(t, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783", hex.EncodeToString(key))
+
+	kdf = KeystoreCrypto{KDF: "scrypt", KDFParams: map[string]interface{}{
+		"n": float64(1024), "r": float64(8), "p": float64(16), "dklen": float64(64), "salt": hex.EncodeToString([]byte("NaCl")),
+	}}
+	key, err = kdf.derivedKey("password")
+	require.Nil(t, err)
+	require.Equal(t, "fdbabe1c9d3472007856e7190d01e9fe7c6ad7cbc8237830e77376634b3731622eaf30d92e22a3886ff109279d9830dac727afb94a83ee6d8360cbdfa2cc0640", hex.EncodeToString(key))
+
+	// N must be a power of 2
+	kdf.KDFParams["n"] = float64(1000)
+	_, err = kdf.derivedKey("password")
+	require.NotNil(t, err)
+
+	kdf.KDF = "argon2"
+	_, err = kdf.derivedKey("password")
+	require.NotNil(t, err)
+}
+
+func TestDecryptKeystore(t *testing.T) {
+	// Web3 Secret Storage test vectors
+	pbkdf2Keystore := `{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "6087dab2f9fdbbfaddc31a909735c1e6"},
+			"ciphertext": "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+			"kdf": "pbkdf2",
+			"kdfparams": {"c": 262144, "dklen": 32, "prf": "hmac-sha256", "salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},
+			"mac": "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+		},
+		"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version": 3
+	}`
+	scryptKeystore := `{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "83dbcc02d8ccb40e466191a123791e0e"},
+			"ciphertext": "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+			"kdf": "scrypt",
+			"kdfparams": {"dklen": 32, "n": 262144, "p": 8, "r": 1, "salt": "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},
+			"mac": "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+		},
+		"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version": 3
+	}`
+
+	for _, keystore := range []string{pbkdf2Keystore, scryptKeystore} {
+		key, err := DecryptKeystore([]byte(keystore), "testpassword")
+		require.Nil(t, err)
+		require.Equal(t, "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d", hex.EncodeToString(key.Bytes()))
+	}
+
+	_, err := DecryptKeystore([]byte(pbkdf2Keystore), "password")
+	require.Equal(t, ErrKeystorePassword, err)
+
+	_, err = DecryptKeystore([]byte(`{"version": 1}`), "testpassword")
+	require.NotNil(t, err)
+	_, err = DecryptKeystore([]byte(`{"version": 3, "crypto": {"cipher": "aes-128-ctr", "kdf": "argon2", "kdfparams": {"dklen": 32}}}`), "testpassword")
+	require.NotNil(t, err)
+}
+
+func TestEncryptKeystore(t *testing.T) {
+	key, err := NewPrivateKey("0x4646464646464646464646464646464646464646464646464646464646464646")
+	require.Nil(t, err)
+
+	data, err := EncryptKeystore(key, "password", LightScryptN, LightScryptP)
+	require.Nil(t, err)
+
+	signer, err := NewKeystoreSigner(data, "password")
+	require.Nil(t, err)
+	require.Equal(t, key.Address(), signer.Address())
+
+	_, err = NewKeystoreSigner(data, "wrong")
+	require.Equal(t, ErrKeystorePassword, err)
+
+	_, err = EncryptKeystore(key, "password", 1000, 1)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,335 @@
+// Package multicall implements Multicall3 client aggregating many contract calls into a single eth_call.
+package multicall
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/onrik/ethrpc/abi"
+)
+
+// Address - Multicall3 address, the contract is deployed to the same address on most chains
+const Address = "0xcA11bde05977b3631167028862bE2a173976CA11"
+
+const (
+	// DefaultMaxCalldataSize - default limit of aggregate3 calldata size of one eth_call
+	DefaultMaxCalldataSize = 128 * 1024
+	// DefaultMaxGas - default limit of estimated gas of calls in one eth_call
+	DefaultMaxGas = 30000000
+	// DefaultCallGas - default gas estimation of one call
+	DefaultCallGas = 100000
+)
+
+var (
+	// ErrCallFailed - returned by Result.Decode for failed calls
+	ErrCallFailed = errors.New("multicall: call failed")
+)
+
+// multicallABI - Multicall3 functions used by the client
+var multicallABI = abi.MustParse(`[
+	{"type": "function", "name": "aggregate3", "stateMutability": "payable", "inputs": [
+		{"name": "calls", "type": "tuple[]", "components": [
+			{"name": "target", "type": "address"},
+			{"name": "allowFailure", "type": "bool"},
+			{"name": "callData", "type": "bytes"}
+		]}
+	], "outputs": [
+		{"name": "returnData", "type": "tuple[]", "components": [
+			{"name": "success", "type": "bool"},
+			{"name": "returnData", "type": "bytes"}
+		]}
+	]},
+	{"type": "function", "name": "getEthBalance", "stateMutability": "view", "inputs": [{"name": "addr", "type": "address"}], "outputs": [{"name": "balance", "type": "uint256"}]},
+	{"type": "function", "name": "getBlockHash", "stateMutability": "view", "inputs": [{"name": "blockNumber", "type": "uint256"}], "outputs": [{"name": "blockHash", "type": "bytes32"}]},
+	{"type": "function", "name": "getBlockNumber", "stateMutability": "view", "inputs": [], "outputs": [{"name": "blockNumber", "type": "uint256"}]},
+	{"type": "function", "name": "getCurrentBlockTimestamp", "stateMutability": "view", "inputs": [], "outputs": [{"name": "timestamp", "type": "uint256"}]},
+	{"type": "function", "name": "getCurrentBlockGasLimit", "stateMutability": "view", "inputs": [], "outputs": [{"name": "gaslimit", "type": "uint256"}]},
+	{"type": "function", "name": "getCurrentBlockCoinbase", "stateMutability": "view", "inputs": [], "outputs": [{"name": "coinbase", "type": "address"}]},
+	{"type": "function", "name": "getBasefee", "stateMutability": "view", "inputs": [], "outputs": [{"name": "basefee", "type": "uint256"}]},
+	{"type": "function", "name": "getChainId", "stateMutability": "view", "inputs": [], "outputs": [{"name": "chainid", "type": "uint256"}]},
+	{"type": "function", "name": "getLastBlockHash", "stateMutability": "view", "inputs": [], "outputs": [{"name": "blockHash", "type": "bytes32"}]}
+]`)
+
+// Call - contract call aggregated by Multicall3
+type Call struct {
+	Target string
+	Data   []byte
+	// AllowFailure allows the call to fail, otherwise failure reverts the whole eth_call
+	AllowFailure bool
+	// Gas is estimation of gas used by the call for chunking, DefaultCallGas is used if zero
+	Gas int
+}
+
+// NewCall returns call of contract method with arguments encoded with ABI
+func NewCall(target string, contract *abi.ABI, method string, args ...interface{}) (Call, error) {
+	data, err := contract.Encode(method, args...)
+	if err != nil {
+		return Call{}, err
+	}
+
+	return Call{Target: target, Data: data}, nil
+}
+
+// Result - result of aggregated call
+type Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// BlockInfo - information about block of eth_call
+type BlockInfo struct {
+	Number     int
+	ParentHash string
+	Timestamp  int
+	Coinbase   string
+	GasLimit   int
+	BaseFee    *big.Int
+	ChainID    int
+}
+
+// Multicall - Multicall3 client
+type Multicall struct {
+	caller          abi.Caller
+	address         string
+	block           string
+	maxCalldataSize int
+	maxGas          int
+}
+
+// New returns Multicall3 client making calls with caller, *ethrpc.EthRPC for example
+func New(caller abi.Caller, options ...func(m *Multicall)) *Multicall {
+	m := &Multicall{
+		caller:          caller,
+		address:         Address,
+		block:           "latest",
+		maxCalldataSize: DefaultMaxCalldataSize,
+		maxGas:          DefaultMaxGas,
+	}
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+// WithAddress set address of Multicall3 contract
+func WithAddress(address string) func(m *Multicall) {
+	return func(m *Multicall) {
+		m.address = address
+	}
+}
+
+// WithBlock set block number or tag of calls, "latest" is used by default
+func WithBlock(block string) func(m *Multicall) {
+	return func(m *Multicall) {
+		m.block = block
+	}
+}
+
+// WithMaxCalldataSize set limit of calldata size of one eth_call
+func WithMaxCalldataSize(size int) func(m *Multicall) {
+	return func(m *Multicall) {
+		m.maxCalldataSize = size
+	}
+}
+
+// WithMaxGas set limit of estimated gas of calls in one eth_call
+func WithMaxGas(gas int) func(m *Multicall) {
+	return func(m *Multicall) {
+		m.maxGas = gas
+	}
+}
+
+// Aggregate makes calls with aggregate3 and returns results in order of calls.
+// Calls are split into chunks by calldata size and estimated gas, every chunk is a separate eth_call.
+func (m *Multicall) Aggregate(calls []Call) ([]Result, error) {
+	results := make([]Result, 0, len(calls))
+	for _, chunk := range m.chunks(calls) {
+		chunkResults, err := m.aggregate(chunk)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, chunkResults...)
+	}
+
+	return results, nil
+}
+
+func (m *Multicall) aggregate(calls []Call) ([]Result, error) {
+	values := make([]interface{}, len(calls))
+	for i, call := range calls {
+		values[i] = []interface{}{call.Target, call.AllowFailure, call.Data}
+	}
+
+	outputs, err := m.call("aggregate3", values)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []Result{}
+	if err := abi.Assign(&results, outputs[0]); err != nil {
+		return nil, err
+	}
+	if len(results) != len(calls) {
+		return nil, fmt.Errorf("invalid results number %d of %d calls", len(results), len(calls))
+	}
+
+	return results, nil
+}
+
+func (m *Multicall) call(method string, args ...interface{}) ([]interface{}, error) {
+	transaction, err := multicallABI.Transaction(m.address, method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := m.caller.EthCall(transaction, m.block)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := abi.DecodeHex(result)
+	if err != nil {
+		return nil, err
+	}
+
+	return multicallABI.DecodeOutput(method, data)
+}
+
+// chunks splits calls by calldata size and estimated gas, every chunk has at least one call
+func (m *Multicall) chunks(calls []Call) [][]Call {
+	// selector, offset and length of calls array
+	const overhead = 4 + 32 + 32
+
+	chunks := [][]Call{}
+	start, size, gas := 0, overhead, 0
+	for i, call := range calls {
+		// offset, target, allowFailure, offset and length of callData and padded callData
+		callSize := 5*32 + (len(call.Data)+31)/32*32
+		callGas := call.Gas
+		if callGas == 0 {
+			callGas = DefaultCallGas
+		}
+
+		if i > start && ((m.maxCalldataSize > 0 && size+callSize > m.maxCalldataSize) || (m.maxGas > 0 && gas+callGas > m.maxGas)) {
+			chunks = append(chunks, calls[start:i])
+			start, size, gas = i, overhead, 0
+		}
+		size += callSize
+		gas += callGas
+	}
+	if start < len(calls) {
+		chunks = append(chunks, calls[start:])
+	}
+
+	return chunks
+}
+
+// GetEthBalance returns balance of address
+func (m *Multicall) GetEthBalance(address string) (*big.Int, error) {
+	values, err := m.call("getEthBalance", address)
+	if err != nil {
+		return nil, err
+	}
+
+	return values[0].(*big.Int), nil
+}
+
+// GetEthBalances returns balances of addresses, calls are aggregated with aggregate3
+func (m *Multicall) GetEthBalances(addresses []string) ([]*big.Int, error) {
+	calls := make([]Call, len(addresses))
+	for i, address := range addresses {
+		call, err := NewCall(m.address, multicallABI, "getEthBalance", address)
+		if err != nil {
+			return nil, err
+		}
+		calls[i] = call
+	}
+
+	results, err := m.Aggregate(calls)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]*big.Int, len(results))
+	for i, result := range results {
+		values, err := multicallABI.DecodeOutput("getEthBalance", result.ReturnData)
+		if err != nil {
+			return nil, err
+		}
+		balances[i] = values[0].(*big.Int)
+	}
+
+	return balances, nil
+}
+
+// GetBlockHash returns hash of block by number, only hashes of 256 most recent blocks are available
+func (m *Multicall) GetBlockHash(number int) (string, error) {
+	values, err := m.call("getBlockHash", number)
+	if err != nil {
+		return "", err
+	}
+
+	hash := values[0].([32]byte)
+	return fmt.Sprintf("0x%x", hash), nil
+}
+
+// BlockInfo returns information about block of calls with single aggregate3 call
+func (m *Multicall) BlockInfo() (*BlockInfo, error) {
+	methods := []string{"getBlockNumber", "getLastBlockHash", "getCurrentBlockTimestamp", "getCurrentBlockCoinbase", "getCurrentBlockGasLimit", "getBasefee", "getChainId"}
+	calls := make([]Call, len(methods))
+	for i, method := range methods {
+		call, err := NewCall(m.address, multicallABI, method)
+		if err != nil {
+			return nil, err
+		}
+		// getBasefee isn't supported before London
+		call.AllowFailure = method == "getBasefee"
+		calls[i] = call
+	}
+
+	results, err := m.aggregate(calls)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(methods))
+	for i, result := range results {
+		if !result.Success {
+			continue
+		}
+		decoded, err := multicallABI.DecodeOutput(methods[i], result.ReturnData)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = decoded[0]
+	}
+
+	parentHash := values[1].([32]byte)
+	info := &BlockInfo{
+		Number:     int(values[0].(*big.Int).Int64()),
+		ParentHash: fmt.Sprintf("0x%x", parentHash),
+		Timestamp:  int(values[2].(*big.Int).Int64()),
+		Coinbase:   values[3].(string),
+		GasLimit:   int(values[4].(*big.Int).Int64()),
+		ChainID:    int(values[6].(*big.Int).Int64()),
+	}
+	if values[5] != nil {
+		info.BaseFee = values[5].(*big.Int)
+	}
+
+	return info, nil
+}
+
+// Decode returns return values of successful call decoded with ABI, failed calls return error with decoded revert reason if possible
+func (result Result) Decode(contract *abi.ABI, method string) ([]interface{}, error) {
+	if !result.Success {
+		if revert, err := contract.DecodeRevert(result.ReturnData); err == nil {
+			return nil, fmt.Errorf("%w: %s", ErrCallFailed, revert)
+		}
+		return nil, ErrCallFailed
+	}
+
+	return contract.DecodeOutput(method, result.ReturnData)
+}
@@ -0,0 +1,250 @@
+package multicall
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/onrik/ethrpc"
+	"github.com/onrik/ethrpc/abi"
+	"github.com/stretchr/testify/require"
+)
+
+var tokenABI = abi.MustParse(`[
+	{"type": "function", "name": "balanceOf", "stateMutability": "view", "inputs": [{"name": "account", "type": "address"}], "outputs": [{"name": "", "type": "uint256"}]},
+	{"type": "error", "name": "Paused", "inputs": []}
+]`)
+
+// testCaller - Multicall3 contract emulation
+type testCaller struct {
+	tags     []string
+	chunks   []int
+	balances map[string]int64
+	err      error
+}
+
+func (c *testCaller) EthCall(transaction ethrpc.T, tag string) (string, error) {
+	c.tags = append(c.tags, tag)
+	if c.err != nil {
+		return "", c.err
+	}
+
+	data, err := abi.DecodeHex(transaction.Data)
+	if err != nil {
+		return "", err
+	}
+	method, args, err := multicallABI.DecodeInput(data)
+	if err != nil {
+		return "", err
+	}
+
+	var output []byte
+	switch method.Name {
+	case "aggregate3":
+		calls := args[0].([][]interface{})
+		c.chunks = append(c.chunks, len(calls))
+		results := make([]interface{}, len(calls))
+		for i, call := range calls {
+			success, returnData := c.execute(call[0].(string), call[2].([]byte))
+			if !success && !call[1].(bool) {
+				return "", ethrpc.EthError{Code: 3, Message: "execution reverted: Multicall3: call failed"}
+			}
+			results[i] = []interface{}{success, returnData}
+		}
+		output, err = method.Outputs.Encode(results)
+	case "getEthBalance":
+		output, err = method.Outputs.Encode(c.balances[args[0].(string)])
+	case "getBlockHash":
+		output, err = method.Outputs.Encode([32]byte{byte(args[0].(*big.Int).Int64())})
+	default:
+		return "", errors.New("unexpected method " + method.Name)
+	}
+
+	return "0x" + hex.EncodeToString(output), err
+}
+
+// execute emulates call of token and Multicall3 block functions
+func (c *testCaller) execute(target string, data []byte) (bool, []byte) {
+	var output []byte
+	if strings.EqualFold(target, Address) {
+		method, args, err := multicallABI.DecodeInput(data)
+		if err != nil {
+			return false, nil
+		}
+		switch method.Name {
+		case "getEthBalance":
+			output, _ = method.Outputs.Encode(c.balances[args[0].(string)])
+		case "getBlockNumber":
+			output, _ = method.Outputs.Encode(100)
+		case "getLastBlockHash":
+			output, _ = method.Outputs.Encode([32]byte{99})
+		case "getCurrentBlockTimestamp":
+			output, _ = method.Outputs.Encode(1700000000)
+		case "getCurrentBlockCoinbase":
+			output, _ = method.Outputs.Encode("0x4838b106fce9647bdf1e7877bf73ce8b0bad5f97")
+		case "getCurrentBlockGasLimit":
+			output, _ = method.Outputs.Encode(30000000)
+		case "getChainId":
+			output, _ = method.Outputs.Encode(1)
+		default:
+			// getBasefee before London
+			return false, nil
+		}
+		return true, output
+	}
+
+	method, args, err := tokenABI.DecodeInput(data)
+	if err != nil {
+		return false, nil
+	}
+	balance, ok := c.balances[args[0].(string)]
+	if !ok {
+		return false, tokenABI.Errors["Paused"].ID()
+	}
+	output, _ = method.Outputs.Encode(balance)
+
+	return true, output
+}
+
+func TestAggregate(t *testing.T) {
+	caller := &testCaller{balances: map[string]int64{
+		"0x1111111111111111111111111111111111111111": 100,
+		"0x2222222222222222222222222222222222222222": 200,
+	}}
+	multicall := New(caller, WithBlock("0x10"))
+
+	calls := []Call{}
+	for _, account := range []string{"0x1111111111111111111111111111111111111111", "0x3333333333333333333333333333333333333333", "0x2222222222222222222222222222222222222222"} {
+		call, err := NewCall("0x6b175474e89094c44da98b954eedeac495271d0f", tokenABI, "balanceOf", account)
+		require.Nil(t, err)
+		call.AllowFailure = true
+		calls = append(calls, call)
+	}
+
+	results, err := multicall.Aggregate(calls)
+	require.Nil(t, err)
+	require.Len(t, results, 3)
+	require.Equal(t, []string{"0x10"}, caller.tags)
+	require.True(t, results[0].Success)
+	require.False(t, results[1].Success)
+
+	values, err := results[2].Decode(tokenABI, "balanceOf")
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{big.NewInt(200)}, values)
+
+	_, err = results[1].Decode(tokenABI, "balanceOf")
+	require.True(t, errors.Is(err, ErrCallFailed))
+	require.Equal(t, "multicall: call failed: Paused()", err.Error())
+	_, err = Result{}.Decode(tokenABI, "balanceOf")
+	require.Equal(t, ErrCallFailed, err)
+
+	// Failure of call without allowed failure reverts all calls
+	calls[1].AllowFailure = false
+	_, err = multicall.Aggregate(calls)
+	require.True(t, errors.Is(err, ethrpc.ErrExecutionReverted))
+
+	results, err = multicall.Aggregate(nil)
+	require.Nil(t, err)
+	require.Len(t, results, 0)
+
+	_, err = NewCall("0x6b175474e89094c44da98b954eedeac495271d0f", tokenABI, "transfer")
+	require.NotNil(t, err)
+	_, err = multicall.Aggregate([]Call{{Target: "0x01"}})
+	require.NotNil(t, err)
+}
+
+func TestAggregateChunks(t *testing.T) {
+	caller := &testCaller{balances: map[string]int64{}}
+	calls := make([]Call, 10)
+	for i := range calls {
+		calls[i] = Call{Target: Address, Data: make([]byte, 36), AllowFailure: true}
+	}
+
+	results, err := New(caller).Aggregate(calls)
+	require.Nil(t, err)
+	require.Len(t, results, 10)
+	require.Equal(t, []int{10}, caller.chunks)
+
+	// Every call takes 5 words and 2 words of data
+	caller.chunks = nil
+	_, err = New(caller, WithMaxCalldataSize(68+3*224)).Aggregate(calls)
+	require.Nil(t, err)
+	require.Equal(t, []int{3, 3, 3, 1}, caller.chunks)
+
+	caller.chunks = nil
+	calls[0].Gas = 250000
+	_, err = New(caller, WithMaxGas(300000)).Aggregate(calls)
+	require.Nil(t, err)
+	require.Equal(t, []int{1, 3, 3, 3}, caller.chunks)
+
+	// Call exceeding limits is sent alone
+	caller.chunks = nil
+	calls[0].Gas = 500000
+	_, err = New(caller, WithMaxGas(300000), WithMaxCalldataSize(1)).Aggregate(calls[:3])
+	require.Nil(t, err)
+	require.Equal(t, []int{1, 1, 1}, caller.chunks)
+
+	caller.err = errors.New("error")
+	_, err = New(caller).Aggregate(calls)
+	require.Equal(t, caller.err, err)
+}
+
+func TestGetEthBalance(t *testing.T) {
+	caller := &testCaller{balances: map[string]int64{
+		"0x1111111111111111111111111111111111111111": 100,
+		"0x2222222222222222222222222222222222222222": 200,
+	}}
+	multicall := New(caller, WithAddress(Address))
+
+	balance, err := multicall.GetEthBalance("0x1111111111111111111111111111111111111111")
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(100), balance)
+
+	balances, err := multicall.GetEthBalances([]string{
+		"0x1111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222",
+		"0x3333333333333333333333333333333333333333",
+	})
+	require.Nil(t, err)
+	require.Len(t, balances, 3)
+	require.Equal(t, "100", balances[0].String())
+	require.Equal(t, "200", balances[1].String())
+	require.Equal(t, "0", balances[2].String())
+	require.Equal(t, []int{3}, caller.chunks)
+
+	_, err = multicall.GetEthBalances([]string{"0x01"})
+	require.NotNil(t, err)
+
+	caller.err = errors.New("error")
+	_, err = multicall.GetEthBalance("0x1111111111111111111111111111111111111111")
+	require.Equal(t, caller.err, err)
+}
+
+func TestBlockInfo(t *testing.T) {
+	caller := &testCaller{}
+	multicall := New(caller)
+
+	info, err := multicall.BlockInfo()
+	require.Nil(t, err)
+	require.Equal(t, &BlockInfo{
+		Number:     100,
+		ParentHash: "0x6300000000000000000000000000000000000000000000000000000000000000",
+		Timestamp:  1700000000,
+		Coinbase:   "0x4838b106fce9647bdf1e7877bf73ce8b0bad5f97",
+		GasLimit:   30000000,
+		ChainID:    1,
+	}, info)
+	require.Equal(t, []string{"latest"}, caller.tags)
+
+	hash, err := multicall.GetBlockHash(98)
+	require.Nil(t, err)
+	require.Equal(t, "0x6200000000000000000000000000000000000000000000000000000000000000", hash)
+
+	caller.err = errors.New("error")
+	_, err = multicall.BlockInfo()
+	require.Equal(t, caller.err, err)
+	_, err = multicall.GetBlockHash(98)
+	require.Equal(t, caller.err, err)
+}
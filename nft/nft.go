@@ -0,0 +1,245 @@
+// Package nft implements clients of ERC-721 and ERC-1155 tokens and decoding of their transfers.
+package nft
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/onrik/ethrpc"
+	"github.com/onrik/ethrpc/abi"
+)
+
+// ERC-165 interface identifiers
+var (
+	ERC165Interface             = [4]byte{0x01, 0xff, 0xc9, 0xa7}
+	ERC721Interface             = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	ERC721MetadataInterface     = [4]byte{0x5b, 0x5e, 0x13, 0x9f}
+	ERC721EnumerableInterface   = [4]byte{0x78, 0x0e, 0x9d, 0x63}
+	ERC1155Interface            = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+	ERC1155MetadataURIInterface = [4]byte{0x0e, 0x89, 0x34, 0x1c}
+)
+
+// ERC721ABI - ERC-721 token interface with metadata extension
+var ERC721ABI = abi.MustParse(`[
+	{"type": "function", "name": "supportsInterface", "stateMutability": "view", "inputs": [{"name": "interfaceId", "type": "bytes4"}], "outputs": [{"name": "", "type": "bool"}]},
+	{"type": "function", "name": "balanceOf", "stateMutability": "view", "inputs": [{"name": "owner", "type": "address"}], "outputs": [{"name": "", "type": "uint256"}]},
+	{"type": "function", "name": "ownerOf", "stateMutability": "view", "inputs": [{"name": "tokenId", "type": "uint256"}], "outputs": [{"name": "", "type": "address"}]},
+	{"type": "function", "name": "tokenURI", "stateMutability": "view", "inputs": [{"name": "tokenId", "type": "uint256"}], "outputs": [{"name": "", "type": "string"}]},
+	{"type": "event", "name": "Transfer", "anonymous": false, "inputs": [
+		{"name": "from", "type": "address", "indexed": true},
+		{"name": "to", "type": "address", "indexed": true},
+		{"name": "tokenId", "type": "uint256", "indexed": true}
+	]}
+]`)
+
+// ERC1155ABI - ERC-1155 token interface with metadata URI extension
+var ERC1155ABI = abi.MustParse(`[
+	{"type": "function", "name": "supportsInterface", "stateMutability": "view", "inputs": [{"name": "interfaceId", "type": "bytes4"}], "outputs": [{"name": "", "type": "bool"}]},
+	{"type": "function", "name": "balanceOf", "stateMutability": "view", "inputs": [{"name": "account", "type": "address"}, {"name": "id", "type": "uint256"}], "outputs": [{"name": "", "type": "uint256"}]},
+	{"type": "function", "name": "balanceOfBatch", "stateMutability": "view", "inputs": [{"name": "accounts", "type": "address[]"}, {"name": "ids", "type": "uint256[]"}], "outputs": [{"name": "", "type": "uint256[]"}]},
+	{"type": "function", "name": "uri", "stateMutability": "view", "inputs": [{"name": "id", "type": "uint256"}], "outputs": [{"name": "", "type": "string"}]},
+	{"type": "event", "name": "TransferSingle", "anonymous": false, "inputs": [
+		{"name": "operator", "type": "address", "indexed": true},
+		{"name": "from", "type": "address", "indexed": true},
+		{"name": "to", "type": "address", "indexed": true},
+		{"name": "id", "type": "uint256", "indexed": false},
+		{"name": "value", "type": "uint256", "indexed": false}
+	]},
+	{"type": "event", "name": "TransferBatch", "anonymous": false, "inputs": [
+		{"name": "operator", "type": "address", "indexed": true},
+		{"name": "from", "type": "address", "indexed": true},
+		{"name": "to", "type": "address", "indexed": true},
+		{"name": "ids", "type": "uint256[]", "indexed": false},
+		{"name": "values", "type": "uint256[]", "indexed": false}
+	]}
+]`)
+
+// ERC721 - client of ERC-721 token contract
+type ERC721 struct {
+	address string
+	caller  abi.Caller
+	block   string
+}
+
+// NewERC721 returns client of ERC-721 contract at address, caller is used for view methods, *ethrpc.EthRPC for example
+func NewERC721(address string, caller abi.Caller) *ERC721 {
+	return &ERC721{address: address, caller: caller, block: "latest"}
+}
+
+// Address returns address of token contract
+func (token *ERC721) Address() string {
+	return token.address
+}
+
+// WithBlock returns copy of client making calls at block number or tag, "latest" is used by default
+func (token *ERC721) WithBlock(block string) *ERC721 {
+	copied := *token
+	copied.block = block
+	return &copied
+}
+
+// SupportsInterface reports whether contract implements interface using ERC-165 detection, contracts without ERC-165 support return false
+func (token *ERC721) SupportsInterface(interfaceID [4]byte) (bool, error) {
+	return supportsInterface(token.caller, token.address, token.block, interfaceID)
+}
+
+// BalanceOf returns number of tokens owned by owner
+func (token *ERC721) BalanceOf(owner string) (*big.Int, error) {
+	values, err := ERC721ABI.Call(token.caller, token.address, token.block, "balanceOf", owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return values[0].(*big.Int), nil
+}
+
+// OwnerOf returns owner of token
+func (token *ERC721) OwnerOf(tokenID *big.Int) (string, error) {
+	values, err := ERC721ABI.Call(token.caller, token.address, token.block, "ownerOf", tokenID)
+	if err != nil {
+		return "", err
+	}
+
+	return values[0].(string), nil
+}
+
+// TokenURI returns metadata URI of token
+func (token *ERC721) TokenURI(tokenID *big.Int) (string, error) {
+	values, err := ERC721ABI.Call(token.caller, token.address, token.block, "tokenURI", tokenID)
+	if err != nil {
+		return "", err
+	}
+
+	return values[0].(string), nil
+}
+
+// ERC1155 - client of ERC-1155 multi token contract
+type ERC1155 struct {
+	address string
+	caller  abi.Caller
+	block   string
+}
+
+// NewERC1155 returns client of ERC-1155 contract at address, caller is used for view methods, *ethrpc.EthRPC for example
+func NewERC1155(address string, caller abi.Caller) *ERC1155 {
+	return &ERC1155{address: address, caller: caller, block: "latest"}
+}
+
+// Address returns address of token contract
+func (token *ERC1155) Address() string {
+	return token.address
+}
+
+// WithBlock returns copy of client making calls at block number or tag, "latest" is used by default
+func (token *ERC1155) WithBlock(block string) *ERC1155 {
+	copied := *token
+	copied.block = block
+	return &copied
+}
+
+// SupportsInterface reports whether contract implements interface using ERC-165 detection, contracts without ERC-165 support return false
+func (token *ERC1155) SupportsInterface(interfaceID [4]byte) (bool, error) {
+	return supportsInterface(token.caller, token.address, token.block, interfaceID)
+}
+
+// BalanceOf returns amount of token id owned by account
+func (token *ERC1155) BalanceOf(account string, id *big.Int) (*big.Int, error) {
+	values, err := ERC1155ABI.Call(token.caller, token.address, token.block, "balanceOf", account, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return values[0].(*big.Int), nil
+}
+
+// BalanceOfBatch returns amounts of token ids owned by accounts, accounts and ids must have the same length
+func (token *ERC1155) BalanceOfBatch(accounts []string, ids []*big.Int) ([]*big.Int, error) {
+	if len(accounts) != len(ids) {
+		return nil, fmt.Errorf("accounts number %d doesn't match ids number %d", len(accounts), len(ids))
+	}
+
+	values, err := ERC1155ABI.Call(token.caller, token.address, token.block, "balanceOfBatch", accounts, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := values[0].([]*big.Int)
+	if len(balances) != len(ids) {
+		return nil, fmt.Errorf("invalid balances number %d of %d ids", len(balances), len(ids))
+	}
+
+	return balances, nil
+}
+
+// URI returns metadata URI of token id as returned by contract, it can contain {id} placeholder, see FormatURI
+func (token *ERC1155) URI(id *big.Int) (string, error) {
+	values, err := ERC1155ABI.Call(token.caller, token.address, token.block, "uri", id)
+	if err != nil {
+		return "", err
+	}
+
+	return values[0].(string), nil
+}
+
+// FormatURI replaces {id} placeholder of ERC-1155 metadata URI with hex token id padded to 64 characters
+func FormatURI(uri string, id *big.Int) string {
+	return strings.ReplaceAll(uri, "{id}", fmt.Sprintf("%064x", id))
+}
+
+// invalidInterface - interface id which must not be supported by ERC-165 contracts
+var invalidInterface = [4]byte{0xff, 0xff, 0xff, 0xff}
+
+// supportsInterface follows ERC-165 detection, contract must support ERC165Interface and must not support 0xffffffff,
+// so contracts returning true for any interface are not detected as implementing it
+func supportsInterface(caller abi.Caller, address, block string, interfaceID [4]byte) (bool, error) {
+	supported, err := callSupportsInterface(caller, address, block, ERC165Interface)
+	if err != nil || !supported {
+		return false, err
+	}
+	supported, err = callSupportsInterface(caller, address, block, invalidInterface)
+	if err != nil || supported {
+		return false, err
+	}
+	if interfaceID == ERC165Interface {
+		return true, nil
+	}
+
+	return callSupportsInterface(caller, address, block, interfaceID)
+}
+
+// supportsInterfaceGas - gas limit of supportsInterface call required by ERC-165
+const supportsInterfaceGas = 30000
+
+func callSupportsInterface(caller abi.Caller, address, block string, interfaceID [4]byte) (bool, error) {
+	transaction, err := ERC721ABI.Transaction(address, "supportsInterface", interfaceID)
+	if err != nil {
+		return false, err
+	}
+	transaction.Gas = supportsInterfaceGas
+
+	result, err := caller.EthCall(transaction, block)
+	// Contracts without supportsInterface revert or return empty data
+	if errors.Is(err, ethrpc.ErrExecutionReverted) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	data, err := abi.DecodeHex(result)
+	if err != nil {
+		return false, err
+	}
+
+	values, err := ERC721ABI.DecodeOutput("supportsInterface", data)
+	if errors.Is(err, abi.ErrShortData) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return values[0].(bool), nil
+}
@@ -0,0 +1,205 @@
+package nft
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/onrik/ethrpc"
+	"github.com/onrik/ethrpc/abi"
+	"github.com/onrik/ethrpc/internal/rpctest"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	contractAddress = "0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d"
+	owner           = "0x1111111111111111111111111111111111111111"
+	recipient       = "0x2222222222222222222222222222222222222222"
+	operator        = "0x3333333333333333333333333333333333333333"
+)
+
+// testContract - emulates token contract, interfaces are returned by supportsInterface, other methods return results by name
+type testContract struct {
+	contract     *abi.ABI
+	args         []interface{}
+	interfaces   map[[4]byte]bool
+	supportsAll  bool
+	results      map[string]string
+	interfaceIDs [][4]byte
+}
+
+// call is used as handler of rpctest.Caller
+func (c *testContract) call(transaction ethrpc.T) (string, error) {
+	data, err := abi.DecodeHex(transaction.Data)
+	if err != nil {
+		return "", err
+	}
+	method, args, err := c.contract.DecodeInput(data)
+	if err != nil {
+		return "", err
+	}
+	c.args = args
+
+	if method.Name == "supportsInterface" {
+		interfaceID := args[0].([4]byte)
+		c.interfaceIDs = append(c.interfaceIDs, interfaceID)
+		if c.interfaces == nil && !c.supportsAll {
+			// Contract without ERC-165 support
+			return "0x", nil
+		}
+		output, err := method.Outputs.Encode(c.supportsAll || c.interfaces[interfaceID])
+		return "0x" + hex.EncodeToString(output), err
+	}
+
+	return c.results[method.Name], nil
+}
+
+func encode(t *testing.T, contract *abi.ABI, method string, values ...interface{}) string {
+	data, err := contract.Methods[method].Outputs.Encode(values...)
+	require.Nil(t, err)
+
+	return "0x" + hex.EncodeToString(data)
+}
+
+func TestERC721(t *testing.T) {
+	contract := &testContract{
+		contract:   ERC721ABI,
+		interfaces: map[[4]byte]bool{ERC165Interface: true, ERC721Interface: true, ERC721MetadataInterface: true},
+		results: map[string]string{
+			"balanceOf": encode(t, ERC721ABI, "balanceOf", big.NewInt(3)),
+			"ownerOf":   encode(t, ERC721ABI, "ownerOf", owner),
+			"tokenURI":  encode(t, ERC721ABI, "tokenURI", "ipfs://QmeSjSinHpPnmXmspMjwiXyN6zS4E9zccariGR3jxcaWtq/7"),
+		},
+	}
+	caller := &rpctest.Caller{Handler: contract.call}
+	token := NewERC721(contractAddress, caller)
+	require.Equal(t, contractAddress, token.Address())
+
+	supported, err := token.SupportsInterface(ERC721Interface)
+	require.Nil(t, err)
+	require.True(t, supported)
+	supported, err = token.SupportsInterface(ERC1155Interface)
+	require.Nil(t, err)
+	require.False(t, supported)
+
+	balance, err := token.BalanceOf(owner)
+	require.Nil(t, err)
+	require.Equal(t, "3", balance.String())
+	require.Equal(t, []interface{}{owner}, contract.args)
+	require.Equal(t, "latest", caller.Block)
+
+	_, err = token.WithBlock("0x10").SupportsInterface(ERC721Interface)
+	require.Nil(t, err)
+	require.Equal(t, "0x10", caller.Block)
+
+	tokenOwner, err := token.OwnerOf(big.NewInt(7))
+	require.Nil(t, err)
+	require.Equal(t, owner, tokenOwner)
+	require.Equal(t, []interface{}{big.NewInt(7)}, contract.args)
+
+	uri, err := token.TokenURI(big.NewInt(7))
+	require.Nil(t, err)
+	require.Equal(t, "ipfs://QmeSjSinHpPnmXmspMjwiXyN6zS4E9zccariGR3jxcaWtq/7", uri)
+
+	// Nonexistent token
+	caller.Err = &abi.RevertError{EthError: ethrpc.EthError{Code: 3, Message: "execution reverted"}}
+	_, err = token.OwnerOf(big.NewInt(8))
+	require.True(t, errors.Is(err, ethrpc.ErrExecutionReverted))
+}
+
+func TestERC1155(t *testing.T) {
+	contract := &testContract{
+		contract:   ERC1155ABI,
+		interfaces: map[[4]byte]bool{ERC165Interface: true, ERC1155Interface: true},
+		results: map[string]string{
+			"balanceOf":      encode(t, ERC1155ABI, "balanceOf", big.NewInt(10)),
+			"balanceOfBatch": encode(t, ERC1155ABI, "balanceOfBatch", []*big.Int{big.NewInt(10), big.NewInt(0)}),
+			"uri":            encode(t, ERC1155ABI, "uri", "https://token-cdn-domain/{id}.json"),
+		},
+	}
+	caller := &rpctest.Caller{Handler: contract.call}
+	token := NewERC1155(contractAddress, caller)
+	require.Equal(t, contractAddress, token.Address())
+
+	supported, err := token.SupportsInterface(ERC1155Interface)
+	require.Nil(t, err)
+	require.True(t, supported)
+
+	balance, err := token.BalanceOf(owner, big.NewInt(314592))
+	require.Nil(t, err)
+	require.Equal(t, "10", balance.String())
+	require.Equal(t, []interface{}{owner, big.NewInt(314592)}, contract.args)
+
+	balances, err := token.BalanceOfBatch([]string{owner, recipient}, []*big.Int{big.NewInt(1), big.NewInt(2)})
+	require.Nil(t, err)
+	require.Len(t, balances, 2)
+	require.Equal(t, "10", balances[0].String())
+	require.Equal(t, "0", balances[1].String())
+	require.Equal(t, []interface{}{[]string{owner, recipient}, []*big.Int{big.NewInt(1), big.NewInt(2)}}, contract.args)
+
+	_, err = token.BalanceOfBatch([]string{owner}, []*big.Int{big.NewInt(1), big.NewInt(2)})
+	require.NotNil(t, err)
+	_, err = token.BalanceOfBatch([]string{owner, recipient, operator}, []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)})
+	require.NotNil(t, err)
+
+	uri, err := token.URI(big.NewInt(314592))
+	require.Nil(t, err)
+	require.Equal(t, "https://token-cdn-domain/{id}.json", uri)
+	require.Equal(t, "https://token-cdn-domain/000000000000000000000000000000000000000000000000000000000004cce0.json", FormatURI(uri, big.NewInt(314592)))
+
+	caller.Err = errors.New("error")
+	_, err = token.URI(big.NewInt(314592))
+	require.Equal(t, caller.Err, err)
+}
+
+func TestSupportsInterface(t *testing.T) {
+	// Contract without ERC-165 support returns empty data
+	contract := &testContract{contract: ERC721ABI}
+	caller := &rpctest.Caller{Handler: contract.call}
+	supported, err := NewERC721(contractAddress, caller).SupportsInterface(ERC165Interface)
+	require.Nil(t, err)
+	require.False(t, supported)
+
+	caller.Err = &abi.RevertError{EthError: ethrpc.EthError{Code: 3, Message: "execution reverted"}}
+	supported, err = NewERC1155(contractAddress, caller).SupportsInterface(ERC165Interface)
+	require.Nil(t, err)
+	require.False(t, supported)
+
+	caller.Err = errors.New("error")
+	_, err = NewERC721(contractAddress, caller).SupportsInterface(ERC165Interface)
+	require.Equal(t, caller.Err, err)
+
+	// ERC-165 detection checks ERC-165 support and 0xffffffff before the interface
+	contract = &testContract{contract: ERC721ABI, interfaces: map[[4]byte]bool{ERC165Interface: true, ERC721Interface: true}}
+	caller = &rpctest.Caller{Handler: contract.call}
+	supported, err = NewERC721(contractAddress, caller).SupportsInterface(ERC721Interface)
+	require.Nil(t, err)
+	require.True(t, supported)
+	require.Equal(t, [][4]byte{ERC165Interface, {0xff, 0xff, 0xff, 0xff}, ERC721Interface}, contract.interfaceIDs)
+
+	contract.interfaceIDs = nil
+	supported, err = NewERC721(contractAddress, caller).SupportsInterface(ERC165Interface)
+	require.Nil(t, err)
+	require.True(t, supported)
+	require.Equal(t, [][4]byte{ERC165Interface, {0xff, 0xff, 0xff, 0xff}}, contract.interfaceIDs)
+	// ERC-165 requires 30000 gas limit of supportsInterface call
+	require.Equal(t, 30000, caller.Transaction.Gas)
+
+	// Contract without ERC-165 support but with the interface is not queried for it
+	contract = &testContract{contract: ERC721ABI, interfaces: map[[4]byte]bool{ERC721Interface: true}}
+	caller = &rpctest.Caller{Handler: contract.call}
+	supported, err = NewERC721(contractAddress, caller).SupportsInterface(ERC721Interface)
+	require.Nil(t, err)
+	require.False(t, supported)
+	require.Equal(t, [][4]byte{ERC165Interface}, contract.interfaceIDs)
+
+	// Contract returning true for every interface
+	contract = &testContract{contract: ERC721ABI, supportsAll: true}
+	caller = &rpctest.Caller{Handler: contract.call}
+	for _, interfaceID := range [][4]byte{ERC165Interface, ERC721Interface, ERC1155Interface} {
+		supported, err = NewERC1155(contractAddress, caller).SupportsInterface(interfaceID)
+		require.Nil(t, err)
+		require.False(t, supported)
+	}
+}
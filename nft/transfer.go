@@ -0,0 +1,153 @@
+package nft
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/onrik/ethrpc"
+	"github.com/onrik/ethrpc/abi"
+)
+
+// ZeroAddress - sender of minted tokens and recipient of burned tokens
+const ZeroAddress = "0x0000000000000000000000000000000000000000"
+
+// Standard - token standard of transfer
+type Standard string
+
+// Token standards
+const (
+	ERC721Standard  Standard = "ERC-721"
+	ERC1155Standard Standard = "ERC-1155"
+)
+
+// Transfer - ownership change of ERC-721 token or amount of ERC-1155 token id,
+// TransferBatch events are decoded to one transfer per token id
+type Transfer struct {
+	Standard Standard
+	Contract string
+	// Operator is empty for ERC-721 transfers
+	Operator string
+	From     string
+	To       string
+	TokenID  *big.Int
+	// Amount is 1 for ERC-721 transfers
+	Amount *big.Int
+	Log    ethrpc.Log
+}
+
+// IsMint reports whether token is minted by the transfer
+func (transfer Transfer) IsMint() bool {
+	return strings.EqualFold(transfer.From, ZeroAddress)
+}
+
+// IsBurn reports whether token is burned by the transfer
+func (transfer Transfer) IsBurn() bool {
+	return strings.EqualFold(transfer.To, ZeroAddress)
+}
+
+// DecodeTransfers decodes ERC-721 Transfer, ERC-1155 TransferSingle and TransferBatch events from log.
+// ERC-20 transfers without indexed token id and logs of other events return abi.ErrEventMismatch.
+func DecodeTransfers(log ethrpc.Log) ([]Transfer, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("%w: log has no topics", abi.ErrEventMismatch)
+	}
+
+	switch topic := log.Topics[0]; {
+	case isTopic(topic, ERC721ABI.Events["Transfer"]):
+		decoded, err := ERC721ABI.Events["Transfer"].DecodeLog(log)
+		if err != nil {
+			return nil, err
+		}
+		return []Transfer{{
+			Standard: ERC721Standard,
+			Contract: log.Address,
+			From:     decoded.Args[0].(string),
+			To:       decoded.Args[1].(string),
+			TokenID:  decoded.Args[2].(*big.Int),
+			Amount:   big.NewInt(1),
+			Log:      log,
+		}}, nil
+	case isTopic(topic, ERC1155ABI.Events["TransferSingle"]):
+		decoded, err := ERC1155ABI.Events["TransferSingle"].DecodeLog(log)
+		if err != nil {
+			return nil, err
+		}
+		return []Transfer{newERC1155Transfer(decoded, decoded.Args[3].(*big.Int), decoded.Args[4].(*big.Int))}, nil
+	case isTopic(topic, ERC1155ABI.Events["TransferBatch"]):
+		decoded, err := ERC1155ABI.Events["TransferBatch"].DecodeLog(log)
+		if err != nil {
+			return nil, err
+		}
+		ids := decoded.Args[3].([]*big.Int)
+		values := decoded.Args[4].([]*big.Int)
+		if len(ids) != len(values) {
+			return nil, fmt.Errorf("TransferBatch ids number %d doesn't match values number %d", len(ids), len(values))
+		}
+		transfers := make([]Transfer, len(ids))
+		for i := range ids {
+			transfers[i] = newERC1155Transfer(decoded, ids[i], values[i])
+		}
+		return transfers, nil
+	}
+
+	return nil, fmt.Errorf("%w: topic %s isn't a token transfer", abi.ErrEventMismatch, log.Topics[0])
+}
+
+// FilterTransfers returns filter of ERC-721 and ERC-1155 transfers of contracts, empty contracts match any contract.
+// Filter also matches ERC-20 transfers which are skipped by GetTransfers.
+// FromBlock and ToBlock of the filter should be set before use.
+func FilterTransfers(contracts ...string) ethrpc.FilterParams {
+	return ethrpc.FilterParams{
+		Address: contracts,
+		Topics: [][]string{{
+			topicHex(ERC721ABI.Events["Transfer"]),
+			topicHex(ERC1155ABI.Events["TransferSingle"]),
+			topicHex(ERC1155ABI.Events["TransferBatch"]),
+		}},
+	}
+}
+
+// GetTransfers returns ERC-721 and ERC-1155 transfers matching the filter in order of logs, logs of other events are skipped
+func GetTransfers(getter abi.LogsGetter, params ethrpc.FilterParams) ([]Transfer, error) {
+	logs, err := getter.EthGetLogs(params)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := []Transfer{}
+	for _, log := range logs {
+		decoded, err := DecodeTransfers(log)
+		if errors.Is(err, abi.ErrEventMismatch) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, decoded...)
+	}
+
+	return transfers, nil
+}
+
+func newERC1155Transfer(decoded *abi.EventLog, id, amount *big.Int) Transfer {
+	return Transfer{
+		Standard: ERC1155Standard,
+		Contract: decoded.Log.Address,
+		Operator: decoded.Args[0].(string),
+		From:     decoded.Args[1].(string),
+		To:       decoded.Args[2].(string),
+		TokenID:  id,
+		Amount:   amount,
+		Log:      decoded.Log,
+	}
+}
+
+func isTopic(topic string, event abi.Event) bool {
+	return strings.EqualFold(topic, topicHex(event))
+}
+
+func topicHex(event abi.Event) string {
+	return fmt.Sprintf("0x%x", event.ID())
+}
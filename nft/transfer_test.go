@@ -0,0 +1,131 @@
+package nft
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/onrik/ethrpc"
+	"github.com/onrik/ethrpc/abi"
+	"github.com/onrik/ethrpc/internal/rpctest"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	transferTopic       = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	transferSingleTopic = "0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62"
+	transferBatchTopic  = "0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb"
+	zeroTopic           = "0x0000000000000000000000000000000000000000000000000000000000000000"
+	ownerTopic          = "0x000000000000000000000000" + "1111111111111111111111111111111111111111"
+	recipientTopic      = "0x000000000000000000000000" + "2222222222222222222222222222222222222222"
+	operatorTopic       = "0x000000000000000000000000" + "3333333333333333333333333333333333333333"
+	tokenIDTopic        = "0x0000000000000000000000000000000000000000000000000000000000000007"
+)
+
+func encodeData(t *testing.T, event abi.Event, values ...interface{}) string {
+	data, err := event.Inputs.NonIndexed().Encode(values...)
+	require.Nil(t, err)
+
+	return "0x" + hex.EncodeToString(data)
+}
+
+func TestDecodeTransfers(t *testing.T) {
+	log := ethrpc.Log{Address: contractAddress, Topics: []string{transferTopic, zeroTopic, recipientTopic, tokenIDTopic}, Data: "0x"}
+	transfers, err := DecodeTransfers(log)
+	require.Nil(t, err)
+	require.Equal(t, []Transfer{{
+		Standard: ERC721Standard,
+		Contract: contractAddress,
+		From:     ZeroAddress,
+		To:       recipient,
+		TokenID:  big.NewInt(7),
+		Amount:   big.NewInt(1),
+		Log:      log,
+	}}, transfers)
+	require.True(t, transfers[0].IsMint())
+	require.False(t, transfers[0].IsBurn())
+
+	log = ethrpc.Log{
+		Address: contractAddress,
+		Topics:  []string{transferSingleTopic, operatorTopic, ownerTopic, zeroTopic},
+		Data:    encodeData(t, ERC1155ABI.Events["TransferSingle"], big.NewInt(5), big.NewInt(100)),
+	}
+	transfers, err = DecodeTransfers(log)
+	require.Nil(t, err)
+	require.Equal(t, []Transfer{{
+		Standard: ERC1155Standard,
+		Contract: contractAddress,
+		Operator: operator,
+		From:     owner,
+		To:       ZeroAddress,
+		TokenID:  big.NewInt(5),
+		Amount:   big.NewInt(100),
+		Log:      log,
+	}}, transfers)
+	require.False(t, transfers[0].IsMint())
+	require.True(t, transfers[0].IsBurn())
+
+	log = ethrpc.Log{
+		Address: contractAddress,
+		Topics:  []string{transferBatchTopic, operatorTopic, ownerTopic, recipientTopic},
+		Data:    encodeData(t, ERC1155ABI.Events["TransferBatch"], []*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10), big.NewInt(20)}),
+	}
+	transfers, err = DecodeTransfers(log)
+	require.Nil(t, err)
+	require.Len(t, transfers, 2)
+	for i, transfer := range transfers {
+		require.Equal(t, ERC1155Standard, transfer.Standard)
+		require.Equal(t, operator, transfer.Operator)
+		require.Equal(t, owner, transfer.From)
+		require.Equal(t, recipient, transfer.To)
+		require.Equal(t, big.NewInt(int64(i+1)), transfer.TokenID)
+		require.Equal(t, big.NewInt(int64(10*(i+1))), transfer.Amount)
+	}
+
+	log.Data = encodeData(t, ERC1155ABI.Events["TransferBatch"], []*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10)})
+	_, err = DecodeTransfers(log)
+	require.NotNil(t, err)
+	require.False(t, errors.Is(err, abi.ErrEventMismatch))
+
+	// ERC-20 transfer with value in data
+	_, err = DecodeTransfers(ethrpc.Log{Topics: []string{transferTopic, ownerTopic, recipientTopic}, Data: tokenIDTopic})
+	require.True(t, errors.Is(err, abi.ErrEventMismatch))
+	_, err = DecodeTransfers(ethrpc.Log{Topics: []string{zeroTopic}})
+	require.True(t, errors.Is(err, abi.ErrEventMismatch))
+	_, err = DecodeTransfers(ethrpc.Log{})
+	require.True(t, errors.Is(err, abi.ErrEventMismatch))
+}
+
+func TestGetTransfers(t *testing.T) {
+	params := FilterTransfers(contractAddress)
+	require.Equal(t, ethrpc.FilterParams{
+		Address: []string{contractAddress},
+		Topics:  [][]string{{transferTopic, transferSingleTopic, transferBatchTopic}},
+	}, params)
+
+	getter := &rpctest.LogsGetter{Logs: []ethrpc.Log{
+		{Topics: []string{transferTopic, ownerTopic, recipientTopic}, Data: tokenIDTopic},
+		{Topics: []string{transferTopic, ownerTopic, recipientTopic, tokenIDTopic}, Data: "0x"},
+		{
+			Topics: []string{transferBatchTopic, operatorTopic, ownerTopic, recipientTopic},
+			Data:   encodeData(t, ERC1155ABI.Events["TransferBatch"], []*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10), big.NewInt(20)}),
+		},
+	}}
+	transfers, err := GetTransfers(getter, params)
+	require.Nil(t, err)
+	require.Equal(t, params, getter.Params)
+	require.Len(t, transfers, 3)
+	require.Equal(t, ERC721Standard, transfers[0].Standard)
+	require.Equal(t, "7", transfers[0].TokenID.String())
+	require.Equal(t, ERC1155Standard, transfers[2].Standard)
+	require.Equal(t, "2", transfers[2].TokenID.String())
+
+	getter.Logs = []ethrpc.Log{{Topics: []string{transferSingleTopic, operatorTopic, ownerTopic, recipientTopic}, Data: "0x01"}}
+	_, err = GetTransfers(getter, params)
+	require.NotNil(t, err)
+
+	getter.Err = errors.New("error")
+	_, err = GetTransfers(getter, params)
+	require.Equal(t, getter.Err, err)
+}
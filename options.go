@@ -33,3 +33,25 @@ func WithDebug(enabled bool) func(rpc *EthRPC) {
 		rpc.Debug = enabled
 	}
 }
+
+// WithJWTSecret set secret for engine API jwt authentication
+func WithJWTSecret(secret []byte) func(rpc *EthRPC) {
+	return func(rpc *EthRPC) {
+		rpc.jwtSecret = secret
+	}
+}
+
+// WithMaxResponseSize set limit of response body size, DefaultMaxResponseSize is used by default, zero disables the limit
+func WithMaxResponseSize(size int64) func(rpc *EthRPC) {
+	return func(rpc *EthRPC) {
+		rpc.maxResponseSize = size
+	}
+}
+
+// WithStrictValidation set strict validation of responses: jsonrpc version 2.0, matching id and either result or error.
+// Lenient validation is used by default, it tolerates missing id and version.
+func WithStrictValidation(enabled bool) func(rpc *EthRPC) {
+	return func(rpc *EthRPC) {
+		rpc.strict = enabled
+	}
+}
@@ -0,0 +1,57 @@
+package ethrpc
+
+// PersonalNewAccount generates a new private key, stores it in the key store directory and returns its address.
+func (rpc *EthRPC) PersonalNewAccount(password string) (string, error) {
+	var address string
+
+	err := rpc.call("personal_newAccount", &address, password)
+	return address, err
+}
+
+// PersonalUnlockAccount decrypts the key with the given address from the key store for duration seconds.
+func (rpc *EthRPC) PersonalUnlockAccount(address, password string, duration int) (bool, error) {
+	var unlocked bool
+
+	err := rpc.call("personal_unlockAccount", &unlocked, address, password, duration)
+	return unlocked, err
+}
+
+// PersonalLockAccount removes the private key with given address from memory.
+func (rpc *EthRPC) PersonalLockAccount(address string) (bool, error) {
+	var locked bool
+
+	err := rpc.call("personal_lockAccount", &locked, address)
+	return locked, err
+}
+
+// PersonalListAccounts returns all the addresses of all keys in the key store.
+func (rpc *EthRPC) PersonalListAccounts() ([]string, error) {
+	accounts := []string{}
+
+	err := rpc.call("personal_listAccounts", &accounts)
+	return accounts, err
+}
+
+// PersonalSign calculates an Ethereum specific signature with: sign(keccak256("\x19Ethereum Signed Message:\n" + len(message) + message))).
+func (rpc *EthRPC) PersonalSign(data, address, password string) (string, error) {
+	var signature string
+
+	err := rpc.call("personal_sign", &signature, data, address, password)
+	return signature, err
+}
+
+// PersonalEcRecover returns the address associated with the private key that was used to calculate the signature in PersonalSign.
+func (rpc *EthRPC) PersonalEcRecover(data, signature string) (string, error) {
+	var address string
+
+	err := rpc.call("personal_ecRecover", &address, data, signature)
+	return address, err
+}
+
+// PersonalSendTransaction unlocks the From account with password, signs and sends the transaction.
+func (rpc *EthRPC) PersonalSendTransaction(transaction T, password string) (string, error) {
+	var hash string
+
+	err := rpc.call("personal_sendTransaction", &hash, transaction, password)
+	return hash, err
+}
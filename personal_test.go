@@ -0,0 +1,94 @@
+package ethrpc
+
+import (
+	"errors"
+	"math/big"
+)
+
+func (s *EthRPCTestSuite) TestPersonalNewAccount() {
+	s.registerResponseError(errors.New("error"))
+	_, err := s.rpc.PersonalNewAccount("secret")
+	s.Require().NotNil(err)
+
+	s.registerResponse(`"0x5e97870f263700f46aa00d967821199b9bc5a120"`, func(body []byte) {
+		s.methodEqual(body, "personal_newAccount")
+		s.paramsEqual(body, `["secret"]`)
+	})
+
+	address, err := s.rpc.PersonalNewAccount("secret")
+	s.Require().Nil(err)
+	s.Require().Equal("0x5e97870f263700f46aa00d967821199b9bc5a120", address)
+}
+
+func (s *EthRPCTestSuite) TestPersonalUnlockAccount() {
+	s.registerResponse(`true`, func(body []byte) {
+		s.methodEqual(body, "personal_unlockAccount")
+		s.paramsEqual(body, `["0x5e97870f263700f46aa00d967821199b9bc5a120", "secret", 300]`)
+	})
+
+	unlocked, err := s.rpc.PersonalUnlockAccount("0x5e97870f263700f46aa00d967821199b9bc5a120", "secret", 300)
+	s.Require().Nil(err)
+	s.Require().True(unlocked)
+}
+
+func (s *EthRPCTestSuite) TestPersonalLockAccount() {
+	s.registerResponse(`true`, func(body []byte) {
+		s.methodEqual(body, "personal_lockAccount")
+		s.paramsEqual(body, `["0x5e97870f263700f46aa00d967821199b9bc5a120"]`)
+	})
+
+	locked, err := s.rpc.PersonalLockAccount("0x5e97870f263700f46aa00d967821199b9bc5a120")
+	s.Require().Nil(err)
+	s.Require().True(locked)
+}
+
+func (s *EthRPCTestSuite) TestPersonalListAccounts() {
+	s.registerResponse(`["0x5e97870f263700f46aa00d967821199b9bc5a120", "0x3d80b31a78c30fc628f20b2c89d7ddbf6e53cedc"]`, func(body []byte) {
+		s.methodEqual(body, "personal_listAccounts")
+		s.paramsEqual(body, "null")
+	})
+
+	accounts, err := s.rpc.PersonalListAccounts()
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"0x5e97870f263700f46aa00d967821199b9bc5a120", "0x3d80b31a78c30fc628f20b2c89d7ddbf6e53cedc"}, accounts)
+}
+
+func (s *EthRPCTestSuite) TestPersonalSign() {
+	signature := "0xa3f20717a250c2b0b729b7e5becbff67fdaef7e0699da4de7ca5895b02a170a12d887fd3b17bfdce3481f10bea41f45ba9f709d39ce8325427b57afcfc994cee1b"
+	s.registerResponse(`"`+signature+`"`, func(body []byte) {
+		s.methodEqual(body, "personal_sign")
+		s.paramsEqual(body, `["0xdeadbeaf", "0x9b2055d370f73ec7d8a03e965129118dc8f5bf83", "secret"]`)
+	})
+
+	result, err := s.rpc.PersonalSign("0xdeadbeaf", "0x9b2055d370f73ec7d8a03e965129118dc8f5bf83", "secret")
+	s.Require().Nil(err)
+	s.Require().Equal(signature, result)
+}
+
+func (s *EthRPCTestSuite) TestPersonalEcRecover() {
+	signature := "0xa3f20717a250c2b0b729b7e5becbff67fdaef7e0699da4de7ca5895b02a170a12d887fd3b17bfdce3481f10bea41f45ba9f709d39ce8325427b57afcfc994cee1b"
+	s.registerResponse(`"0x9b2055d370f73ec7d8a03e965129118dc8f5bf83"`, func(body []byte) {
+		s.methodEqual(body, "personal_ecRecover")
+		s.paramsEqual(body, `["0xdeadbeaf", "`+signature+`"]`)
+	})
+
+	address, err := s.rpc.PersonalEcRecover("0xdeadbeaf", signature)
+	s.Require().Nil(err)
+	s.Require().Equal("0x9b2055d370f73ec7d8a03e965129118dc8f5bf83", address)
+}
+
+func (s *EthRPCTestSuite) TestPersonalSendTransaction() {
+	hash := "0x9c17afa5336d3cfd47e2e795520959b92e627e123e538fd4d5d7ece9025a8dce"
+	s.registerResponse(`"`+hash+`"`, func(body []byte) {
+		s.methodEqual(body, "personal_sendTransaction")
+		s.paramsEqual(body, `[{"from": "0x9b2055d370f73ec7d8a03e965129118dc8f5bf83", "to": "0x5e97870f263700f46aa00d967821199b9bc5a120", "value": "0x10"}, "secret"]`)
+	})
+
+	result, err := s.rpc.PersonalSendTransaction(T{
+		From:  "0x9b2055d370f73ec7d8a03e965129118dc8f5bf83",
+		To:    "0x5e97870f263700f46aa00d967821199b9bc5a120",
+		Value: big.NewInt(16),
+	}, "secret")
+	s.Require().Nil(err)
+	s.Require().Equal(hash, result)
+}
@@ -0,0 +1,51 @@
+package ethrpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+// revertCode - error code of reverted execution returned by geth like nodes
+const revertCode = 3
+
+var (
+	errorSelector = "\x08\xc3\x79\xa0" // Error(string)
+	panicSelector = "\x4e\x48\x7b\x71" // Panic(uint256)
+)
+
+// RevertData returns revert data from error data, geth like nodes return hex string and others wrap it to object or message.
+// Data is treated as revert data if the node returned code 3 of reverted execution
+// or data starts with selector of Error(string) or Panic(uint256), use abi.AsRevertError to decode it.
+func (err EthError) RevertData() ([]byte, bool) {
+	if err.Data == "" {
+		return nil, false
+	}
+
+	var value string
+	if json.Unmarshal([]byte(err.Data), &value) != nil {
+		object := struct {
+			Data string `json:"data"`
+		}{}
+		if json.Unmarshal([]byte(err.Data), &object) != nil {
+			return nil, false
+		}
+		value = object.Data
+	}
+
+	// Nethermind returns "Reverted 0x..."
+	i := strings.Index(value, "0x")
+	if i < 0 {
+		return nil, false
+	}
+	data, e := hex.DecodeString(value[i+2:])
+	if e != nil {
+		return nil, false
+	}
+
+	if err.Code != revertCode && (len(data) < 4 || (string(data[:4]) != errorSelector && string(data[:4]) != panicSelector)) {
+		return nil, false
+	}
+
+	return data, true
+}
@@ -0,0 +1,68 @@
+package ethrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	revertReasonData = "0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"000000000000000000000000000000000000000000000000000000000000001a" +
+		"4e6f7420656e6f7567682045746865722070726f76696465642e000000000000"
+	panicData       = "0x4e487b710000000000000000000000000000000000000000000000000000000000000011"
+	customErrorData = "0xcf4791810000000000000000000000000000000000000000000000000000000000000001"
+)
+
+func TestEthErrorRevertData(t *testing.T) {
+	for _, test := range []struct {
+		code     int
+		data     string
+		expected []byte
+		ok       bool
+	}{
+		{3, ``, nil, false},
+		{3, `"0x"`, []byte{}, true},
+		{3, `"0x0102"`, []byte{1, 2}, true},
+		{3, `"Reverted 0x0102"`, []byte{1, 2}, true},
+		{3, `{"message": "reverted", "data": "0x0102"}`, []byte{1, 2}, true},
+		{3, `"0xzz"`, nil, false},
+		{3, `"reverted"`, nil, false},
+		{3, `1`, nil, false},
+		// Other codes are reverts only with data of standard errors
+		{-32000, `"` + revertReasonData + `"`, hexToBytesMust(revertReasonData), true},
+		{-32015, `"Reverted ` + panicData + `"`, hexToBytesMust(panicData), true},
+		{-32000, `"` + customErrorData + `"`, nil, false},
+		{-32000, `"0x0102"`, nil, false},
+		{-32000, `{"hash": "0x0102"}`, nil, false},
+	} {
+		data, ok := EthError{Code: test.code, Data: test.data}.RevertData()
+		require.Equal(t, test.ok, ok, test.data)
+		require.Equal(t, test.expected, data, test.data)
+	}
+}
+
+func TestEthErrorJSON(t *testing.T) {
+	ethErr := EthError{}
+	require.Nil(t, json.Unmarshal([]byte(`{"code": 3, "message": "execution reverted", "data": {"data": "0x0102"}}`), &ethErr))
+	require.Equal(t, EthError{Code: 3, Message: "execution reverted", Data: `{"data": "0x0102"}`}, ethErr)
+
+	data, err := json.Marshal(ethErr)
+	require.Nil(t, err)
+	require.Equal(t, `{"code":3,"message":"execution reverted","data":{"data":"0x0102"}}`, string(data))
+
+	ethErr = EthError{}
+	require.Nil(t, json.Unmarshal([]byte(`{"code": -32000, "message": "error", "data": null}`), &ethErr))
+	require.Equal(t, EthError{Code: -32000, Message: "error"}, ethErr)
+
+	data, err = json.Marshal(ethErr)
+	require.Nil(t, err)
+	require.Equal(t, `{"code":-32000,"message":"error"}`, string(data))
+
+	// Errors are comparable
+	require.True(t, error(ethErr) == error(EthError{Code: -32000, Message: "error"}))
+
+	require.NotNil(t, json.Unmarshal([]byte(`{"code": "3"}`), &ethErr))
+}
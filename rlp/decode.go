@@ -0,0 +1,602 @@
+package rlp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// Decode reads RLP value from r and stores the result in value which must be a non-nil pointer.
+// Input size is not limited unless r is *bytes.Reader, *bytes.Buffer or *strings.Reader,
+// use NewStream with a limit to decode untrusted input of unknown size.
+func Decode(r io.Reader, value interface{}) error {
+	return NewStream(r, 0).Decode(value)
+}
+
+// DecodeBytes decodes RLP data into value which must be a non-nil pointer.
+// Input must contain exactly one value.
+func DecodeBytes(data []byte, value interface{}) error {
+	s := NewStream(bytes.NewReader(data), uint64(len(data)))
+	if err := s.Decode(value); err != nil {
+		return err
+	}
+	if s.remaining > 0 {
+		return ErrMoreThanOneValue
+	}
+
+	return nil
+}
+
+// decodeError - decode error with the type being decoded
+type decodeError struct {
+	err error
+	typ reflect.Type
+}
+
+func (e *decodeError) Error() string {
+	return fmt.Sprintf("%v for %v", e.err, e.typ)
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+func wrapDecodeError(err error, typ reflect.Type) error {
+	if err == nil {
+		return nil
+	}
+	var decodeErr *decodeError
+	if errors.As(err, &decodeErr) {
+		return err
+	}
+
+	return &decodeError{err: err, typ: typ}
+}
+
+// Stream - streaming RLP decoder reading values piece by piece.
+// Values larger than the input limit or the containing list are rejected before reading them.
+type Stream struct {
+	r         io.Reader
+	remaining uint64
+	limited   bool
+	// remaining bytes of open lists
+	stack []uint64
+
+	kind      Kind
+	size      uint64
+	byteValue byte
+	kindErr   error
+	kindValid bool
+}
+
+// NewStream returns stream reading from r with the input limit in bytes.
+// Zero limit means no limit unless r is *bytes.Reader, *bytes.Buffer or *strings.Reader, then the limit is the length of its data.
+func NewStream(r io.Reader, limit uint64) *Stream {
+	s := &Stream{r: r}
+	if limit > 0 {
+		s.remaining = limit
+		s.limited = true
+		return s
+	}
+
+	switch reader := r.(type) {
+	case *bytes.Reader:
+		s.remaining = uint64(reader.Len())
+		s.limited = true
+	case *bytes.Buffer:
+		s.remaining = uint64(reader.Len())
+		s.limited = true
+	case *strings.Reader:
+		s.remaining = uint64(reader.Len())
+		s.limited = true
+	}
+
+	return s
+}
+
+// Kind returns kind and size of the next value without consuming it.
+// Size is zero for Byte kind. EOL is returned at the end of the current list.
+func (s *Stream) Kind() (Kind, uint64, error) {
+	if s.kindValid {
+		return s.kind, s.size, s.kindErr
+	}
+	if len(s.stack) > 0 && s.stack[len(s.stack)-1] == 0 {
+		return 0, 0, EOL
+	}
+
+	s.kind, s.size, s.kindErr = s.readKind()
+	if s.kindErr == nil {
+		if len(s.stack) > 0 && s.size > s.stack[len(s.stack)-1] {
+			s.kindErr = ErrElemTooLarge
+		} else if s.limited && s.size > s.remaining {
+			s.kindErr = ErrValueTooLarge
+		}
+	}
+	s.kindValid = true
+
+	return s.kind, s.size, s.kindErr
+}
+
+func (s *Stream) readKind() (Kind, uint64, error) {
+	b, err := s.read(1)
+	if err != nil {
+		if err == io.EOF && len(s.stack) > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, 0, err
+	}
+
+	switch {
+	case b[0] < 0x80:
+		s.byteValue = b[0]
+		return Byte, 0, nil
+	case b[0] < 0xb8:
+		return String, uint64(b[0] - 0x80), nil
+	case b[0] < 0xc0:
+		size, err := s.readSize(b[0] - 0xb7)
+		return String, size, err
+	case b[0] < 0xf8:
+		return List, uint64(b[0] - 0xc0), nil
+	default:
+		size, err := s.readSize(b[0] - 0xf7)
+		return List, size, err
+	}
+}
+
+// readSize reads size of long string or list
+func (s *Stream) readSize(length byte) (uint64, error) {
+	data, err := s.readFull(uint64(length))
+	if err != nil {
+		return 0, err
+	}
+	if data[0] == 0 {
+		return 0, ErrCanonSize
+	}
+
+	size := uint64(0)
+	for _, b := range data {
+		size = size<<8 | uint64(b)
+	}
+	// short form must be used for sizes below 56
+	if size < 56 {
+		return 0, ErrCanonSize
+	}
+
+	return size, nil
+}
+
+// read consumes n bytes of input checking the input limit and the current list size
+func (s *Stream) read(n uint64) ([]byte, error) {
+	if n == 0 {
+		return []byte{}, nil
+	}
+	if s.limited && n > s.remaining {
+		if s.remaining == 0 {
+			return nil, io.EOF
+		}
+		return nil, io.ErrUnexpectedEOF
+	}
+	if len(s.stack) > 0 && n > s.stack[len(s.stack)-1] {
+		return nil, ErrElemTooLarge
+	}
+
+	// input of unknown size is read by chunks, so large sizes don't cause large allocations
+	buf := new(bytes.Buffer)
+	if s.limited {
+		buf.Grow(int(n))
+	}
+	read, err := io.CopyN(buf, s.r, int64(n))
+	if err == io.EOF && read > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+
+	if s.limited {
+		s.remaining -= uint64(read)
+	}
+	if len(s.stack) > 0 {
+		s.stack[len(s.stack)-1] -= uint64(read)
+	}
+
+	return buf.Bytes(), err
+}
+
+// readFull consumes n bytes of input that must be present
+func (s *Stream) readFull(n uint64) ([]byte, error) {
+	data, err := s.read(n)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return data, err
+}
+
+// Bytes reads the next value of String or Byte kind
+func (s *Stream) Bytes() ([]byte, error) {
+	kind, size, err := s.Kind()
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case Byte:
+		s.kindValid = false
+		return []byte{s.byteValue}, nil
+	case String:
+		s.kindValid = false
+		data, err := s.readFull(size)
+		if err != nil {
+			return nil, err
+		}
+		if size == 1 && data[0] < 0x80 {
+			return nil, ErrCanonSize
+		}
+		return data, nil
+	}
+
+	return nil, ErrExpectedString
+}
+
+// Uint64 reads the next value as unsigned integer
+func (s *Stream) Uint64() (uint64, error) {
+	return s.uint(64)
+}
+
+func (s *Stream) uint(bits int) (uint64, error) {
+	kind, size, err := s.Kind()
+	if err != nil {
+		return 0, err
+	}
+
+	switch kind {
+	case Byte:
+		if s.byteValue == 0 {
+			return 0, ErrCanonInt
+		}
+		s.kindValid = false
+		return uint64(s.byteValue), nil
+	case String:
+		if size > uint64(bits/8) {
+			return 0, ErrUintOverflow
+		}
+		data, err := s.Bytes()
+		if err != nil {
+			return 0, err
+		}
+		if len(data) > 0 && data[0] == 0 {
+			return 0, ErrCanonInt
+		}
+
+		i := uint64(0)
+		for _, b := range data {
+			i = i<<8 | uint64(b)
+		}
+		return i, nil
+	}
+
+	return 0, ErrExpectedString
+}
+
+// Bool reads the next value as boolean
+func (s *Stream) Bool() (bool, error) {
+	i, err := s.uint(8)
+	if err != nil {
+		return false, err
+	}
+
+	switch i {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	}
+
+	return false, fmt.Errorf("rlp: invalid boolean value %d", i)
+}
+
+// BigInt reads the next value as unsigned big integer
+func (s *Stream) BigInt() (*big.Int, error) {
+	data, err := s.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 0 && data[0] == 0 {
+		return nil, ErrCanonInt
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
+
+// List starts decoding of list and returns its payload size.
+// ListEnd must be called after reading all list elements.
+func (s *Stream) List() (uint64, error) {
+	kind, size, err := s.Kind()
+	if err != nil {
+		return 0, err
+	}
+	if kind != List {
+		return 0, ErrExpectedList
+	}
+
+	// payload is accounted in the enclosing list at once
+	if len(s.stack) > 0 {
+		s.stack[len(s.stack)-1] -= size
+	}
+	s.stack = append(s.stack, size)
+	s.kindValid = false
+
+	return size, nil
+}
+
+// ListEnd returns to the enclosing list
+func (s *Stream) ListEnd() error {
+	if len(s.stack) == 0 || s.stack[len(s.stack)-1] != 0 {
+		return errNotAtEOL
+	}
+
+	s.stack = s.stack[:len(s.stack)-1]
+	s.kindValid = false
+
+	return nil
+}
+
+// Raw reads the next value with its header without decoding it
+func (s *Stream) Raw() ([]byte, error) {
+	kind, size, err := s.Kind()
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case Byte:
+		s.kindValid = false
+		return []byte{s.byteValue}, nil
+	case String:
+		data, err := s.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		return encodeString(data), nil
+	}
+
+	if _, err := s.List(); err != nil {
+		return nil, err
+	}
+	payload, err := s.readFull(size)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ListEnd(); err != nil {
+		return nil, err
+	}
+
+	return append(header(0xc0, size), payload...), nil
+}
+
+// Decode decodes the next value into value which must be a non-nil pointer
+func (s *Stream) Decode(value interface{}) error {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("rlp: decode target must be a non-nil pointer, got %T", value)
+	}
+
+	return s.decodeValue(v.Elem())
+}
+
+func (s *Stream) decodeValue(v reflect.Value) error {
+	typ := v.Type()
+	if typ == rawValueType {
+		raw, err := s.Raw()
+		if err != nil {
+			return wrapDecodeError(err, typ)
+		}
+		v.SetBytes(raw)
+		return nil
+	}
+
+	if reflect.PtrTo(typ).Implements(decoderType) {
+		return wrapDecodeError(v.Addr().Interface().(Decoder).DecodeRLP(s), typ)
+	}
+
+	if typ == bigIntType {
+		i, err := s.BigInt()
+		if err != nil {
+			return wrapDecodeError(err, typ)
+		}
+		v.Addr().Interface().(*big.Int).Set(i)
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(typ.Elem())
+		if err := s.decodeValue(elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case reflect.Interface:
+		if typ.NumMethod() != 0 {
+			break
+		}
+		value, err := s.decodeInterface()
+		if err != nil {
+			return wrapDecodeError(err, typ)
+		}
+		v.Set(reflect.ValueOf(value))
+		return nil
+	case reflect.Bool:
+		b, err := s.Bool()
+		if err != nil {
+			return wrapDecodeError(err, typ)
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, err := s.uint(typ.Bits())
+		if err != nil {
+			return wrapDecodeError(err, typ)
+		}
+		v.SetUint(i)
+		return nil
+	case reflect.String:
+		data, err := s.Bytes()
+		if err != nil {
+			return wrapDecodeError(err, typ)
+		}
+		v.SetString(string(data))
+		return nil
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 && !reflect.PtrTo(typ.Elem()).Implements(decoderType) {
+			return wrapDecodeError(s.decodeByteArray(v), typ)
+		}
+		return s.decodeList(v)
+	case reflect.Struct:
+		return s.decodeStruct(v)
+	}
+
+	return fmt.Errorf("rlp: type %v is not RLP-serializable", typ)
+}
+
+func (s *Stream) decodeByteArray(v reflect.Value) error {
+	data, err := s.Bytes()
+	if err != nil {
+		return err
+	}
+
+	if v.Kind() == reflect.Slice {
+		v.SetBytes(data)
+		return nil
+	}
+
+	if len(data) != v.Len() {
+		return fmt.Errorf("rlp: input string length %d doesn't match array length %d", len(data), v.Len())
+	}
+	reflect.Copy(v, reflect.ValueOf(data))
+
+	return nil
+}
+
+func (s *Stream) decodeList(v reflect.Value) error {
+	typ := v.Type()
+	if _, err := s.List(); err != nil {
+		return wrapDecodeError(err, typ)
+	}
+
+	if typ.Kind() == reflect.Slice {
+		v.Set(reflect.MakeSlice(typ, 0, 0))
+	}
+	if err := s.decodeElements(v, 0); err != nil {
+		return err
+	}
+
+	return wrapDecodeError(s.ListEnd(), typ)
+}
+
+// decodeElements decodes remaining list elements into slice or array starting from index
+func (s *Stream) decodeElements(v reflect.Value, index int) error {
+	typ := v.Type()
+	for ; ; index++ {
+		if _, _, err := s.Kind(); err == EOL {
+			break
+		} else if err != nil {
+			return wrapDecodeError(err, typ)
+		}
+
+		if typ.Kind() == reflect.Array {
+			if index >= v.Len() {
+				return wrapDecodeError(errTooManyElements, typ)
+			}
+		} else {
+			v.Set(reflect.Append(v, reflect.Zero(typ.Elem())))
+		}
+
+		if err := s.decodeValue(v.Index(index)); err != nil {
+			return err
+		}
+	}
+
+	if typ.Kind() == reflect.Array && index < v.Len() {
+		return wrapDecodeError(errTooFewElements, typ)
+	}
+
+	return nil
+}
+
+func (s *Stream) decodeStruct(v reflect.Value) error {
+	typ := v.Type()
+	fields, err := structFields(typ)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.List(); err != nil {
+		return wrapDecodeError(err, typ)
+	}
+
+	for i, f := range fields {
+		value := v.Field(f.index)
+		if f.tail {
+			value.Set(reflect.MakeSlice(value.Type(), 0, 0))
+			if err := s.decodeElements(value, 0); err != nil {
+				return err
+			}
+			break
+		}
+
+		if _, _, err := s.Kind(); err == EOL {
+			if !f.optional {
+				return wrapDecodeError(errTooFewElements, typ)
+			}
+			// missing optional fields are zero
+			for _, rest := range fields[i:] {
+				field := v.Field(rest.index)
+				field.Set(reflect.Zero(field.Type()))
+			}
+			break
+		}
+
+		if err := s.decodeValue(value); err != nil {
+			return err
+		}
+	}
+
+	if err := s.ListEnd(); err == errNotAtEOL {
+		return wrapDecodeError(errTooManyElements, typ)
+	} else if err != nil {
+		return wrapDecodeError(err, typ)
+	}
+
+	return nil
+}
+
+// decodeInterface decodes strings as []byte and lists as []interface{}
+func (s *Stream) decodeInterface() (interface{}, error) {
+	kind, _, err := s.Kind()
+	if err != nil {
+		return nil, err
+	}
+	if kind != List {
+		return s.Bytes()
+	}
+
+	if _, err := s.List(); err != nil {
+		return nil, err
+	}
+	items := []interface{}{}
+	for {
+		item, err := s.decodeInterface()
+		if err == EOL {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, s.ListEnd()
+}
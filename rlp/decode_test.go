@@ -0,0 +1,230 @@
+package rlp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func unhex(s string) []byte {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+type fooDecoder struct {
+	value string
+}
+
+func (d *fooDecoder) DecodeRLP(s *Stream) error {
+	data, err := s.Bytes()
+	d.value = "decoded " + string(data)
+	return err
+}
+
+func TestDecodeBytes(t *testing.T) {
+	var b []byte
+	require.Nil(t, DecodeBytes(unhex("83646f67"), &b))
+	require.Equal(t, []byte("dog"), b)
+	require.Nil(t, DecodeBytes(unhex("7f"), &b))
+	require.Equal(t, []byte{0x7f}, b)
+	require.Nil(t, DecodeBytes(unhex("80"), &b))
+	require.Equal(t, []byte{}, b)
+
+	var array [3]byte
+	require.Nil(t, DecodeBytes(unhex("83010203"), &array))
+	require.Equal(t, [3]byte{1, 2, 3}, array)
+	require.NotNil(t, DecodeBytes(unhex("820102"), &array))
+
+	var s string
+	long := strings.Repeat("a", 56)
+	require.Nil(t, DecodeBytes(append(unhex("b838"), long...), &s))
+	require.Equal(t, long, s)
+
+	var i uint64
+	require.Nil(t, DecodeBytes(unhex("820400"), &i))
+	require.Equal(t, uint64(1024), i)
+	require.Nil(t, DecodeBytes(unhex("80"), &i))
+	require.Equal(t, uint64(0), i)
+
+	var small uint8
+	require.True(t, errors.Is(DecodeBytes(unhex("820400"), &small), ErrUintOverflow))
+
+	var flag bool
+	require.Nil(t, DecodeBytes(unhex("01"), &flag))
+	require.True(t, flag)
+	require.NotNil(t, DecodeBytes(unhex("02"), &flag))
+
+	var bigInt *big.Int
+	require.Nil(t, DecodeBytes(unhex("8a01000000000000000000"), &bigInt))
+	require.Equal(t, "4722366482869645213696", bigInt.String())
+
+	var bigValue big.Int
+	require.Nil(t, DecodeBytes(unhex("820400"), &bigValue))
+	require.Equal(t, int64(1024), bigValue.Int64())
+
+	var list []string
+	require.Nil(t, DecodeBytes(unhex("c88363617483646f67"), &list))
+	require.Equal(t, []string{"cat", "dog"}, list)
+
+	var nested interface{}
+	require.Nil(t, DecodeBytes(unhex("c7c0c1c0c3c0c1c0"), &nested))
+	require.Equal(t, []interface{}{
+		[]interface{}{},
+		[]interface{}{[]interface{}{}},
+		[]interface{}{[]interface{}{}, []interface{}{[]interface{}{}}},
+	}, nested)
+
+	var raw []RawValue
+	require.Nil(t, DecodeBytes(unhex("c60183646f67c0"), &raw))
+	require.Equal(t, []RawValue{{0x01}, {0x83, 'd', 'o', 'g'}, {0xc0}}, raw)
+
+	var decoder fooDecoder
+	require.Nil(t, DecodeBytes(unhex("83666f6f"), &decoder))
+	require.Equal(t, "decoded foo", decoder.value)
+
+	var items [2]uint64
+	require.Nil(t, DecodeBytes(unhex("c20102"), &items))
+	require.Equal(t, [2]uint64{1, 2}, items)
+	require.NotNil(t, DecodeBytes(unhex("c101"), &items))
+	require.NotNil(t, DecodeBytes(unhex("c3010203"), &items))
+}
+
+func TestDecodeStruct(t *testing.T) {
+	value := testStruct{}
+	require.Nil(t, DecodeBytes(unhex("c6018362617280"), &value))
+	require.Equal(t, testStruct{A: 1, B: "bar", C: []byte{}}, value)
+
+	pointer := new(testStruct)
+	require.Nil(t, DecodeBytes(unhex("c6018362617280"), &pointer))
+	require.Equal(t, "bar", pointer.B)
+
+	require.True(t, errors.Is(DecodeBytes(unhex("c20183"), &value), ErrElemTooLarge))
+	require.True(t, errors.Is(DecodeBytes(unhex("c101"), &value), errTooFewElements))
+	require.True(t, errors.Is(DecodeBytes(unhex("c70183626172800a"), &value), errTooManyElements))
+	require.True(t, errors.Is(DecodeBytes(unhex("83646f67"), &value), ErrExpectedList))
+
+	optional := optionalStruct{}
+	require.Nil(t, DecodeBytes(unhex("c101"), &optional))
+	require.Equal(t, optionalStruct{A: 1}, optional)
+	require.Nil(t, DecodeBytes(unhex("c40180c102"), &optional))
+	require.Equal(t, optionalStruct{A: 1, B: big.NewInt(0), C: []uint64{2}}, optional)
+
+	tail := tailStruct{}
+	require.Nil(t, DecodeBytes(unhex("c3010203"), &tail))
+	require.Equal(t, tailStruct{A: 1, Tail: []RawValue{{0x02}, {0x03}}}, tail)
+
+	ignored := ignoredStruct{}
+	require.Nil(t, DecodeBytes(unhex("c101"), &ignored))
+	require.Equal(t, uint64(1), ignored.A)
+
+	err := DecodeBytes(unhex("c6808362617280"), &value)
+	require.Nil(t, err)
+	err = DecodeBytes(unhex("c6008362617280"), &value)
+	require.True(t, errors.Is(err, ErrCanonInt))
+	require.Contains(t, err.Error(), "uint64")
+}
+
+func TestDecodeErrors(t *testing.T) {
+	var b []byte
+	var i uint64
+	var list []uint64
+
+	require.NotNil(t, DecodeBytes(unhex("83646f67"), b))
+	require.NotNil(t, DecodeBytes(unhex("83646f67"), nil))
+
+	require.Equal(t, ErrMoreThanOneValue, DecodeBytes(unhex("0102"), &i))
+	require.True(t, errors.Is(DecodeBytes(unhex(""), &b), io.EOF))
+	require.True(t, errors.Is(DecodeBytes(unhex("8363"), &b), ErrValueTooLarge))
+	require.True(t, errors.Is(DecodeBytes(unhex("b8"), &b), io.ErrUnexpectedEOF))
+
+	// single byte must be encoded as itself
+	require.True(t, errors.Is(DecodeBytes(unhex("8105"), &b), ErrCanonSize))
+	// short size in long form
+	require.True(t, errors.Is(DecodeBytes(unhex("b80161"), &b), ErrCanonSize))
+	// size with leading zero
+	require.True(t, errors.Is(DecodeBytes(append(unhex("b90038"), make([]byte, 56)...), &b), ErrCanonSize))
+	// integer with leading zero
+	require.True(t, errors.Is(DecodeBytes(unhex("820004"), &i), ErrCanonInt))
+	require.True(t, errors.Is(DecodeBytes(unhex("00"), &i), ErrCanonInt))
+
+	require.True(t, errors.Is(DecodeBytes(unhex("c101"), &i), ErrExpectedString))
+	require.True(t, errors.Is(DecodeBytes(unhex("01"), &list), ErrExpectedList))
+	// element size exceeds the list
+	require.True(t, errors.Is(DecodeBytes(unhex("c28363"), &list), ErrElemTooLarge))
+
+	var m map[string]string
+	require.NotNil(t, DecodeBytes(unhex("c0"), &m))
+}
+
+func TestStream(t *testing.T) {
+	s := NewStream(bytes.NewReader(unhex("c80183646f67c20203")), 0)
+
+	kind, size, err := s.Kind()
+	require.Nil(t, err)
+	require.Equal(t, List, kind)
+	require.Equal(t, uint64(8), size)
+
+	_, err = s.List()
+	require.Nil(t, err)
+
+	i, err := s.Uint64()
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), i)
+
+	kind, size, err = s.Kind()
+	require.Nil(t, err)
+	require.Equal(t, String, kind)
+	require.Equal(t, uint64(3), size)
+	require.Equal(t, "String", kind.String())
+
+	data, err := s.Bytes()
+	require.Nil(t, err)
+	require.Equal(t, []byte("dog"), data)
+
+	raw, err := s.Raw()
+	require.Nil(t, err)
+	require.Equal(t, unhex("c20203"), raw)
+
+	_, _, err = s.Kind()
+	require.Equal(t, EOL, err)
+	require.Nil(t, s.ListEnd())
+
+	_, _, err = s.Kind()
+	require.Equal(t, io.EOF, err)
+
+	// ListEnd before reading all elements
+	s = NewStream(bytes.NewReader(unhex("c20102")), 0)
+	_, err = s.List()
+	require.Nil(t, err)
+	require.Equal(t, errNotAtEOL, s.ListEnd())
+}
+
+func TestStreamLimit(t *testing.T) {
+	// String larger than the limit is rejected before reading it
+	s := NewStream(io.MultiReader(bytes.NewReader(unhex("bb7fffffff"))), 1024)
+	_, err := s.Bytes()
+	require.Equal(t, ErrValueTooLarge, err)
+
+	// Reader of unknown size without limit
+	s = NewStream(io.MultiReader(bytes.NewReader(unhex("bb7fffffff01"))), 0)
+	_, err = s.Bytes()
+	require.Equal(t, io.ErrUnexpectedEOF, err)
+
+	var list []uint64
+	require.Nil(t, Decode(io.MultiReader(bytes.NewReader(unhex("c20102"))), &list))
+	require.Equal(t, []uint64{1, 2}, list)
+
+	s = NewStream(bytes.NewReader(unhex("c20102c0")), 3)
+	require.Nil(t, s.Decode(&list))
+	_, _, err = s.Kind()
+	require.Equal(t, io.EOF, err)
+}
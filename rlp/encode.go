@@ -0,0 +1,193 @@
+package rlp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+)
+
+// Encode writes RLP encoding of value to w
+func Encode(w io.Writer, value interface{}) error {
+	data, err := EncodeToBytes(value)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// EncodeToBytes returns RLP encoding of value
+func EncodeToBytes(value interface{}) ([]byte, error) {
+	return encodeValue(reflect.ValueOf(value))
+}
+
+func encodeValue(v reflect.Value) ([]byte, error) {
+	if !v.IsValid() {
+		// nil interface
+		return []byte{0xc0}, nil
+	}
+
+	typ := v.Type()
+	if typ == rawValueType {
+		return v.Bytes(), nil
+	}
+
+	if typ.Kind() != reflect.Interface && typ.Implements(encoderType) && !(typ.Kind() == reflect.Ptr && v.IsNil()) {
+		return encodeWithEncoder(v.Interface().(Encoder))
+	}
+	if typ.Kind() != reflect.Ptr && reflect.PtrTo(typ).Implements(encoderType) && v.CanAddr() {
+		return encodeWithEncoder(v.Addr().Interface().(Encoder))
+	}
+
+	if typ == bigIntType {
+		i := v.Interface().(big.Int)
+		return encodeBigInt(&i)
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			if isListType(typ.Elem()) {
+				return []byte{0xc0}, nil
+			}
+			return []byte{0x80}, nil
+		}
+		if typ.Elem() == bigIntType {
+			return encodeBigInt(v.Interface().(*big.Int))
+		}
+		return encodeValue(v.Elem())
+	case reflect.Interface:
+		return encodeValue(v.Elem())
+	case reflect.Bool:
+		if v.Bool() {
+			return []byte{0x01}, nil
+		}
+		return []byte{0x80}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return encodeUint(v.Uint()), nil
+	case reflect.String:
+		return encodeString([]byte(v.String())), nil
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 && !typ.Elem().Implements(encoderType) {
+			if typ.Kind() == reflect.Slice {
+				return encodeString(v.Bytes()), nil
+			}
+			data := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(data), v)
+			return encodeString(data), nil
+		}
+
+		payload := []byte{}
+		for i := 0; i < v.Len(); i++ {
+			item, err := encodeValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			payload = append(payload, item...)
+		}
+		return append(header(0xc0, uint64(len(payload))), payload...), nil
+	case reflect.Struct:
+		return encodeStruct(v)
+	}
+
+	return nil, fmt.Errorf("rlp: type %v is not RLP-serializable", typ)
+}
+
+func encodeWithEncoder(encoder Encoder) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := encoder.EncodeRLP(buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encodeStruct(v reflect.Value) ([]byte, error) {
+	fields, err := structFields(v.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	// trailing zero optional fields are omitted
+	last := len(fields) - 1
+	for ; last >= 0; last-- {
+		if !fields[last].optional || !v.Field(fields[last].index).IsZero() {
+			break
+		}
+	}
+
+	payload := []byte{}
+	for _, f := range fields[:last+1] {
+		value := v.Field(f.index)
+		if f.tail {
+			for i := 0; i < value.Len(); i++ {
+				item, err := encodeValue(value.Index(i))
+				if err != nil {
+					return nil, err
+				}
+				payload = append(payload, item...)
+			}
+			continue
+		}
+
+		item, err := encodeValue(value)
+		if err != nil {
+			return nil, err
+		}
+		payload = append(payload, item...)
+	}
+
+	return append(header(0xc0, uint64(len(payload))), payload...), nil
+}
+
+func encodeBigInt(i *big.Int) ([]byte, error) {
+	if i.Sign() < 0 {
+		return nil, fmt.Errorf("rlp: cannot encode negative big.Int")
+	}
+
+	return encodeString(i.Bytes()), nil
+}
+
+func encodeUint(i uint64) []byte {
+	if i == 0 {
+		return []byte{0x80}
+	}
+	if i < 0x80 {
+		return []byte{byte(i)}
+	}
+
+	return encodeString(uintBytes(i))
+}
+
+func encodeString(data []byte) []byte {
+	if len(data) == 1 && data[0] < 0x80 {
+		return []byte{data[0]}
+	}
+
+	return append(header(0x80, uint64(len(data))), data...)
+}
+
+// header returns string (offset 0x80) or list (offset 0xc0) header of payload with given size
+func header(offset byte, size uint64) []byte {
+	if size < 56 {
+		return []byte{offset + byte(size)}
+	}
+
+	length := uintBytes(size)
+	return append([]byte{offset + 55 + byte(len(length))}, length...)
+}
+
+// uintBytes returns big-endian bytes of i without leading zeros
+func uintBytes(i uint64) []byte {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, i)
+	for len(data) > 0 && data[0] == 0 {
+		data = data[1:]
+	}
+
+	return data
+}
@@ -0,0 +1,116 @@
+package rlp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testStruct struct {
+	A uint64
+	B string
+	C []byte
+}
+
+type optionalStruct struct {
+	A uint64
+	B *big.Int `rlp:"optional"`
+	C []uint64 `rlp:"optional"`
+}
+
+type tailStruct struct {
+	A    uint64
+	Tail []RawValue `rlp:"tail"`
+}
+
+type ignoredStruct struct {
+	A uint64
+	B uint64 `rlp:"-"`
+	c uint64
+}
+
+type fooEncoder struct{}
+
+func (fooEncoder) EncodeRLP(w io.Writer) error {
+	_, err := w.Write([]byte{0x83, 'f', 'o', 'o'})
+	return err
+}
+
+func TestEncodeToBytes(t *testing.T) {
+	encode := func(value interface{}) string {
+		data, err := EncodeToBytes(value)
+		require.Nil(t, err)
+		return hex.EncodeToString(data)
+	}
+
+	require.Equal(t, "80", encode([]byte{}))
+	require.Equal(t, "00", encode([]byte{0}))
+	require.Equal(t, "7f", encode([]byte{0x7f}))
+	require.Equal(t, "8180", encode([]byte{0x80}))
+	require.Equal(t, "83646f67", encode([]byte("dog")))
+	require.Equal(t, "83646f67", encode("dog"))
+	require.Equal(t, "83010203", encode([3]byte{1, 2, 3}))
+	require.Equal(t, "80", encode(uint64(0)))
+	require.Equal(t, "0f", encode(uint64(15)))
+	require.Equal(t, "820400", encode(uint64(1024)))
+	require.Equal(t, "88ffffffffffffffff", encode(uint64(1<<64-1)))
+	require.Equal(t, "01", encode(true))
+	require.Equal(t, "80", encode(false))
+	require.Equal(t, "80", encode((*big.Int)(nil)))
+	require.Equal(t, "80", encode(big.NewInt(0)))
+	require.Equal(t, "8a01000000000000000000", encode(new(big.Int).Lsh(big.NewInt(1), 72)))
+	require.Equal(t, "820400", encode(*big.NewInt(1024)))
+	require.Equal(t, "c0", encode([]interface{}{}))
+	require.Equal(t, "c0", encode(nil))
+	require.Equal(t, "c0", encode((*testStruct)(nil)))
+	require.Equal(t, "80", encode((*uint64)(nil)))
+	require.Equal(t, "c88363617483646f67", encode([]string{"cat", "dog"}))
+	require.Equal(t, "c88363617483646f67", encode([]interface{}{[]byte("cat"), "dog"}))
+	require.Equal(t, "c7c0c1c0c3c0c1c0", encode([]interface{}{
+		[]interface{}{},
+		[]interface{}{[]interface{}{}},
+		[]interface{}{[]interface{}{}, []interface{}{[]interface{}{}}},
+	}))
+	require.Equal(t, "c50183646f67", encode(RawValue{0xc5, 0x01, 0x83, 'd', 'o', 'g'}))
+	require.Equal(t, "83666f6f", encode(fooEncoder{}))
+	require.Equal(t, "c483666f6f", encode([]fooEncoder{{}}))
+
+	long := []byte(strings.Repeat("a", 56))
+	require.Equal(t, "b838"+hex.EncodeToString(long), encode(long))
+	longList := make([]uint64, 60)
+	require.Equal(t, "f83c"+strings.Repeat("80", 60), encode(longList))
+
+	// structs
+	require.Equal(t, "c6018362617280", encode(testStruct{A: 1, B: "bar"}))
+	require.Equal(t, "c6018362617280", encode(&testStruct{A: 1, B: "bar"}))
+	require.Equal(t, "c101", encode(optionalStruct{A: 1}))
+	require.Equal(t, "c20102", encode(optionalStruct{A: 1, B: big.NewInt(2)}))
+	require.Equal(t, "c40180c102", encode(optionalStruct{A: 1, C: []uint64{2}}))
+	require.Equal(t, "c3010203", encode(tailStruct{A: 1, Tail: []RawValue{{0x02}, {0x03}}}))
+	require.Equal(t, "c101", encode(ignoredStruct{A: 1, B: 2, c: 3}))
+
+	for _, value := range []interface{}{
+		-1,
+		big.NewInt(-1),
+		map[string]string{},
+		struct {
+			A uint64 `rlp:"optional"`
+			B uint64
+		}{},
+		struct {
+			A uint64 `rlp:"unknown"`
+		}{},
+	} {
+		_, err := EncodeToBytes(value)
+		require.NotNil(t, err, "%T", value)
+	}
+
+	buf := new(bytes.Buffer)
+	require.Nil(t, Encode(buf, "dog"))
+	require.Equal(t, []byte{0x83, 'd', 'o', 'g'}, buf.Bytes())
+}
@@ -0,0 +1,58 @@
+package rlp
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func FuzzDecode(f *testing.F) {
+	for _, seed := range []string{"", "00", "7f", "80", "8180", "83646f67", "b838", "c0", "c7c0c1c0c3c0c1c0", "f83c80", "bb7fffffff", "c20183"} {
+		f.Add(unhex(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var value interface{}
+		if err := DecodeBytes(data, &value); err != nil {
+			return
+		}
+
+		// only canonical input is accepted, so encoding restores it exactly
+		encoded, err := EncodeToBytes(value)
+		if err != nil {
+			t.Fatalf("can't encode decoded value %v: %v", value, err)
+		}
+		if !bytes.Equal(data, encoded) {
+			t.Fatalf("roundtrip mismatch: input %x, encoded %x", data, encoded)
+		}
+	})
+}
+
+func FuzzDecodeStruct(f *testing.F) {
+	for _, seed := range []string{"c6018362617280", "c101", "c40180c102", "c3010203"} {
+		f.Add(unhex(seed))
+	}
+
+	type fuzzStruct struct {
+		A uint64
+		B *big.Int
+		C []byte
+		D []fuzzStruct `rlp:"optional"`
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		value := fuzzStruct{}
+		if err := DecodeBytes(data, &value); err != nil {
+			return
+		}
+
+		encoded, err := EncodeToBytes(value)
+		if err != nil {
+			t.Fatalf("can't encode decoded value %v: %v", value, err)
+		}
+		decoded := fuzzStruct{}
+		if err := DecodeBytes(encoded, &decoded); err != nil {
+			t.Fatalf("can't decode encoded value %x: %v", encoded, err)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+// Package rlp implements Recursive Length Prefix serialization used by Ethereum.
+//
+// Supported Go types are []byte, [N]byte, string, bool, unsigned integers, big.Int, slices, arrays,
+// structs and pointers to them. Struct fields are encoded as list in the declaration order,
+// unexported fields are skipped. Field behaviour can be changed with tags:
+//
+//	rlp:"-"         field is ignored
+//	rlp:"optional"  field is omitted at the end of list when it and all following fields are zero
+//	rlp:"tail"      last slice field absorbs all remaining list elements
+package rlp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Kind - kind of RLP value
+type Kind int
+
+// RLP value kinds
+const (
+	Byte Kind = iota
+	String
+	List
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Byte:
+		return "Byte"
+	case String:
+		return "String"
+	case List:
+		return "List"
+	}
+
+	return fmt.Sprintf("Unknown(%d)", int(k))
+}
+
+var (
+	// EOL - returned when the end of the current list is reached
+	EOL = errors.New("rlp: end of list")
+
+	ErrExpectedString   = errors.New("rlp: expected String or Byte")
+	ErrExpectedList     = errors.New("rlp: expected List")
+	ErrCanonInt         = errors.New("rlp: non-canonical integer format")
+	ErrCanonSize        = errors.New("rlp: non-canonical size information")
+	ErrElemTooLarge     = errors.New("rlp: element is larger than containing list")
+	ErrValueTooLarge    = errors.New("rlp: value size exceeds available input length")
+	ErrMoreThanOneValue = errors.New("rlp: input contains more than one value")
+	ErrUintOverflow     = errors.New("rlp: uint overflow")
+
+	errNotAtEOL        = errors.New("rlp: call of ListEnd not positioned at EOL")
+	errTooFewElements  = errors.New("rlp: too few elements")
+	errTooManyElements = errors.New("rlp: input list has too many elements")
+)
+
+// RawValue - already encoded RLP value. It is written as is and decoded without processing.
+type RawValue []byte
+
+// Encoder - implemented by types with custom RLP encoding
+type Encoder interface {
+	EncodeRLP(w io.Writer) error
+}
+
+// Decoder - implemented by types with custom RLP decoding
+type Decoder interface {
+	DecodeRLP(s *Stream) error
+}
+
+var (
+	rawValueType = reflect.TypeOf(RawValue{})
+	bigIntType   = reflect.TypeOf(big.Int{})
+	encoderType  = reflect.TypeOf((*Encoder)(nil)).Elem()
+	decoderType  = reflect.TypeOf((*Decoder)(nil)).Elem()
+)
+
+// field - struct field taking part in encoding
+type field struct {
+	index    int
+	optional bool
+	tail     bool
+}
+
+var structFieldsCache sync.Map
+
+// structFields returns encoded fields of struct type according to rlp tags
+func structFields(typ reflect.Type) ([]field, error) {
+	if cached, ok := structFieldsCache.Load(typ); ok {
+		return cached.([]field), nil
+	}
+
+	fields := []field{}
+	for i := 0; i < typ.NumField(); i++ {
+		structField := typ.Field(i)
+		if structField.PkgPath != "" {
+			continue
+		}
+
+		f := field{index: i}
+		for _, tag := range strings.Split(structField.Tag.Get("rlp"), ",") {
+			switch strings.TrimSpace(tag) {
+			case "":
+			case "-":
+				f.index = -1
+			case "optional":
+				f.optional = true
+			case "tail":
+				if i != typ.NumField()-1 || structField.Type.Kind() != reflect.Slice {
+					return nil, fmt.Errorf(`rlp: invalid struct tag "tail" for %v.%s (must be on last field with slice type)`, typ, structField.Name)
+				}
+				f.tail = true
+			default:
+				return nil, fmt.Errorf("rlp: unknown struct tag %q on %v.%s", tag, typ, structField.Name)
+			}
+		}
+		if f.index < 0 {
+			continue
+		}
+
+		if len(fields) > 0 {
+			previous := fields[len(fields)-1]
+			if previous.optional && !f.optional && !f.tail {
+				return nil, fmt.Errorf(`rlp: struct field %v.%s needs "optional" tag because previous field is optional`, typ, structField.Name)
+			}
+		}
+		fields = append(fields, f)
+	}
+
+	structFieldsCache.Store(typ, fields)
+	return fields, nil
+}
+
+// isListType reports whether nil value of type is encoded as empty list
+func isListType(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Struct:
+		return typ != bigIntType
+	case reflect.Slice, reflect.Array:
+		return typ.Elem().Kind() != reflect.Uint8
+	case reflect.Interface:
+		return true
+	}
+
+	return false
+}
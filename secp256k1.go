@@ -0,0 +1,127 @@
+package ethrpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// compactSignatureOffset - recovery code offset of compact [V || R || S] signatures, V = 27 + recovery id
+const compactSignatureOffset = 27
+
+// PrivateKey - secp256k1 private key, curve operations are constant time
+type PrivateKey struct {
+	key *secp256k1.PrivateKey
+}
+
+// NewPrivateKey parse hex encoded 32 bytes private key
+func NewPrivateKey(value string) (*PrivateKey, error) {
+	data, err := hexToBytes(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return PrivateKeyFromBytes(data)
+}
+
+// PrivateKeyFromBytes returns private key from 32 bytes big-endian scalar
+func PrivateKeyFromBytes(data []byte) (*PrivateKey, error) {
+	if len(data) != 32 {
+		return nil, fmt.Errorf("invalid private key length %d, expected 32 bytes", len(data))
+	}
+
+	var d secp256k1.ModNScalar
+	if overflow := d.SetByteSlice(data); overflow || d.IsZero() {
+		return nil, errors.New("invalid private key")
+	}
+
+	return &PrivateKey{key: secp256k1.NewPrivateKey(&d)}, nil
+}
+
+// GeneratePrivateKey returns new random private key
+func GeneratePrivateKey() (*PrivateKey, error) {
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrivateKey{key: key}, nil
+}
+
+// Bytes returns 32 bytes big-endian private key scalar
+func (key *PrivateKey) Bytes() []byte {
+	return key.key.Serialize()
+}
+
+// PublicKey returns 64 bytes uncompressed public key without 0x04 prefix
+func (key *PrivateKey) PublicKey() []byte {
+	return key.key.PubKey().SerializeUncompressed()[1:]
+}
+
+// Address returns the account address of the key
+func (key *PrivateKey) Address() string {
+	return publicKeyToAddress(key.PublicKey())
+}
+
+// SignHash signs 32 bytes hash and returns 65 bytes [R || S || V] signature where V is 0 or 1.
+// Nonce is generated deterministically (RFC 6979) and S is normalized to the lower half of the curve order.
+func (key *PrivateKey) SignHash(hash []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, fmt.Errorf("invalid hash length %d, expected 32 bytes", len(hash))
+	}
+
+	// Compact signature is [V || R || S] with V = 27 + recovery id
+	compact := ecdsa.SignCompact(key.key, hash, false)
+
+	signature := make([]byte, 65)
+	copy(signature, compact[1:])
+	signature[64] = compact[0] - compactSignatureOffset
+
+	return signature, nil
+}
+
+// EcRecover returns the public key that produced 65 bytes [R || S || V] signature of hash.
+// V can be 0, 1 or 27, 28.
+func EcRecover(hash, signature []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, fmt.Errorf("invalid hash length %d, expected 32 bytes", len(hash))
+	}
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("invalid signature length %d, expected 65 bytes", len(signature))
+	}
+
+	v := signature[64]
+	if v >= compactSignatureOffset {
+		v -= compactSignatureOffset
+	}
+	if v > 3 {
+		return nil, errors.New("invalid signature recovery id")
+	}
+
+	compact := make([]byte, 65)
+	compact[0] = compactSignatureOffset + v
+	copy(compact[1:], signature[:64])
+
+	publicKey, _, err := ecdsa.RecoverCompact(compact, hash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	return publicKey.SerializeUncompressed()[1:], nil
+}
+
+// RecoverAddress returns the address of the account that produced 65 bytes [R || S || V] signature of hash.
+func RecoverAddress(hash, signature []byte) (string, error) {
+	publicKey, err := EcRecover(hash, signature)
+	if err != nil {
+		return "", err
+	}
+
+	return publicKeyToAddress(publicKey), nil
+}
+
+func publicKeyToAddress(publicKey []byte) string {
+	return fmt.Sprintf("0x%x", Keccak256(publicKey)[12:])
+}
@@ -0,0 +1,99 @@
+package ethrpc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivateKey(t *testing.T) {
+	key, err := NewPrivateKey("0x0000000000000000000000000000000000000000000000000000000000000001")
+	require.Nil(t, err)
+	require.Equal(t, "0x7e5f4552091a69125d5dfcb7b8c2659029395bdf", key.Address())
+	require.Equal(t, "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8", hex.EncodeToString(key.PublicKey()))
+
+	key, err = NewPrivateKey("4646464646464646464646464646464646464646464646464646464646464646")
+	require.Nil(t, err)
+	require.Equal(t, "0x9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f", key.Address())
+	require.Equal(t, "4646464646464646464646464646464646464646464646464646464646464646", hex.EncodeToString(key.Bytes()))
+
+	_, err = NewPrivateKey("0x00")
+	require.NotNil(t, err)
+	_, err = NewPrivateKey("zz")
+	require.NotNil(t, err)
+	_, err = PrivateKeyFromBytes(make([]byte, 32))
+	require.NotNil(t, err)
+	_, err = PrivateKeyFromBytes(secp256k1.S256().N.Bytes())
+	require.NotNil(t, err)
+
+	generated, err := GeneratePrivateKey()
+	require.Nil(t, err)
+	restored, err := PrivateKeyFromBytes(generated.Bytes())
+	require.Nil(t, err)
+	require.Equal(t, generated.Address(), restored.Address())
+}
+
+func TestSignHash(t *testing.T) {
+	key, err := NewPrivateKey("0x0000000000000000000000000000000000000000000000000000000000000001")
+	require.Nil(t, err)
+
+	// RFC 6979 test vector
+	hash := sha256.Sum256([]byte("Satoshi Nakamoto"))
+	signature, err := key.SignHash(hash[:])
+	require.Nil(t, err)
+	require.Equal(t, "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d82442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e501", hex.EncodeToString(signature))
+
+	address, err := RecoverAddress(hash[:], signature)
+	require.Nil(t, err)
+	require.Equal(t, key.Address(), address)
+
+	signature[64] += 27
+	publicKey, err := EcRecover(hash[:], signature)
+	require.Nil(t, err)
+	require.Equal(t, key.PublicKey(), publicKey)
+
+	_, err = key.SignHash([]byte{1})
+	require.NotNil(t, err)
+}
+
+func TestSignHashLowS(t *testing.T) {
+	key, err := GeneratePrivateKey()
+	require.Nil(t, err)
+
+	for i := 0; i < 20; i++ {
+		hash := Keccak256([]byte{byte(i)})
+		signature, err := key.SignHash(hash)
+		require.Nil(t, err)
+		var s secp256k1.ModNScalar
+		require.False(t, s.SetByteSlice(signature[32:64]))
+		require.False(t, s.IsOverHalfOrder())
+
+		address, err := RecoverAddress(hash, signature)
+		require.Nil(t, err)
+		require.Equal(t, key.Address(), address)
+	}
+}
+
+func TestEcRecoverInvalid(t *testing.T) {
+	hash := Keccak256([]byte("data"))
+
+	_, err := EcRecover(hash[:31], make([]byte, 65))
+	require.NotNil(t, err)
+	_, err = EcRecover(hash, make([]byte, 64))
+	require.NotNil(t, err)
+	_, err = EcRecover(hash, make([]byte, 65))
+	require.NotNil(t, err)
+
+	signature := make([]byte, 65)
+	signature[31], signature[63], signature[64] = 1, 1, 5
+	_, err = EcRecover(hash, signature)
+	require.NotNil(t, err)
+
+	// x = 5 is not on the curve
+	signature[31], signature[64] = 5, 0
+	_, err = RecoverAddress(hash, signature)
+	require.NotNil(t, err)
+}
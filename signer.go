@@ -0,0 +1,138 @@
+package ethrpc
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Signer - signs transactions and messages on behalf of an account
+type Signer interface {
+	Address() string
+	SignTransaction(transaction T) (*SignedTransaction, error)
+	SignMessage(message []byte) (string, error)
+	SignTypedData(data TypedData) (string, error)
+}
+
+var _ Signer = (*PrivateKey)(nil)
+var _ Signer = (*KeystoreSigner)(nil)
+var _ Signer = (*NodeSigner)(nil)
+var _ Signer = (*RemoteSigner)(nil)
+
+// MessageHash returns EIP-191 hash of message: keccak256("\x19Ethereum Signed Message:\n" + len(message) + message)
+func MessageHash(message []byte) []byte {
+	return Keccak256([]byte("\x19Ethereum Signed Message:\n"+strconv.Itoa(len(message))), message)
+}
+
+// SignMessage signs EIP-191 hash of message and returns hex encoded signature with V 27 or 28.
+func (key *PrivateKey) SignMessage(message []byte) (string, error) {
+	return key.signHashHex(MessageHash(message))
+}
+
+// SignTypedData signs EIP-712 typed data and returns hex encoded signature with V 27 or 28.
+func (key *PrivateKey) SignTypedData(data TypedData) (string, error) {
+	hash, err := data.Hash()
+	if err != nil {
+		return "", err
+	}
+
+	return key.signHashHex(hash)
+}
+
+func (key *PrivateKey) signHashHex(hash []byte) (string, error) {
+	signature, err := key.SignHash(hash)
+	if err != nil {
+		return "", err
+	}
+	signature[64] += 27
+
+	return fmt.Sprintf("0x%x", signature), nil
+}
+
+// NodeSigner - signer using keys managed by the node (eth_sign, eth_signTransaction, eth_signTypedData_v4).
+// It also works with web3signer eth1 JSON-RPC endpoint.
+type NodeSigner struct {
+	rpc     *EthRPC
+	address string
+}
+
+// NewNodeSigner returns signer using account with given address of the node
+func NewNodeSigner(rpc *EthRPC, address string) *NodeSigner {
+	return &NodeSigner{
+		rpc:     rpc,
+		address: address,
+	}
+}
+
+// Address returns the signer account address
+func (signer *NodeSigner) Address() string {
+	return signer.address
+}
+
+// SignTransaction signs transaction with eth_signTransaction
+func (signer *NodeSigner) SignTransaction(transaction T) (*SignedTransaction, error) {
+	transaction.From = signer.address
+
+	return signer.rpc.EthSignTransaction(transaction)
+}
+
+// SignMessage signs message with eth_sign
+func (signer *NodeSigner) SignMessage(message []byte) (string, error) {
+	return signer.rpc.EthSign(signer.address, fmt.Sprintf("0x%x", message))
+}
+
+// SignTypedData signs typed data with eth_signTypedData_v4
+func (signer *NodeSigner) SignTypedData(data TypedData) (string, error) {
+	return signer.rpc.EthSignTypedDataV4(signer.address, data)
+}
+
+// RemoteSigner - signer using Clef compatible external signer API (account_ namespace)
+type RemoteSigner struct {
+	rpc     *EthRPC
+	address string
+}
+
+// NewRemoteSigner returns signer using account with given address of external signer with given url
+func NewRemoteSigner(url string, address string, options ...func(rpc *EthRPC)) *RemoteSigner {
+	return &RemoteSigner{
+		rpc:     New(url, options...),
+		address: address,
+	}
+}
+
+// Address returns the signer account address
+func (signer *RemoteSigner) Address() string {
+	return signer.address
+}
+
+// Accounts returns a list of addresses managed by the external signer
+func (signer *RemoteSigner) Accounts() ([]string, error) {
+	accounts := []string{}
+
+	err := signer.rpc.call("account_list", &accounts)
+	return accounts, err
+}
+
+// SignTransaction signs transaction with account_signTransaction
+func (signer *RemoteSigner) SignTransaction(transaction T) (*SignedTransaction, error) {
+	transaction.From = signer.address
+	signed := new(SignedTransaction)
+
+	err := signer.rpc.call("account_signTransaction", signed, transaction)
+	return signed, err
+}
+
+// SignMessage signs text/plain data with account_signData
+func (signer *RemoteSigner) SignMessage(message []byte) (string, error) {
+	var signature string
+
+	err := signer.rpc.call("account_signData", &signature, "text/plain", signer.address, fmt.Sprintf("0x%x", message))
+	return signature, err
+}
+
+// SignTypedData signs typed data with account_signTypedData
+func (signer *RemoteSigner) SignTypedData(data TypedData) (string, error) {
+	var signature string
+
+	err := signer.rpc.call("account_signTypedData", &signature, signer.address, data)
+	return signature, err
+}
@@ -0,0 +1,170 @@
+package ethrpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivateKeySigner(t *testing.T) {
+	key, err := PrivateKeyFromBytes(Keccak256([]byte("cow")))
+	require.Nil(t, err)
+	require.Equal(t, "0xcd2a3d9f938e13cd947ec05abc7fe734df8dd826", key.Address())
+
+	data := TypedData{}
+	require.Nil(t, json.Unmarshal([]byte(mailTypedData), &data))
+
+	signature, err := key.SignTypedData(data)
+	require.Nil(t, err)
+	require.Equal(t, "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c", signature)
+
+	signature, err = key.SignMessage([]byte("hello"))
+	require.Nil(t, err)
+	signatureBytes, err := hexToBytes(signature)
+	require.Nil(t, err)
+	require.Contains(t, []byte{27, 28}, signatureBytes[64])
+
+	address, err := RecoverAddress(MessageHash([]byte("hello")), signatureBytes)
+	require.Nil(t, err)
+	require.Equal(t, key.Address(), address)
+
+	require.Equal(t, "50b2c43fd39106bafbba0da34fc430e1f91e3c96ea2acee2bc34119f92b37750", hex.EncodeToString(MessageHash([]byte("hello"))))
+}
+
+func (s *EthRPCTestSuite) TestNodeSigner() {
+	address := "0x9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f"
+	signer := NewNodeSigner(s.rpc, address)
+	s.Require().Equal(address, signer.Address())
+
+	s.registerResponse(`{"raw": "0x01", "tx": {"hash": "0x02"}}`, func(body []byte) {
+		s.methodEqual(body, "eth_signTransaction")
+		s.paramsEqual(body, fmt.Sprintf(`[{"from": "%s", "to": "0x3535353535353535353535353535353535353535", "gas": "0x5208"}]`, address))
+	})
+	signed, err := signer.SignTransaction(T{To: "0x3535353535353535353535353535353535353535", Gas: 21000})
+	s.Require().Nil(err)
+	s.Require().Equal("0x01", signed.Raw)
+
+	s.registerResponse(`"0x03"`, func(body []byte) {
+		s.methodEqual(body, "eth_sign")
+		s.paramsEqual(body, fmt.Sprintf(`["%s", "0x68656c6c6f"]`, address))
+	})
+	signature, err := signer.SignMessage([]byte("hello"))
+	s.Require().Nil(err)
+	s.Require().Equal("0x03", signature)
+
+	s.registerResponse(`"0x04"`, func(body []byte) {
+		s.methodEqual(body, "eth_signTypedData_v4")
+	})
+	signature, err = signer.SignTypedData(TypedData{PrimaryType: "EIP712Domain"})
+	s.Require().Nil(err)
+	s.Require().Equal("0x04", signature)
+}
+
+// newClefStandIn returns test server implementing Clef account_ methods with the key
+func newClefStandIn(t *testing.T, key *PrivateKey) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := struct {
+			ID     int               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}{}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+
+		var result interface{}
+		var err error
+		switch request.Method {
+		case "account_list":
+			result = []string{key.Address()}
+		case "account_signData":
+			var contentType, address, data string
+			require.Nil(t, json.Unmarshal(request.Params[0], &contentType))
+			require.Nil(t, json.Unmarshal(request.Params[1], &address))
+			require.Nil(t, json.Unmarshal(request.Params[2], &data))
+			require.Equal(t, "text/plain", contentType)
+			require.Equal(t, key.Address(), address)
+			message, _ := hexToBytes(data)
+			result, err = key.SignMessage(message)
+		case "account_signTypedData":
+			data := TypedData{}
+			require.Nil(t, json.Unmarshal(request.Params[1], &data))
+			result, err = key.SignTypedData(data)
+		case "account_signTransaction":
+			tx := struct {
+				From     string  `json:"from"`
+				To       string  `json:"to"`
+				Gas      hexInt  `json:"gas"`
+				GasPrice *hexBig `json:"gasPrice"`
+				Nonce    hexInt  `json:"nonce"`
+				ChainID  *hexBig `json:"chainId"`
+			}{}
+			require.Nil(t, json.Unmarshal(request.Params[0], &tx))
+			require.Equal(t, key.Address(), tx.From)
+			var signed *SignedTransaction
+			signed, err = key.SignTransaction(T{
+				To:       tx.To,
+				Gas:      int(tx.Gas),
+				GasPrice: (*big.Int)(tx.GasPrice),
+				Nonce:    int(tx.Nonce),
+				ChainID:  (*big.Int)(tx.ChainID),
+			})
+			if err == nil {
+				result = map[string]interface{}{"raw": signed.Raw, "tx": map[string]string{"hash": signed.Tx.Hash}}
+			}
+		default:
+			err = fmt.Errorf("the method %s does not exist/is not available", request.Method)
+		}
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": result}
+		if err != nil {
+			response = map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "error": map[string]interface{}{"code": -32601, "message": err.Error()}}
+		}
+		require.Nil(t, json.NewEncoder(w).Encode(response))
+	}))
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key, err := PrivateKeyFromBytes(Keccak256([]byte("cow")))
+	require.Nil(t, err)
+
+	server := newClefStandIn(t, key)
+	defer server.Close()
+
+	signer := NewRemoteSigner(server.URL, key.Address())
+	require.Equal(t, key.Address(), signer.Address())
+
+	accounts, err := signer.Accounts()
+	require.Nil(t, err)
+	require.Equal(t, []string{key.Address()}, accounts)
+
+	transaction := T{
+		To:       "0x3535353535353535353535353535353535353535",
+		Gas:      21000,
+		GasPrice: big.NewInt(20000000000),
+		Nonce:    9,
+		ChainID:  big.NewInt(1),
+	}
+	signed, err := signer.SignTransaction(transaction)
+	require.Nil(t, err)
+	expected, err := key.SignTransaction(transaction)
+	require.Nil(t, err)
+	require.Equal(t, expected.Raw, signed.Raw)
+	require.Equal(t, expected.Tx.Hash, signed.Tx.Hash)
+
+	signature, err := signer.SignMessage([]byte("hello"))
+	require.Nil(t, err)
+	expectedSignature, err := key.SignMessage([]byte("hello"))
+	require.Nil(t, err)
+	require.Equal(t, expectedSignature, signature)
+
+	data := TypedData{}
+	require.Nil(t, json.Unmarshal([]byte(mailTypedData), &data))
+	signature, err = signer.SignTypedData(data)
+	require.Nil(t, err)
+	require.Equal(t, "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c", signature)
+}
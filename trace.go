@@ -0,0 +1,259 @@
+package ethrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"unsafe"
+)
+
+// Trace types for trace_call and trace_replay* methods
+const (
+	TraceTypeTrace     = "trace"
+	TraceTypeVMTrace   = "vmTrace"
+	TraceTypeStateDiff = "stateDiff"
+)
+
+// ActionTrace - trace_ namespace trace object
+type ActionTrace struct {
+	Action              TraceAction
+	Result              *TraceActionResult
+	Subtraces           int
+	TraceAddress        []int
+	Type                string
+	Error               string
+	BlockHash           string
+	BlockNumber         int
+	TransactionHash     string
+	TransactionPosition *int
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *ActionTrace) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyActionTrace)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return err
+	}
+
+	*t = *(*ActionTrace)(unsafe.Pointer(proxy))
+
+	return nil
+}
+
+// TraceAction - action of call, create, suicide or reward trace
+type TraceAction struct {
+	CallType       string
+	From           string
+	To             string
+	Gas            int
+	Input          string
+	Value          *big.Int
+	Init           string
+	CreationMethod string
+	Address        string
+	RefundAddress  string
+	Balance        *big.Int
+	Author         string
+	RewardType     string
+}
+
+// TraceActionResult - result of call or create trace
+type TraceActionResult struct {
+	GasUsed int
+	Output  string
+	Address string
+	Code    string
+}
+
+// TraceResults - result of trace_call and trace_replay* methods
+type TraceResults struct {
+	Output          string                 `json:"output"`
+	StateDiff       map[string]AccountDiff `json:"stateDiff"`
+	Trace           []ActionTrace          `json:"trace"`
+	VMTrace         *VMTrace               `json:"vmTrace"`
+	TransactionHash string                 `json:"transactionHash"`
+}
+
+// AccountDiff - account changes in state diff
+type AccountDiff struct {
+	Balance StateDiffValue            `json:"balance"`
+	Nonce   StateDiffValue            `json:"nonce"`
+	Code    StateDiffValue            `json:"code"`
+	Storage map[string]StateDiffValue `json:"storage"`
+}
+
+// StateDiffValue - value change in state diff.
+// Kind is "=" (unchanged), "+" (born), "-" (died) or "*" (changed)
+type StateDiffValue struct {
+	Kind string
+	From string
+	To   string
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (v *StateDiffValue) UnmarshalJSON(data []byte) error {
+	var kind string
+	if err := json.Unmarshal(data, &kind); err == nil {
+		*v = StateDiffValue{Kind: kind}
+		return nil
+	}
+
+	diff := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &diff); err != nil {
+		return err
+	}
+
+	if len(diff) != 1 {
+		return fmt.Errorf("invalid state diff value: %s", data)
+	}
+
+	for kind, value := range diff {
+		*v = StateDiffValue{Kind: kind}
+		switch kind {
+		case "+":
+			return json.Unmarshal(value, &v.To)
+		case "-":
+			return json.Unmarshal(value, &v.From)
+		case "*":
+			change := struct {
+				From string `json:"from"`
+				To   string `json:"to"`
+			}{}
+			if err := json.Unmarshal(value, &change); err != nil {
+				return err
+			}
+			v.From, v.To = change.From, change.To
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid state diff value: %s", data)
+}
+
+// VMTrace - virtual machine execution trace
+type VMTrace struct {
+	Code string        `json:"code"`
+	Ops  []VMOperation `json:"ops"`
+}
+
+// VMOperation - executed opcode
+type VMOperation struct {
+	Cost int                  `json:"cost"`
+	Ex   *VMExecutedOperation `json:"ex"`
+	PC   int                  `json:"pc"`
+	Sub  *VMTrace             `json:"sub"`
+}
+
+// VMExecutedOperation - effects of executed opcode
+type VMExecutedOperation struct {
+	Used  int            `json:"used"`
+	Push  []string       `json:"push"`
+	Mem   *VMMemoryDiff  `json:"mem"`
+	Store *VMStorageDiff `json:"store"`
+}
+
+// VMMemoryDiff - memory change
+type VMMemoryDiff struct {
+	Off  int    `json:"off"`
+	Data string `json:"data"`
+}
+
+// VMStorageDiff - storage change
+type VMStorageDiff struct {
+	Key string `json:"key"`
+	Val string `json:"val"`
+}
+
+// TraceFilterParams - trace_filter parameters object
+type TraceFilterParams struct {
+	FromBlock   string   `json:"fromBlock,omitempty"`
+	ToBlock     string   `json:"toBlock,omitempty"`
+	FromAddress []string `json:"fromAddress,omitempty"`
+	ToAddress   []string `json:"toAddress,omitempty"`
+	After       int      `json:"after,omitempty"`
+	Count       int      `json:"count,omitempty"`
+}
+
+type proxyActionTrace struct {
+	Action              proxyTraceAction        `json:"action"`
+	Result              *proxyTraceActionResult `json:"result"`
+	Subtraces           int                     `json:"subtraces"`
+	TraceAddress        []int                   `json:"traceAddress"`
+	Type                string                  `json:"type"`
+	Error               string                  `json:"error"`
+	BlockHash           string                  `json:"blockHash"`
+	BlockNumber         int                     `json:"blockNumber"`
+	TransactionHash     string                  `json:"transactionHash"`
+	TransactionPosition *int                    `json:"transactionPosition"`
+}
+
+type proxyTraceAction struct {
+	CallType       string  `json:"callType"`
+	From           string  `json:"from"`
+	To             string  `json:"to"`
+	Gas            hexInt  `json:"gas"`
+	Input          string  `json:"input"`
+	Value          *hexBig `json:"value"`
+	Init           string  `json:"init"`
+	CreationMethod string  `json:"creationMethod"`
+	Address        string  `json:"address"`
+	RefundAddress  string  `json:"refundAddress"`
+	Balance        *hexBig `json:"balance"`
+	Author         string  `json:"author"`
+	RewardType     string  `json:"rewardType"`
+}
+
+type proxyTraceActionResult struct {
+	GasUsed hexInt `json:"gasUsed"`
+	Output  string `json:"output"`
+	Address string `json:"address"`
+	Code    string `json:"code"`
+}
+
+// TraceBlock returns traces of all transactions in a block by block number.
+func (rpc *EthRPC) TraceBlock(number int) ([]ActionTrace, error) {
+	traces := []ActionTrace{}
+
+	err := rpc.call("trace_block", &traces, IntToHex(number))
+	return traces, err
+}
+
+// TraceTransaction returns all traces of a transaction.
+func (rpc *EthRPC) TraceTransaction(hash string) ([]ActionTrace, error) {
+	traces := []ActionTrace{}
+
+	err := rpc.call("trace_transaction", &traces, hash)
+	return traces, err
+}
+
+// TraceFilter returns traces matching a given filter.
+func (rpc *EthRPC) TraceFilter(params TraceFilterParams) ([]ActionTrace, error) {
+	traces := []ActionTrace{}
+
+	err := rpc.call("trace_filter", &traces, params)
+	return traces, err
+}
+
+// TraceCall executes a new message call and returns traces of requested types.
+func (rpc *EthRPC) TraceCall(transaction T, traceTypes []string, block string) (*TraceResults, error) {
+	results := new(TraceResults)
+
+	err := rpc.call("trace_call", results, transaction, traceTypes, block)
+	return results, err
+}
+
+// TraceReplayTransaction replays a transaction and returns traces of requested types.
+func (rpc *EthRPC) TraceReplayTransaction(hash string, traceTypes []string) (*TraceResults, error) {
+	results := new(TraceResults)
+
+	err := rpc.call("trace_replayTransaction", results, hash, traceTypes)
+	return results, err
+}
+
+// TraceReplayBlockTransactions replays all transactions in a block and returns traces of requested types.
+func (rpc *EthRPC) TraceReplayBlockTransactions(number int, traceTypes []string) ([]TraceResults, error) {
+	results := []TraceResults{}
+
+	err := rpc.call("trace_replayBlockTransactions", &results, IntToHex(number), traceTypes)
+	return results, err
+}
@@ -0,0 +1,218 @@
+package ethrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const traceBlockResult = `[
+	{
+		"action": {
+			"callType": "call",
+			"from": "0xa95350d70b18fa29f6b5eb8d627ceeeee499340d",
+			"to": "0x8d12a197cb00d4747a1fe03395095ce2a5cc6819",
+			"gas": "0x3d090",
+			"input": "0x",
+			"value": "0xde0b6b3a7640000"
+		},
+		"blockHash": "0x2bdda43f649c564642101fc990f569dd855e60f88bf83e931f509a92c62700f9",
+		"blockNumber": 4216277,
+		"result": {"gasUsed": "0x5208", "output": "0x"},
+		"subtraces": 1,
+		"traceAddress": [],
+		"transactionHash": "0xa72743a3608e2ae7b3d1cc1f0e3ceed9a1c78d803eba5f28d5d6908adfaa211c",
+		"transactionPosition": 0,
+		"type": "call"
+	},
+	{
+		"action": {
+			"from": "0x8d12a197cb00d4747a1fe03395095ce2a5cc6819",
+			"gas": "0x100",
+			"init": "0x6080",
+			"value": "0x0"
+		},
+		"blockHash": "0x2bdda43f649c564642101fc990f569dd855e60f88bf83e931f509a92c62700f9",
+		"blockNumber": 4216277,
+		"error": "Reverted",
+		"result": null,
+		"subtraces": 0,
+		"traceAddress": [0],
+		"transactionHash": "0xa72743a3608e2ae7b3d1cc1f0e3ceed9a1c78d803eba5f28d5d6908adfaa211c",
+		"transactionPosition": 0,
+		"type": "create"
+	},
+	{
+		"action": {
+			"author": "0x1e9939daaad6924ad004c2560e90804164900341",
+			"rewardType": "block",
+			"value": "0x1bc16d674ec80000"
+		},
+		"blockHash": "0x2bdda43f649c564642101fc990f569dd855e60f88bf83e931f509a92c62700f9",
+		"blockNumber": 4216277,
+		"result": null,
+		"subtraces": 0,
+		"traceAddress": [],
+		"transactionHash": null,
+		"transactionPosition": null,
+		"type": "reward"
+	}
+]`
+
+func (s *EthRPCTestSuite) TestTraceBlock() {
+	s.registerResponseError(errors.New("error"))
+	_, err := s.rpc.TraceBlock(4216277)
+	s.Require().NotNil(err)
+
+	s.registerResponse(traceBlockResult, func(body []byte) {
+		s.methodEqual(body, "trace_block")
+		s.paramsEqual(body, `["0x4055d5"]`)
+	})
+
+	traces, err := s.rpc.TraceBlock(4216277)
+	s.Require().Nil(err)
+	s.Require().Len(traces, 3)
+
+	call := traces[0]
+	s.Require().Equal("call", call.Type)
+	s.Require().Equal("call", call.Action.CallType)
+	s.Require().Equal("0xa95350d70b18fa29f6b5eb8d627ceeeee499340d", call.Action.From)
+	s.Require().Equal("0x8d12a197cb00d4747a1fe03395095ce2a5cc6819", call.Action.To)
+	s.Require().Equal(250000, call.Action.Gas)
+	s.Require().Equal(0, Eth1().Cmp(call.Action.Value))
+	s.Require().Equal(21000, call.Result.GasUsed)
+	s.Require().Equal(1, call.Subtraces)
+	s.Require().Equal([]int{}, call.TraceAddress)
+	s.Require().Equal(4216277, call.BlockNumber)
+	s.Require().Equal(0, *call.TransactionPosition)
+
+	create := traces[1]
+	s.Require().Equal("create", create.Type)
+	s.Require().Equal("0x6080", create.Action.Init)
+	s.Require().Equal("Reverted", create.Error)
+	s.Require().Nil(create.Result)
+	s.Require().Equal([]int{0}, create.TraceAddress)
+
+	reward := traces[2]
+	s.Require().Equal("reward", reward.Type)
+	s.Require().Equal("block", reward.Action.RewardType)
+	s.Require().Equal("0x1e9939daaad6924ad004c2560e90804164900341", reward.Action.Author)
+	s.Require().Nil(reward.TransactionPosition)
+}
+
+func (s *EthRPCTestSuite) TestTraceTransaction() {
+	hash := "0xa72743a3608e2ae7b3d1cc1f0e3ceed9a1c78d803eba5f28d5d6908adfaa211c"
+	s.registerResponse(traceBlockResult, func(body []byte) {
+		s.methodEqual(body, "trace_transaction")
+		s.paramsEqual(body, fmt.Sprintf(`["%s"]`, hash))
+	})
+
+	traces, err := s.rpc.TraceTransaction(hash)
+	s.Require().Nil(err)
+	s.Require().Len(traces, 3)
+}
+
+func (s *EthRPCTestSuite) TestTraceFilter() {
+	s.registerResponse(`[]`, func(body []byte) {
+		s.methodEqual(body, "trace_filter")
+		s.paramsEqual(body, `[{"fromBlock": "0x1", "toBlock": "latest", "toAddress": ["0x8d12a197cb00d4747a1fe03395095ce2a5cc6819"], "count": 10}]`)
+	})
+
+	traces, err := s.rpc.TraceFilter(TraceFilterParams{
+		FromBlock: "0x1",
+		ToBlock:   "latest",
+		ToAddress: []string{"0x8d12a197cb00d4747a1fe03395095ce2a5cc6819"},
+		Count:     10,
+	})
+	s.Require().Nil(err)
+	s.Require().Len(traces, 0)
+}
+
+func (s *EthRPCTestSuite) TestTraceCall() {
+	result := `{
+		"output": "0x01",
+		"stateDiff": {
+			"0xa95350d70b18fa29f6b5eb8d627ceeeee499340d": {
+				"balance": {"*": {"from": "0x10", "to": "0x8"}},
+				"code": "=",
+				"nonce": {"*": {"from": "0x5", "to": "0x6"}},
+				"storage": {
+					"0x01": {"+": "0x02"},
+					"0x03": {"-": "0x04"}
+				}
+			}
+		},
+		"trace": [],
+		"vmTrace": {
+			"code": "0x6001",
+			"ops": [{
+				"cost": 3,
+				"ex": {"mem": null, "push": ["0x1"], "store": {"key": "0x0", "val": "0x1"}, "used": 99997},
+				"pc": 0,
+				"sub": null
+			}]
+		}
+	}`
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "trace_call")
+		s.paramsEqual(body, `[{"to": "0x8d12a197cb00d4747a1fe03395095ce2a5cc6819", "data": "0x70a08231"}, ["trace", "vmTrace", "stateDiff"], "latest"]`)
+	})
+
+	results, err := s.rpc.TraceCall(T{
+		To:   "0x8d12a197cb00d4747a1fe03395095ce2a5cc6819",
+		Data: "0x70a08231",
+	}, []string{TraceTypeTrace, TraceTypeVMTrace, TraceTypeStateDiff}, "latest")
+	s.Require().Nil(err)
+	s.Require().Equal("0x01", results.Output)
+
+	diff := results.StateDiff["0xa95350d70b18fa29f6b5eb8d627ceeeee499340d"]
+	s.Require().Equal(StateDiffValue{Kind: "*", From: "0x10", To: "0x8"}, diff.Balance)
+	s.Require().Equal(StateDiffValue{Kind: "="}, diff.Code)
+	s.Require().Equal(StateDiffValue{Kind: "+", To: "0x02"}, diff.Storage["0x01"])
+	s.Require().Equal(StateDiffValue{Kind: "-", From: "0x04"}, diff.Storage["0x03"])
+
+	s.Require().Equal("0x6001", results.VMTrace.Code)
+	s.Require().Len(results.VMTrace.Ops, 1)
+	s.Require().Equal(3, results.VMTrace.Ops[0].Cost)
+	s.Require().Equal(99997, results.VMTrace.Ops[0].Ex.Used)
+	s.Require().Equal(&VMStorageDiff{Key: "0x0", Val: "0x1"}, results.VMTrace.Ops[0].Ex.Store)
+}
+
+func (s *EthRPCTestSuite) TestTraceReplayTransaction() {
+	hash := "0xa72743a3608e2ae7b3d1cc1f0e3ceed9a1c78d803eba5f28d5d6908adfaa211c"
+	s.registerResponse(fmt.Sprintf(`{"output": "0x", "trace": %s}`, traceBlockResult), func(body []byte) {
+		s.methodEqual(body, "trace_replayTransaction")
+		s.paramsEqual(body, fmt.Sprintf(`["%s", ["trace"]]`, hash))
+	})
+
+	results, err := s.rpc.TraceReplayTransaction(hash, []string{TraceTypeTrace})
+	s.Require().Nil(err)
+	s.Require().Len(results.Trace, 3)
+	s.Require().Nil(results.VMTrace)
+}
+
+func (s *EthRPCTestSuite) TestTraceReplayBlockTransactions() {
+	s.registerResponse(`[{"output": "0x", "transactionHash": "0xa72743a3608e2ae7b3d1cc1f0e3ceed9a1c78d803eba5f28d5d6908adfaa211c", "stateDiff": {}}]`, func(body []byte) {
+		s.methodEqual(body, "trace_replayBlockTransactions")
+		s.paramsEqual(body, `["0x4055d5", ["stateDiff"]]`)
+	})
+
+	results, err := s.rpc.TraceReplayBlockTransactions(4216277, []string{TraceTypeStateDiff})
+	s.Require().Nil(err)
+	s.Require().Len(results, 1)
+	s.Require().Equal("0xa72743a3608e2ae7b3d1cc1f0e3ceed9a1c78d803eba5f28d5d6908adfaa211c", results[0].TransactionHash)
+}
+
+func TestStateDiffValueUnmarshal(t *testing.T) {
+	value := StateDiffValue{}
+	require.NotNil(t, json.Unmarshal([]byte(`1`), &value))
+	require.NotNil(t, json.Unmarshal([]byte(`{}`), &value))
+	require.NotNil(t, json.Unmarshal([]byte(`{"?": "0x1"}`), &value))
+	require.NotNil(t, json.Unmarshal([]byte(`{"*": "0x1"}`), &value))
+
+	require.Nil(t, json.Unmarshal([]byte(`"="`), &value))
+	require.Equal(t, StateDiffValue{Kind: "="}, value)
+}
@@ -0,0 +1,372 @@
+package ethrpc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/onrik/ethrpc/rlp"
+)
+
+// SigningHash returns the hash of transaction to be signed.
+// Legacy transactions without ChainID are hashed in pre EIP-155 format.
+func (t T) SigningHash() ([]byte, error) {
+	fields, err := t.rlpFields()
+	if err != nil {
+		return nil, err
+	}
+
+	txType := t.TxType()
+	if txType == LegacyTxType && t.ChainID != nil {
+		fields = append(fields, t.ChainID, uint64(0), uint64(0))
+	}
+
+	encoded, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if txType == LegacyTxType {
+		return Keccak256(encoded), nil
+	}
+
+	return Keccak256([]byte{byte(txType)}, encoded), nil
+}
+
+// rlpFields returns transaction fields without signature in RLP order of its type
+func (t T) rlpFields() ([]interface{}, error) {
+	to, err := hexToBytes(t.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to address: %w", err)
+	}
+	if len(to) != 0 && len(to) != 20 {
+		return nil, fmt.Errorf("invalid to address length %d", len(to))
+	}
+
+	data, err := hexToBytes(t.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data: %w", err)
+	}
+
+	nonce := uint64(t.Nonce)
+	gas := uint64(t.Gas)
+	txType := t.TxType()
+	if txType == LegacyTxType {
+		return []interface{}{nonce, t.GasPrice, gas, to, t.Value, data}, nil
+	}
+
+	if t.ChainID == nil {
+		return nil, fmt.Errorf("chain id is required for transaction type %d", txType)
+	}
+
+	accessList, err := t.rlpAccessList()
+	if err != nil {
+		return nil, err
+	}
+
+	switch txType {
+	case AccessListTxType:
+		return []interface{}{t.ChainID, nonce, t.GasPrice, gas, to, t.Value, data, accessList}, nil
+	case DynamicFeeTxType:
+		return []interface{}{t.ChainID, nonce, t.MaxPriorityFeePerGas, t.MaxFeePerGas, gas, to, t.Value, data, accessList}, nil
+	case BlobTxType:
+		if len(to) == 0 {
+			return nil, errors.New("blob transaction can't create contract")
+		}
+
+		hashes := make([]interface{}, len(t.BlobVersionedHashes))
+		for i, hash := range t.BlobVersionedHashes {
+			if hashes[i], err = hexToBytes(hash); err != nil {
+				return nil, fmt.Errorf("invalid blob versioned hash: %w", err)
+			}
+		}
+
+		return []interface{}{t.ChainID, nonce, t.MaxPriorityFeePerGas, t.MaxFeePerGas, gas, to, t.Value, data, accessList, t.MaxFeePerBlobGas, hashes}, nil
+	case SetCodeTxType:
+		if len(to) == 0 {
+			return nil, errors.New("set code transaction can't create contract")
+		}
+
+		authorizationList, err := t.rlpAuthorizationList()
+		if err != nil {
+			return nil, err
+		}
+
+		return []interface{}{t.ChainID, nonce, t.MaxPriorityFeePerGas, t.MaxFeePerGas, gas, to, t.Value, data, accessList, authorizationList}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported transaction type %d", txType)
+}
+
+func (t T) rlpAccessList() ([]interface{}, error) {
+	accessList := make([]interface{}, len(t.AccessList))
+	for i, tuple := range t.AccessList {
+		address, err := hexToBytes(tuple.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid access list address: %w", err)
+		}
+
+		keys := make([]interface{}, len(tuple.StorageKeys))
+		for j, key := range tuple.StorageKeys {
+			if keys[j], err = hexToBytes(key); err != nil {
+				return nil, fmt.Errorf("invalid access list storage key: %w", err)
+			}
+		}
+
+		accessList[i] = []interface{}{address, keys}
+	}
+
+	return accessList, nil
+}
+
+func (t T) rlpAuthorizationList() ([]interface{}, error) {
+	authorizationList := make([]interface{}, len(t.AuthorizationList))
+	for i, authorization := range t.AuthorizationList {
+		address, err := hexToBytes(authorization.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid authorization address: %w", err)
+		}
+
+		authorizationList[i] = []interface{}{
+			authorization.ChainID,
+			address,
+			uint64(authorization.Nonce),
+			uint64(authorization.YParity),
+			authorization.R,
+			authorization.S,
+		}
+	}
+
+	return authorizationList, nil
+}
+
+// encodeSigned returns canonical encoding of transaction with signature values
+func (t T) encodeSigned(v, r, s *big.Int) ([]byte, error) {
+	fields, err := t.rlpFields()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := rlp.EncodeToBytes(append(fields, v, r, s))
+	if err != nil {
+		return nil, err
+	}
+
+	if txType := t.TxType(); txType != LegacyTxType {
+		raw = append([]byte{byte(txType)}, raw...)
+	}
+
+	return raw, nil
+}
+
+// SignTransaction signs transaction with the private key and returns raw transaction to use with EthSendRawTransaction.
+// Blob transactions are encoded in canonical form without blobs sidecar.
+func (key *PrivateKey) SignTransaction(transaction T) (*SignedTransaction, error) {
+	hash, err := transaction.SigningHash()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := key.SignHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction.withSignature(signature)
+}
+
+// withSignature returns signed transaction from 65 bytes [R || S || V] signature where V is 0 or 1
+func (t T) withSignature(signature []byte) (*SignedTransaction, error) {
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:64])
+	v := new(big.Int).SetUint64(uint64(signature[64]))
+
+	if t.TxType() == LegacyTxType {
+		if t.ChainID != nil {
+			// v = recovery id + chain id * 2 + 35
+			v.Add(v, new(big.Int).Lsh(t.ChainID, 1))
+			v.Add(v, big.NewInt(35))
+		} else {
+			v.Add(v, big.NewInt(27))
+		}
+	}
+
+	raw, err := t.encodeSigned(v, r, s)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := t.sender(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := &SignedTransaction{
+		Raw: fmt.Sprintf("0x%x", raw),
+		Tx: Transaction{
+			Hash:  fmt.Sprintf("0x%x", Keccak256(raw)),
+			Nonce: t.Nonce,
+			From:  from,
+			To:    t.To,
+			Gas:   t.Gas,
+			Input: t.Data,
+
+			Type:                 t.TxType(),
+			ChainID:              t.ChainID,
+			MaxFeePerGas:         t.MaxFeePerGas,
+			MaxPriorityFeePerGas: t.MaxPriorityFeePerGas,
+			AccessList:           t.AccessList,
+			MaxFeePerBlobGas:     t.MaxFeePerBlobGas,
+			BlobVersionedHashes:  t.BlobVersionedHashes,
+			AuthorizationList:    t.AuthorizationList,
+			V:                    v,
+			R:                    r,
+			S:                    s,
+		},
+	}
+	if t.Value != nil {
+		signed.Tx.Value.Set(t.Value)
+	}
+	if t.GasPrice != nil {
+		signed.Tx.GasPrice.Set(t.GasPrice)
+	}
+
+	return signed, nil
+}
+
+// MarshalBinary returns canonical encoding of the signed transaction as used in transactions trie and eth_sendRawTransaction.
+// Transaction must contain signature values.
+func (t Transaction) MarshalBinary() ([]byte, error) {
+	if t.V == nil || t.R == nil || t.S == nil {
+		return nil, fmt.Errorf("transaction %s has no signature values", t.Hash)
+	}
+
+	return t.toT().encodeSigned(t.V, t.R, t.S)
+}
+
+func (t Transaction) toT() T {
+	value := t.Value
+	gasPrice := t.GasPrice
+
+	return T{
+		To:                   t.To,
+		Gas:                  t.Gas,
+		GasPrice:             &gasPrice,
+		Value:                &value,
+		Data:                 t.Input,
+		Nonce:                t.Nonce,
+		Type:                 t.Type,
+		ChainID:              t.ChainID,
+		MaxFeePerGas:         t.MaxFeePerGas,
+		MaxPriorityFeePerGas: t.MaxPriorityFeePerGas,
+		AccessList:           t.AccessList,
+		MaxFeePerBlobGas:     t.MaxFeePerBlobGas,
+		BlobVersionedHashes:  t.BlobVersionedHashes,
+		AuthorizationList:    t.AuthorizationList,
+	}
+}
+
+func (t T) sender(signature []byte) (string, error) {
+	hash, err := t.SigningHash()
+	if err != nil {
+		return "", err
+	}
+
+	return RecoverAddress(hash, signature)
+}
+
+// PrepareTransaction fills missing chain id, nonce, fees and gas limit of transaction using the node.
+// EIP-1559 fees are used unless GasPrice is set, transaction has access list type or the node doesn't support them.
+// Zero Nonce is treated as missing and replaced with the pending nonce of the sender, so a transaction
+// with nonce 0 (replacement of the first pending transaction for example) should be signed with Signer.SignTransaction directly.
+func (rpc *EthRPC) PrepareTransaction(transaction T) (T, error) {
+	if transaction.ChainID == nil {
+		chainID, err := rpc.EthChainID()
+		if err != nil {
+			return transaction, err
+		}
+		transaction.ChainID = big.NewInt(int64(chainID))
+	}
+
+	if transaction.Nonce == 0 {
+		nonce, err := rpc.EthGetTransactionCount(transaction.From, "pending")
+		if err != nil {
+			return transaction, err
+		}
+		transaction.Nonce = nonce
+	}
+
+	if transaction.GasPrice == nil && transaction.TxType() == AccessListTxType {
+		// Access list transaction has legacy gas price
+		gasPrice, err := rpc.EthGasPrice()
+		if err != nil {
+			return transaction, err
+		}
+		transaction.GasPrice = &gasPrice
+	} else if transaction.GasPrice == nil {
+		if err := rpc.fillFees(&transaction); err != nil {
+			return transaction, err
+		}
+	}
+
+	if transaction.TxType() == BlobTxType && transaction.MaxFeePerBlobGas == nil {
+		return transaction, errors.New("max fee per blob gas is required for blob transaction")
+	}
+
+	if transaction.Gas == 0 {
+		gas, err := rpc.EthEstimateGas(transaction)
+		if err != nil {
+			return transaction, err
+		}
+		transaction.Gas = gas
+	}
+
+	return transaction, nil
+}
+
+func (rpc *EthRPC) fillFees(transaction *T) error {
+	gasPrice, err := rpc.EthGasPrice()
+	if err != nil {
+		return err
+	}
+
+	if transaction.MaxPriorityFeePerGas == nil {
+		tip, err := rpc.EthMaxPriorityFeePerGas()
+		if errors.Is(err, ErrMethodNotFound) && transaction.TxType() == LegacyTxType {
+			// Node doesn't support EIP-1559, other errors are returned to not downgrade transaction silently
+			transaction.GasPrice = &gasPrice
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		transaction.MaxPriorityFeePerGas = &tip
+	}
+
+	if transaction.MaxFeePerGas == nil {
+		// gas price suggestion includes base fee, doubling it leaves room for base fee growth
+		maxFee := new(big.Int).Lsh(&gasPrice, 1)
+		if maxFee.Cmp(transaction.MaxPriorityFeePerGas) < 0 {
+			maxFee.Set(transaction.MaxPriorityFeePerGas)
+		}
+		transaction.MaxFeePerGas = maxFee
+	}
+
+	return nil
+}
+
+// SignAndSendTransaction fills missing transaction fields like PrepareTransaction, signs transaction with the signer and broadcasts it.
+func (rpc *EthRPC) SignAndSendTransaction(signer Signer, transaction T) (string, error) {
+	transaction.From = signer.Address()
+	transaction, err := rpc.PrepareTransaction(transaction)
+	if err != nil {
+		return "", err
+	}
+
+	signed, err := signer.SignTransaction(transaction)
+	if err != nil {
+		return "", err
+	}
+
+	return rpc.EthSendRawTransaction(signed.Raw)
+}
@@ -0,0 +1,235 @@
+package ethrpc
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignLegacyTransaction(t *testing.T) {
+	key, err := NewPrivateKey("0x4646464646464646464646464646464646464646464646464646464646464646")
+	require.Nil(t, err)
+
+	// EIP-155 example
+	transaction := T{
+		Nonce:    9,
+		GasPrice: big.NewInt(20000000000),
+		Gas:      21000,
+		To:       "0x3535353535353535353535353535353535353535",
+		Value:    Eth1(),
+		ChainID:  big.NewInt(1),
+	}
+	hash, err := transaction.SigningHash()
+	require.Nil(t, err)
+	require.Equal(t, "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53", hex.EncodeToString(hash))
+
+	signed, err := key.SignTransaction(transaction)
+	require.Nil(t, err)
+	require.Equal(t, "0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83", signed.Raw)
+	require.Equal(t, "0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788", signed.Tx.Hash)
+	require.Equal(t, "0x9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f", signed.Tx.From)
+	require.Equal(t, 9, signed.Tx.Nonce)
+	require.Equal(t, 0, Eth1().Cmp(&signed.Tx.Value))
+	require.Equal(t, int64(20000000000), signed.Tx.GasPrice.Int64())
+
+	encoded, err := signed.Tx.MarshalBinary()
+	require.Nil(t, err)
+	require.Equal(t, signed.Raw, "0x"+hex.EncodeToString(encoded))
+
+	// Pre EIP-155 transaction has v = 27 or 28
+	transaction.ChainID = nil
+	signed, err = key.SignTransaction(transaction)
+	require.Nil(t, err)
+	raw, _ := hexToBytes(signed.Raw)
+	require.Contains(t, []byte{0x1b, 0x1c}, raw[len(raw)-67])
+}
+
+func TestSignTypedTransactions(t *testing.T) {
+	key, err := NewPrivateKey("0x4646464646464646464646464646464646464646464646464646464646464646")
+	require.Nil(t, err)
+
+	accessList := []AccessTuple{{
+		Address:     "0x3535353535353535353535353535353535353535",
+		StorageKeys: []string{"0x0000000000000000000000000000000000000000000000000000000000000001"},
+	}}
+	transactions := map[int]T{
+		AccessListTxType: {
+			ChainID:    big.NewInt(1),
+			Nonce:      1,
+			GasPrice:   big.NewInt(20000000000),
+			Gas:        30000,
+			To:         "0x3535353535353535353535353535353535353535",
+			AccessList: accessList,
+		},
+		DynamicFeeTxType: {
+			ChainID:              big.NewInt(1),
+			MaxPriorityFeePerGas: big.NewInt(1000000000),
+			MaxFeePerGas:         big.NewInt(30000000000),
+			Gas:                  60000,
+			Data:                 "0x6080",
+		},
+		BlobTxType: {
+			ChainID:              big.NewInt(1),
+			MaxPriorityFeePerGas: big.NewInt(1000000000),
+			MaxFeePerGas:         big.NewInt(30000000000),
+			Gas:                  21000,
+			To:                   "0x3535353535353535353535353535353535353535",
+			AccessList:           accessList,
+			MaxFeePerBlobGas:     big.NewInt(1),
+			BlobVersionedHashes:  []string{"0x01a915e4d060149eb4365960e6a7a45f334393093061116b197e3240065ff2d8"},
+		},
+		SetCodeTxType: {
+			ChainID:              big.NewInt(1),
+			MaxPriorityFeePerGas: big.NewInt(1000000000),
+			MaxFeePerGas:         big.NewInt(30000000000),
+			Gas:                  50000,
+			To:                   "0x3535353535353535353535353535353535353535",
+			AuthorizationList: []Authorization{{
+				ChainID: big.NewInt(1),
+				Address: "0x3636363636363636363636363636363636363636",
+				Nonce:   2,
+				YParity: 1,
+				R:       big.NewInt(3),
+				S:       big.NewInt(4),
+			}},
+		},
+	}
+
+	for txType, transaction := range transactions {
+		require.Equal(t, txType, transaction.TxType())
+
+		signed, err := key.SignTransaction(transaction)
+		require.Nil(t, err)
+		require.Equal(t, key.Address(), signed.Tx.From)
+
+		raw, err := hexToBytes(signed.Raw)
+		require.Nil(t, err)
+		require.Equal(t, byte(txType), raw[0])
+		require.Equal(t, hex.EncodeToString(Keccak256(raw)), signed.Tx.Hash[2:])
+
+		// Returned transaction encodes back to the raw one
+		encoded, err := signed.Tx.MarshalBinary()
+		require.Nil(t, err)
+		require.Equal(t, raw, encoded)
+
+		// Signing is deterministic
+		again, err := key.SignTransaction(transaction)
+		require.Nil(t, err)
+		require.Equal(t, signed.Raw, again.Raw)
+	}
+
+	_, err = key.SignTransaction(T{MaxFeePerGas: big.NewInt(1)})
+	require.NotNil(t, err)
+	_, err = key.SignTransaction(T{ChainID: big.NewInt(1), MaxFeePerBlobGas: big.NewInt(1)})
+	require.NotNil(t, err)
+	_, err = key.SignTransaction(T{To: "0x01"})
+	require.NotNil(t, err)
+	_, err = key.SignTransaction(T{To: "zz"})
+	require.NotNil(t, err)
+	_, err = key.SignTransaction(T{Data: "zz"})
+	require.NotNil(t, err)
+	_, err = key.SignTransaction(T{ChainID: big.NewInt(1), AccessList: []AccessTuple{{Address: "zz"}}})
+	require.NotNil(t, err)
+	_, err = key.SignTransaction(T{ChainID: big.NewInt(1), AccessList: []AccessTuple{{StorageKeys: []string{"zz"}}}})
+	require.NotNil(t, err)
+	_, err = key.SignTransaction(T{ChainID: big.NewInt(1), To: "0x3535353535353535353535353535353535353535", BlobVersionedHashes: []string{"zz"}})
+	require.NotNil(t, err)
+	_, err = key.SignTransaction(T{ChainID: big.NewInt(1), Type: 5})
+	require.NotNil(t, err)
+	_, err = key.SignTransaction(T{ChainID: big.NewInt(1), AuthorizationList: []Authorization{}})
+	require.NotNil(t, err)
+}
+
+func TestTxType(t *testing.T) {
+	require.Equal(t, LegacyTxType, T{}.TxType())
+	require.Equal(t, AccessListTxType, T{AccessList: []AccessTuple{}}.TxType())
+	require.Equal(t, DynamicFeeTxType, T{MaxPriorityFeePerGas: big.NewInt(1)}.TxType())
+	require.Equal(t, BlobTxType, T{BlobVersionedHashes: []string{}}.TxType())
+	require.Equal(t, SetCodeTxType, T{AuthorizationList: []Authorization{}, BlobVersionedHashes: []string{}}.TxType())
+	require.Equal(t, DynamicFeeTxType, T{Type: DynamicFeeTxType}.TxType())
+}
+
+func (s *EthRPCTestSuite) TestPrepareTransaction() {
+	s.registerResponses(map[string]string{
+		"eth_chainId":              `"0x1"`,
+		"eth_getTransactionCount":  `"0x5"`,
+		"eth_gasPrice":             `"0x4a817c800"`,
+		"eth_maxPriorityFeePerGas": `"0x3b9aca00"`,
+		"eth_estimateGas":          `"0x5208"`,
+	})
+
+	transaction, err := s.rpc.PrepareTransaction(T{
+		From:  "0x9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f",
+		To:    "0x3535353535353535353535353535353535353535",
+		Value: Eth1(),
+	})
+	s.Require().Nil(err)
+	s.Require().Equal(int64(1), transaction.ChainID.Int64())
+	s.Require().Equal(5, transaction.Nonce)
+	s.Require().Equal(21000, transaction.Gas)
+	s.Require().Nil(transaction.GasPrice)
+	s.Require().Equal(int64(1000000000), transaction.MaxPriorityFeePerGas.Int64())
+	s.Require().Equal(int64(40000000000), transaction.MaxFeePerGas.Int64())
+	s.Require().Equal(DynamicFeeTxType, transaction.TxType())
+
+	// Legacy node without EIP-1559 support
+	s.registerResponses(map[string]string{
+		"eth_gasPrice":             `"0x4a817c800"`,
+		"eth_maxPriorityFeePerGas": `{"code": -32601, "message": "the method eth_maxPriorityFeePerGas does not exist/is not available"}`,
+	})
+	transaction, err = s.rpc.PrepareTransaction(T{ChainID: big.NewInt(1), Nonce: 1, Gas: 21000})
+	s.Require().Nil(err)
+	s.Require().Equal(int64(20000000000), transaction.GasPrice.Int64())
+	s.Require().Equal(LegacyTxType, transaction.TxType())
+
+	// Other errors don't fall back to legacy transaction
+	s.registerResponses(map[string]string{
+		"eth_gasPrice":             `"0x4a817c800"`,
+		"eth_maxPriorityFeePerGas": `{"code": -32005, "message": "rate limit exceeded"}`,
+	})
+	_, err = s.rpc.PrepareTransaction(T{ChainID: big.NewInt(1), Nonce: 1, Gas: 21000})
+	s.Require().True(errors.Is(err, ErrRateLimited))
+
+	// Access list transaction gets legacy gas price
+	s.registerResponses(map[string]string{
+		"eth_gasPrice": `"0x4a817c800"`,
+	})
+	transaction, err = s.rpc.PrepareTransaction(T{ChainID: big.NewInt(1), Nonce: 1, Gas: 21000, AccessList: []AccessTuple{}})
+	s.Require().Nil(err)
+	s.Require().Equal(int64(20000000000), transaction.GasPrice.Int64())
+	s.Require().Nil(transaction.MaxFeePerGas)
+	s.Require().Equal(AccessListTxType, transaction.TxType())
+
+	// Blob transaction requires blob fee
+	_, err = s.rpc.PrepareTransaction(T{ChainID: big.NewInt(1), Nonce: 1, GasPrice: big.NewInt(1), BlobVersionedHashes: []string{}})
+	s.Require().NotNil(err)
+
+	s.registerResponses(map[string]string{
+		"eth_chainId": `{"code": -32000, "message": "error"}`,
+	})
+	_, err = s.rpc.PrepareTransaction(T{})
+	s.Require().NotNil(err)
+}
+
+func (s *EthRPCTestSuite) TestSignAndSendTransaction() {
+	key, err := NewPrivateKey("0x4646464646464646464646464646464646464646464646464646464646464646")
+	s.Require().Nil(err)
+
+	s.registerResponses(map[string]string{
+		"eth_getTransactionCount": `"0x9"`,
+		"eth_sendRawTransaction":  `"0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788"`,
+	})
+
+	hash, err := s.rpc.SignAndSendTransaction(key, T{
+		ChainID:  big.NewInt(1),
+		GasPrice: big.NewInt(20000000000),
+		Gas:      21000,
+		To:       "0x3535353535353535353535353535353535353535",
+		Value:    Eth1(),
+	})
+	s.Require().Nil(err)
+	s.Require().Equal("0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788", hash)
+}
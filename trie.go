@@ -0,0 +1,271 @@
+package ethrpc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onrik/ethrpc/rlp"
+)
+
+var (
+	// ErrTransactionsRootMismatch - returned when block transactions don't match the transactions root of the header
+	ErrTransactionsRootMismatch = errors.New("transactions root mismatch")
+	// ErrReceiptsRootMismatch - returned when block receipts don't match the receipts root of the header
+	ErrReceiptsRootMismatch = errors.New("receipts root mismatch")
+)
+
+// TransactionsRoot returns root hash of the transactions trie
+func TransactionsRoot(transactions []Transaction) (string, error) {
+	values := make([][]byte, len(transactions))
+	for i, transaction := range transactions {
+		encoded, err := transaction.MarshalBinary()
+		if err != nil {
+			return "", err
+		}
+		values[i] = encoded
+	}
+
+	return indexTrieRoot(values)
+}
+
+// ReceiptsRoot returns root hash of the receipts trie, receipts must be in the block order
+func ReceiptsRoot(receipts []TransactionReceipt) (string, error) {
+	values := make([][]byte, len(receipts))
+	for i, receipt := range receipts {
+		encoded, err := receipt.MarshalBinary()
+		if err != nil {
+			return "", err
+		}
+		values[i] = encoded
+	}
+
+	return indexTrieRoot(values)
+}
+
+// VerifyTransactionsRoot checks that the block transactions match the transactions root of the header.
+// Block must be requested with full transactions.
+func (b Block) VerifyTransactionsRoot() error {
+	root, err := TransactionsRoot(b.Transactions)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(root, b.TransactionsRoot) {
+		return fmt.Errorf("%w: block %d has transactions root %s, computed %s", ErrTransactionsRootMismatch, b.Number, b.TransactionsRoot, root)
+	}
+
+	return nil
+}
+
+// VerifyReceiptsRoot checks that the receipts of block transactions match the receipts root of the header
+func (b Block) VerifyReceiptsRoot(receipts []TransactionReceipt) error {
+	root, err := ReceiptsRoot(receipts)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(root, b.ReceiptsRoot) {
+		return fmt.Errorf("%w: block %d has receipts root %s, computed %s", ErrReceiptsRootMismatch, b.Number, b.ReceiptsRoot, root)
+	}
+
+	return nil
+}
+
+// MarshalBinary returns consensus encoding of the receipt as used in receipts trie
+func (t TransactionReceipt) MarshalBinary() ([]byte, error) {
+	var statusOrRoot []byte
+	if t.Root != "" {
+		// pre Byzantium receipts contain state root instead of status
+		root, err := hexToBytes(t.Root)
+		if err != nil {
+			return nil, fmt.Errorf("invalid receipt root: %w", err)
+		}
+		statusOrRoot = root
+	} else {
+		status, err := ParseInt(t.Status)
+		if err != nil {
+			return nil, fmt.Errorf("invalid receipt status: %w", err)
+		}
+		if status == 1 {
+			statusOrRoot = []byte{0x01}
+		}
+	}
+
+	bloom, err := hexToBytes(t.LogsBloom)
+	if err != nil {
+		return nil, fmt.Errorf("invalid receipt logs bloom: %w", err)
+	}
+
+	logs := make([]interface{}, len(t.Logs))
+	for i, log := range t.Logs {
+		address, err := hexToBytes(log.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log address: %w", err)
+		}
+
+		topics := make([]interface{}, len(log.Topics))
+		for j, topic := range log.Topics {
+			if topics[j], err = hexToBytes(topic); err != nil {
+				return nil, fmt.Errorf("invalid log topic: %w", err)
+			}
+		}
+
+		data, err := hexToBytes(log.Data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log data: %w", err)
+		}
+
+		logs[i] = []interface{}{address, topics, data}
+	}
+
+	encoded, err := rlp.EncodeToBytes([]interface{}{statusOrRoot, uint64(t.CumulativeGasUsed), bloom, logs})
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Type != LegacyTxType {
+		encoded = append([]byte{byte(t.Type)}, encoded...)
+	}
+
+	return encoded, nil
+}
+
+// indexTrieRoot returns hex encoded root hash of Merkle Patricia Trie with RLP encoded indexes as keys
+func indexTrieRoot(values [][]byte) (string, error) {
+	items := make([]trieItem, len(values))
+	for i, value := range values {
+		key, err := rlp.EncodeToBytes(uint64(i))
+		if err != nil {
+			return "", err
+		}
+		items[i] = trieItem{key: keyNibbles(key), value: value}
+	}
+
+	root, err := trieRoot(items)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("0x%x", root), nil
+}
+
+// trieItem - trie key as nibbles and its value
+type trieItem struct {
+	key   []byte
+	value []byte
+}
+
+// trieRoot returns root hash of Merkle Patricia Trie containing items with unique keys
+func trieRoot(items []trieItem) ([]byte, error) {
+	sort.Slice(items, func(i, j int) bool {
+		return bytes.Compare(items[i].key, items[j].key) < 0
+	})
+
+	node, err := trieNode(items, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return Keccak256(node), nil
+}
+
+// trieNode returns RLP encoded node of sorted items having common key prefix of depth nibbles
+func trieNode(items []trieItem, depth int) ([]byte, error) {
+	switch len(items) {
+	case 0:
+		return rlp.EncodeToBytes([]byte{})
+	case 1:
+		return rlp.EncodeToBytes([]interface{}{hexPrefix(items[0].key[depth:], true), items[0].value})
+	}
+
+	// extension node for the common prefix of all keys
+	prefix := commonPrefixLength(items[0].key[depth:], items[len(items)-1].key[depth:])
+	if prefix > 0 {
+		child, err := trieNode(items, depth+prefix)
+		if err != nil {
+			return nil, err
+		}
+		return rlp.EncodeToBytes([]interface{}{hexPrefix(items[0].key[depth:depth+prefix], false), trieReference(child)})
+	}
+
+	// branch node with 16 children and value of the key ending at depth
+	branch := make([]interface{}, 17)
+	branch[16] = []byte{}
+	if len(items[0].key) == depth {
+		branch[16] = items[0].value
+		items = items[1:]
+	}
+
+	for nibble := byte(0); nibble < 16; nibble++ {
+		end := 0
+		for end < len(items) && items[end].key[depth] == nibble {
+			end++
+		}
+
+		if end == 0 {
+			branch[nibble] = []byte{}
+			continue
+		}
+
+		child, err := trieNode(items[:end], depth+1)
+		if err != nil {
+			return nil, err
+		}
+		branch[nibble] = trieReference(child)
+		items = items[end:]
+	}
+
+	return rlp.EncodeToBytes(branch)
+}
+
+// trieReference returns node embedded into parent when it is shorter than 32 bytes and node hash otherwise
+func trieReference(node []byte) interface{} {
+	if len(node) < 32 {
+		return rlp.RawValue(node)
+	}
+
+	return Keccak256(node)
+}
+
+// hexPrefix returns compact encoding of nibbles with leaf flag
+func hexPrefix(nibbles []byte, leaf bool) []byte {
+	flag := byte(0)
+	if leaf {
+		flag = 2
+	}
+
+	encoded := make([]byte, len(nibbles)/2+1)
+	if len(nibbles)%2 == 1 {
+		encoded[0] = (flag+1)<<4 | nibbles[0]
+		nibbles = nibbles[1:]
+	} else {
+		encoded[0] = flag << 4
+	}
+	for i := 0; i < len(nibbles); i += 2 {
+		encoded[i/2+1] = nibbles[i]<<4 | nibbles[i+1]
+	}
+
+	return encoded
+}
+
+func keyNibbles(key []byte) []byte {
+	nibbles := make([]byte, len(key)*2)
+	for i, b := range key {
+		nibbles[i*2] = b >> 4
+		nibbles[i*2+1] = b & 0x0f
+	}
+
+	return nibbles
+}
+
+func commonPrefixLength(a, b []byte) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+
+	return i
+}
@@ -0,0 +1,320 @@
+package ethrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/onrik/ethrpc/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrieRoot(t *testing.T) {
+	tests := []struct {
+		items map[string]string
+		root  string
+	}{
+		{
+			items: map[string]string{},
+			root:  "56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+		},
+		{
+			items: map[string]string{"A": strings.Repeat("a", 50)},
+			root:  "d23786fb4a010da3ce639d66d5e904a11dbc02746d1ce25029e53290cabf28ab",
+		},
+		{
+			items: map[string]string{"doe": "reindeer", "dog": "puppy", "dogglesworth": "cat"},
+			root:  "8aad789dff2f538bca5d8ea56e8abe10f4c7ba3a5dea95fea4cd6e7c3a1168d3",
+		},
+		{
+			items: map[string]string{"do": "verb", "horse": "stallion", "doge": "coin", "dog": "puppy"},
+			root:  "5991bb8c6514148a29db676a14ac506cd2cd5775ace63c30a4fe457715e9ac84",
+		},
+	}
+
+	for _, test := range tests {
+		items := []trieItem{}
+		for key, value := range test.items {
+			items = append(items, trieItem{key: keyNibbles([]byte(key)), value: []byte(value)})
+		}
+
+		root, err := trieRoot(items)
+		require.Nil(t, err)
+		require.Equal(t, test.root, fmt.Sprintf("%x", root))
+	}
+}
+
+func TestHexPrefix(t *testing.T) {
+	require.Equal(t, []byte{0x11, 0x23, 0x45}, hexPrefix([]byte{1, 2, 3, 4, 5}, false))
+	require.Equal(t, []byte{0x00, 0x01, 0x23, 0x45}, hexPrefix([]byte{0, 1, 2, 3, 4, 5}, false))
+	require.Equal(t, []byte{0x20, 0x0f, 0x1c, 0xb8}, hexPrefix([]byte{0, 15, 1, 12, 11, 8}, true))
+	require.Equal(t, []byte{0x3f, 0x1c, 0xb8}, hexPrefix([]byte{15, 1, 12, 11, 8}, true))
+	require.Equal(t, []byte{0x20}, hexPrefix([]byte{}, true))
+}
+
+// first transaction on mainnet, block 46147
+func mainnetTransaction() Transaction {
+	r, _ := new(big.Int).SetString("88ff6cf0fefd94db46111149ae4bfc179e9b94721fffd821d38d16464b3f71d0", 16)
+	s, _ := new(big.Int).SetString("45e0aff800961cfce805daef7016b9b675c137a6a41a548f7b60a3484c06a33a", 16)
+
+	return Transaction{
+		Hash:     "0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060",
+		From:     "0xa1e4380a3b1f749673e270229993ee55f35663b4",
+		To:       "0x5df9b87991262f6ba471f09758cde1c0fc1de734",
+		Value:    *big.NewInt(31337),
+		Gas:      21000,
+		GasPrice: *big.NewInt(50000000000000),
+		Input:    "0x",
+		V:        big.NewInt(28),
+		R:        r,
+		S:        s,
+	}
+}
+
+// blockFixture - block with full transactions and its receipts in JSON-RPC format
+type blockFixture struct {
+	Block    proxyBlockWithTransactions `json:"block"`
+	Receipts []TransactionReceipt       `json:"receipts"`
+}
+
+// loadBlockFixtures loads blocks from testdata:
+// mainnet.json - mainnet block 18189758 with legacy and dynamic fee transactions,
+// pectra_devnet.json - Pectra devnet (chain id 7072151312) block 141654 with blob transactions,
+// sepolia.json - Sepolia blocks 175881 (failed dynamic fee transactions) and 176160 (legacy transactions),
+// hive.json - go-ethereum hive test chain blocks with transactions of every type, logs and failed transactions,
+// homestead.json - pre-Byzantium block generated by go-ethereum with state root in receipts.
+func loadBlockFixtures(t *testing.T, name string) []blockFixture {
+	data, err := os.ReadFile("testdata/" + name)
+	require.Nil(t, err)
+
+	fixtures := []blockFixture{}
+	require.Nil(t, json.Unmarshal(data, &fixtures))
+	require.NotEmpty(t, fixtures)
+
+	return fixtures
+}
+
+func TestTransactionsRoot(t *testing.T) {
+	transaction := mainnetTransaction()
+	raw, err := transaction.MarshalBinary()
+	require.Nil(t, err)
+	require.Equal(t, transaction.Hash, fmt.Sprintf("0x%x", Keccak256(raw)))
+
+	block := Block{
+		Number:           46147,
+		TransactionsRoot: "0x4513310fcb9f6f616972a3b948dc5d547f280849a87ebb5af0191f98b87be598",
+		Transactions:     []Transaction{transaction},
+	}
+	require.Nil(t, block.VerifyTransactionsRoot())
+
+	block.Transactions[0].Value = *big.NewInt(31338)
+	require.True(t, errors.Is(block.VerifyTransactionsRoot(), ErrTransactionsRootMismatch))
+
+	block.Transactions = nil
+	require.True(t, errors.Is(block.VerifyTransactionsRoot(), ErrTransactionsRootMismatch))
+
+	// Transactions without signature values, e.g. block requested without full transactions
+	block.Transactions = []Transaction{{Hash: transaction.Hash}}
+	require.NotNil(t, block.VerifyTransactionsRoot())
+
+	// Many transactions produce branch and extension nodes
+	transactions := make([]Transaction, 200)
+	for i := range transactions {
+		transactions[i] = mainnetTransaction()
+		transactions[i].Nonce = i
+	}
+	root, err := TransactionsRoot(transactions)
+	require.Nil(t, err)
+	transactions[0], transactions[1] = transactions[1], transactions[0]
+	swapped, err := TransactionsRoot(transactions)
+	require.Nil(t, err)
+	require.NotEqual(t, root, swapped)
+
+	// Real blocks with typed transactions
+	txTypes := map[int]bool{}
+	for _, name := range []string{"mainnet.json", "pectra_devnet.json", "sepolia.json", "hive.json", "homestead.json"} {
+		for _, fixture := range loadBlockFixtures(t, name) {
+			block := fixture.Block.toBlock()
+			require.Nil(t, block.VerifyTransactionsRoot(), "%s block %d", name, block.Number)
+
+			for _, transaction := range block.Transactions {
+				txTypes[transaction.Type] = true
+				raw, err := transaction.MarshalBinary()
+				require.Nil(t, err)
+				require.Equal(t, transaction.Hash, fmt.Sprintf("0x%x", Keccak256(raw)))
+			}
+		}
+	}
+	require.Equal(t, map[int]bool{
+		LegacyTxType:     true,
+		AccessListTxType: true,
+		DynamicFeeTxType: true,
+		BlobTxType:       true,
+		SetCodeTxType:    true,
+	}, txTypes)
+
+	block = loadBlockFixtures(t, "mainnet.json")[0].Block.toBlock()
+	block.Transactions[1], block.Transactions[2] = block.Transactions[2], block.Transactions[1]
+	require.True(t, errors.Is(block.VerifyTransactionsRoot(), ErrTransactionsRootMismatch))
+}
+
+func TestReceiptMarshalBinary(t *testing.T) {
+	receipt := TransactionReceipt{
+		CumulativeGasUsed: 21000,
+		LogsBloom:         emptyBloom,
+		Status:            "0x1",
+	}
+	encoded, err := receipt.MarshalBinary()
+	require.Nil(t, err)
+	require.Equal(t, "f9010801825208b90100"+strings.Repeat("00", 256)+"c0", fmt.Sprintf("%x", encoded))
+
+	receipt.Status = "0x0"
+	receipt.Type = DynamicFeeTxType
+	encoded, err = receipt.MarshalBinary()
+	require.Nil(t, err)
+	require.Equal(t, "02f9010880825208b90100"+strings.Repeat("00", 256)+"c0", fmt.Sprintf("%x", encoded))
+
+	receipt = TransactionReceipt{
+		CumulativeGasUsed: 50000,
+		LogsBloom:         emptyBloom,
+		Root:              "0x" + strings.Repeat("11", 32),
+		Logs: []Log{{
+			Address: "0x3535353535353535353535353535353535353535",
+			Topics:  []string{"0x" + strings.Repeat("22", 32)},
+			Data:    "0x0102",
+		}},
+	}
+	encoded, err = receipt.MarshalBinary()
+	require.Nil(t, err)
+
+	var decoded struct {
+		Root              []byte
+		CumulativeGasUsed uint64
+		Bloom             []byte
+		Logs              []struct {
+			Address []byte
+			Topics  [][]byte
+			Data    []byte
+		}
+	}
+	require.Nil(t, rlp.DecodeBytes(encoded, &decoded))
+	require.Equal(t, hexToBytesMust(receipt.Root), decoded.Root)
+	require.Equal(t, uint64(50000), decoded.CumulativeGasUsed)
+	require.Len(t, decoded.Bloom, 256)
+	require.Len(t, decoded.Logs, 1)
+	require.Equal(t, hexToBytesMust(receipt.Logs[0].Address), decoded.Logs[0].Address)
+	require.Equal(t, [][]byte{hexToBytesMust(receipt.Logs[0].Topics[0])}, decoded.Logs[0].Topics)
+	require.Equal(t, []byte{0x01, 0x02}, decoded.Logs[0].Data)
+
+	for _, receipt := range []TransactionReceipt{
+		{Status: "zz"},
+		{Status: "0x1", LogsBloom: "zz"},
+		{Root: "zz"},
+		{Status: "0x1", Logs: []Log{{Address: "zz"}}},
+		{Status: "0x1", Logs: []Log{{Topics: []string{"zz"}}}},
+		{Status: "0x1", Logs: []Log{{Data: "zz"}}},
+	} {
+		_, err := receipt.MarshalBinary()
+		require.NotNil(t, err)
+	}
+}
+
+func TestReceiptsRoot(t *testing.T) {
+	// Post-Byzantium receipts with status of every transaction type, pre-Byzantium receipts with state root
+	receiptTypes := map[int]bool{}
+	failed, withLogs, withRoot := 0, 0, 0
+	for _, name := range []string{"sepolia.json", "hive.json", "homestead.json"} {
+		for _, fixture := range loadBlockFixtures(t, name) {
+			block := fixture.Block.toBlock()
+			require.Len(t, fixture.Receipts, len(block.Transactions))
+			require.Nil(t, block.VerifyReceiptsRoot(fixture.Receipts), "%s block %d", name, block.Number)
+
+			for _, receipt := range fixture.Receipts {
+				receiptTypes[receipt.Type] = true
+				if receipt.Status == "0x0" {
+					failed++
+				}
+				if len(receipt.Logs) > 0 {
+					withLogs++
+				}
+				if receipt.Root != "" {
+					withRoot++
+				}
+			}
+		}
+	}
+	require.Len(t, receiptTypes, 5)
+	require.NotZero(t, failed)
+	require.NotZero(t, withLogs)
+	require.Equal(t, 3, withRoot)
+
+	fixture := loadBlockFixtures(t, "sepolia.json")[0]
+	block := fixture.Block.toBlock()
+	receipts := fixture.Receipts
+	require.True(t, errors.Is(block.VerifyReceiptsRoot(receipts[:2]), ErrReceiptsRootMismatch))
+
+	receipts[0].Status = "0x1"
+	require.True(t, errors.Is(block.VerifyReceiptsRoot(receipts), ErrReceiptsRootMismatch))
+
+	fixture = loadBlockFixtures(t, "homestead.json")[0]
+	block = fixture.Block.toBlock()
+	fixture.Receipts[1].Logs[0].Data = "0x"
+	require.True(t, errors.Is(block.VerifyReceiptsRoot(fixture.Receipts), ErrReceiptsRootMismatch))
+
+	require.NotNil(t, block.VerifyReceiptsRoot([]TransactionReceipt{{Status: "zz"}}))
+}
+
+func (s *EthRPCTestSuite) TestGetBlockVerifyTransactionsRoot() {
+	result := `{
+		"number": "0xb443",
+		"transactionsRoot": "0x4513310fcb9f6f616972a3b948dc5d547f280849a87ebb5af0191f98b87be598",
+		"transactions": [{
+			"hash": "0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060",
+			"nonce": "0x0",
+			"blockNumber": "0xb443",
+			"from": "0xa1e4380a3b1f749673e270229993ee55f35663b4",
+			"to": "0x5df9b87991262f6ba471f09758cde1c0fc1de734",
+			"value": "0x7a69",
+			"gas": "0x5208",
+			"gasPrice": "0x2d79883d2000",
+			"input": "0x",
+			"type": "0x0",
+			"v": "0x1c",
+			"r": "0x88ff6cf0fefd94db46111149ae4bfc179e9b94721fffd821d38d16464b3f71d0",
+			"s": "0x45e0aff800961cfce805daef7016b9b675c137a6a41a548f7b60a3484c06a33a"
+		}]
+	}`
+	s.registerResponse(result, func(body []byte) {})
+
+	block, err := s.rpc.EthGetBlockByNumber(46147, true)
+	s.Require().Nil(err)
+	s.Require().Equal(int64(28), block.Transactions[0].V.Int64())
+	s.Require().Nil(block.VerifyTransactionsRoot())
+
+	s.registerResponse(`{
+		"transactionHash": "0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060",
+		"cumulativeGasUsed": "0x5208",
+		"logsBloom": "`+emptyBloom+`",
+		"status": "0x1",
+		"type": "0x2"
+	}`, func(body []byte) {})
+
+	receipt, err := s.rpc.EthGetTransactionReceipt("0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060")
+	s.Require().Nil(err)
+	s.Require().Equal(DynamicFeeTxType, receipt.Type)
+	encoded, err := receipt.MarshalBinary()
+	s.Require().Nil(err)
+	s.Require().Equal(byte(DynamicFeeTxType), encoded[0])
+}
+
+func hexToBytesMust(value string) []byte {
+	data, err := hexToBytes(value)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
@@ -0,0 +1,79 @@
+package ethrpc
+
+import (
+	"encoding/json"
+	"unsafe"
+)
+
+// TxpoolContent - pending and queued transactions grouped by sender address and nonce
+type TxpoolContent struct {
+	Pending map[string]map[int]Transaction `json:"pending"`
+	Queued  map[string]map[int]Transaction `json:"queued"`
+}
+
+// TxpoolContentFrom - pending and queued transactions of a single sender grouped by nonce
+type TxpoolContentFrom struct {
+	Pending map[int]Transaction `json:"pending"`
+	Queued  map[int]Transaction `json:"queued"`
+}
+
+// TxpoolInspect - textual summaries of pending and queued transactions grouped by sender address and nonce
+type TxpoolInspect struct {
+	Pending map[string]map[int]string `json:"pending"`
+	Queued  map[string]map[int]string `json:"queued"`
+}
+
+// TxpoolStatus - number of pending and queued transactions
+type TxpoolStatus struct {
+	Pending int
+	Queued  int
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *TxpoolStatus) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyTxpoolStatus)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return err
+	}
+
+	*s = *(*TxpoolStatus)(unsafe.Pointer(proxy))
+
+	return nil
+}
+
+type proxyTxpoolStatus struct {
+	Pending hexInt `json:"pending"`
+	Queued  hexInt `json:"queued"`
+}
+
+// TxpoolContent returns all pending and queued transactions.
+func (rpc *EthRPC) TxpoolContent() (*TxpoolContent, error) {
+	content := new(TxpoolContent)
+
+	err := rpc.call("txpool_content", content)
+	return content, err
+}
+
+// TxpoolContentFrom returns pending and queued transactions of a given address.
+func (rpc *EthRPC) TxpoolContentFrom(address string) (*TxpoolContentFrom, error) {
+	content := new(TxpoolContentFrom)
+
+	err := rpc.call("txpool_contentFrom", content, address)
+	return content, err
+}
+
+// TxpoolInspect returns textual summaries of all pending and queued transactions.
+func (rpc *EthRPC) TxpoolInspect() (*TxpoolInspect, error) {
+	inspect := new(TxpoolInspect)
+
+	err := rpc.call("txpool_inspect", inspect)
+	return inspect, err
+}
+
+// TxpoolStatus returns the number of pending and queued transactions.
+func (rpc *EthRPC) TxpoolStatus() (*TxpoolStatus, error) {
+	status := new(TxpoolStatus)
+
+	err := rpc.call("txpool_status", status)
+	return status, err
+}
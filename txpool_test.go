@@ -0,0 +1,99 @@
+package ethrpc
+
+import (
+	"errors"
+	"fmt"
+)
+
+const txpoolTransaction = `{
+	"blockHash": null,
+	"blockNumber": null,
+	"from": "0x0216d5032f356960cd3749c31ab34eeff21b3395",
+	"gas": "0x5208",
+	"gasPrice": "0xba43b7400",
+	"hash": "0xaf953a2d01f55cfe080c0c94150a60105e8ac3d51153058a1f03dd239dd08586",
+	"input": "0x",
+	"nonce": "0x326",
+	"to": "0x7f69a91a3cf4be60020fb58b893b7cbb65376db8",
+	"transactionIndex": null,
+	"value": "0x19a99f0cf456000"
+}`
+
+func (s *EthRPCTestSuite) TestTxpoolContent() {
+	s.registerResponseError(errors.New("error"))
+	_, err := s.rpc.TxpoolContent()
+	s.Require().NotNil(err)
+
+	result := fmt.Sprintf(`{
+		"pending": {"0x0216d5032f356960cd3749c31ab34eeff21b3395": {"806": %s}},
+		"queued": {}
+	}`, txpoolTransaction)
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "txpool_content")
+		s.paramsEqual(body, "null")
+	})
+
+	content, err := s.rpc.TxpoolContent()
+	s.Require().Nil(err)
+	s.Require().Len(content.Pending, 1)
+	s.Require().Len(content.Queued, 0)
+
+	transaction := content.Pending["0x0216d5032f356960cd3749c31ab34eeff21b3395"][806]
+	s.Require().Equal("0xaf953a2d01f55cfe080c0c94150a60105e8ac3d51153058a1f03dd239dd08586", transaction.Hash)
+	s.Require().Equal(806, transaction.Nonce)
+	s.Require().Equal(21000, transaction.Gas)
+	s.Require().Nil(transaction.BlockNumber)
+}
+
+func (s *EthRPCTestSuite) TestTxpoolContentFrom() {
+	address := "0x0216d5032f356960cd3749c31ab34eeff21b3395"
+	result := fmt.Sprintf(`{"pending": {}, "queued": {"806": %s}}`, txpoolTransaction)
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "txpool_contentFrom")
+		s.paramsEqual(body, fmt.Sprintf(`["%s"]`, address))
+	})
+
+	content, err := s.rpc.TxpoolContentFrom(address)
+	s.Require().Nil(err)
+	s.Require().Len(content.Pending, 0)
+	s.Require().Equal("0x7f69a91a3cf4be60020fb58b893b7cbb65376db8", content.Queued[806].To)
+}
+
+func (s *EthRPCTestSuite) TestTxpoolInspect() {
+	result := `{
+		"pending": {
+			"0x26588a9301b0428d95e6fc3a5024fce8bec12d51": {
+				"31813": "0x3375ee30428b2a71c428afa5e89e427905f95f7e: 0 wei + 500000 × 20000000000 wei"
+			}
+		},
+		"queued": {
+			"0x0f6000de1578619320aba5e392706b131fb1de6f": {
+				"6": "0x8383534d0bcd0186d326c993031311c0ac0d9b2d: 9000000000000000000 wei + 21000 × 20000000000 wei"
+			}
+		}
+	}`
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "txpool_inspect")
+		s.paramsEqual(body, "null")
+	})
+
+	inspect, err := s.rpc.TxpoolInspect()
+	s.Require().Nil(err)
+	s.Require().Equal("0x3375ee30428b2a71c428afa5e89e427905f95f7e: 0 wei + 500000 × 20000000000 wei", inspect.Pending["0x26588a9301b0428d95e6fc3a5024fce8bec12d51"][31813])
+	s.Require().Equal("0x8383534d0bcd0186d326c993031311c0ac0d9b2d: 9000000000000000000 wei + 21000 × 20000000000 wei", inspect.Queued["0x0f6000de1578619320aba5e392706b131fb1de6f"][6])
+}
+
+func (s *EthRPCTestSuite) TestTxpoolStatus() {
+	s.registerResponse(`{"pending": "0xa", "queued": "0x7"}`, func(body []byte) {
+		s.methodEqual(body, "txpool_status")
+		s.paramsEqual(body, "null")
+	})
+
+	status, err := s.rpc.TxpoolStatus()
+	s.Require().Nil(err)
+	s.Require().Equal(&TxpoolStatus{Pending: 10, Queued: 7}, status)
+
+	s.registerResponse(`{"pending": "0xz"}`, func(body []byte) {})
+	_, err = s.rpc.TxpoolStatus()
+	s.Require().NotNil(err)
+}
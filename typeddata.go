@@ -0,0 +1,299 @@
+package ethrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	typedDataArrayRegexp   = regexp.MustCompile(`^(.+)\[(\d*)\]$`)
+	typedDataIntegerRegexp = regexp.MustCompile(`^(u?)int(\d*)$`)
+	typedDataBytesRegexp   = regexp.MustCompile(`^bytes(\d+)$`)
+)
+
+// Hash returns EIP-712 digest of typed data to sign: keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message))
+func (data TypedData) Hash() ([]byte, error) {
+	domainSeparator, err := data.hashStruct("EIP712Domain", data.domainMessage())
+	if err != nil {
+		return nil, fmt.Errorf("domain: %w", err)
+	}
+
+	if data.PrimaryType == "EIP712Domain" {
+		return Keccak256([]byte{0x19, 0x01}, domainSeparator), nil
+	}
+
+	messageHash, err := data.hashStruct(data.PrimaryType, data.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	return Keccak256([]byte{0x19, 0x01}, domainSeparator, messageHash), nil
+}
+
+func (data TypedData) domainMessage() map[string]interface{} {
+	domain := map[string]interface{}{}
+	if data.Domain.Name != "" {
+		domain["name"] = data.Domain.Name
+	}
+	if data.Domain.Version != "" {
+		domain["version"] = data.Domain.Version
+	}
+	if data.Domain.ChainID != nil {
+		domain["chainId"] = data.Domain.ChainID
+	}
+	if data.Domain.VerifyingContract != "" {
+		domain["verifyingContract"] = data.Domain.VerifyingContract
+	}
+	if data.Domain.Salt != "" {
+		domain["salt"] = data.Domain.Salt
+	}
+
+	return domain
+}
+
+func (data TypedData) fields(typeName string) ([]TypedDataField, bool) {
+	fields, ok := data.Types[typeName]
+	if ok || typeName != "EIP712Domain" {
+		return fields, ok
+	}
+
+	// Domain type is derived from the set domain fields if it is not declared
+	domain := data.domainMessage()
+	for _, field := range []TypedDataField{
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "address"},
+		{Name: "salt", Type: "bytes32"},
+	} {
+		if _, ok := domain[field.Name]; ok {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, true
+}
+
+// encodeType returns EIP-712 type encoding like "Mail(Person from,Person to,string contents)Person(string name,address wallet)"
+func (data TypedData) encodeType(typeName string) string {
+	deps := map[string]bool{}
+	data.dependencies(typeName, deps)
+	delete(deps, typeName)
+
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	builder := strings.Builder{}
+	for _, name := range append([]string{typeName}, names...) {
+		fields, _ := data.fields(name)
+		params := make([]string, len(fields))
+		for i, field := range fields {
+			params[i] = field.Type + " " + field.Name
+		}
+		builder.WriteString(name + "(" + strings.Join(params, ",") + ")")
+	}
+
+	return builder.String()
+}
+
+func (data TypedData) dependencies(typeName string, deps map[string]bool) {
+	if match := typedDataArrayRegexp.FindStringSubmatch(typeName); match != nil {
+		typeName = match[1]
+	}
+
+	fields, ok := data.fields(typeName)
+	if !ok || deps[typeName] {
+		return
+	}
+
+	deps[typeName] = true
+	for _, field := range fields {
+		data.dependencies(field.Type, deps)
+	}
+}
+
+func (data TypedData) hashStruct(typeName string, message map[string]interface{}) ([]byte, error) {
+	fields, ok := data.fields(typeName)
+	if !ok {
+		return nil, fmt.Errorf("unknown type %s", typeName)
+	}
+
+	encoded := Keccak256([]byte(data.encodeType(typeName)))
+	for _, field := range fields {
+		value, ok := message[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("missing value of field %s.%s", typeName, field.Name)
+		}
+
+		fieldEncoded, err := data.encodeValue(field.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("field %s.%s: %w", typeName, field.Name, err)
+		}
+		encoded = append(encoded, fieldEncoded...)
+	}
+
+	return Keccak256(encoded), nil
+}
+
+func (data TypedData) encodeValue(typeName string, value interface{}) ([]byte, error) {
+	if match := typedDataArrayRegexp.FindStringSubmatch(typeName); match != nil {
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid array value %v", value)
+		}
+		if match[2] != "" {
+			if size, _ := strconv.Atoi(match[2]); size != len(items) {
+				return nil, fmt.Errorf("invalid array length %d, expected %d", len(items), size)
+			}
+		}
+
+		encoded := []byte{}
+		for _, item := range items {
+			itemEncoded, err := data.encodeValue(match[1], item)
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, itemEncoded...)
+		}
+
+		return Keccak256(encoded), nil
+	}
+
+	if _, ok := data.Types[typeName]; ok {
+		message, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid struct value %v", value)
+		}
+
+		return data.hashStruct(typeName, message)
+	}
+
+	switch typeName {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid string value %v", value)
+		}
+		return Keccak256([]byte(s)), nil
+	case "bytes":
+		b, err := typedDataBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return Keccak256(b), nil
+	case "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("invalid bool value %v", value)
+		}
+		encoded := make([]byte, 32)
+		if b {
+			encoded[31] = 1
+		}
+		return encoded, nil
+	case "address":
+		b, err := typedDataBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != 20 {
+			return nil, fmt.Errorf("invalid address length %d", len(b))
+		}
+		return append(make([]byte, 12), b...), nil
+	}
+
+	if match := typedDataBytesRegexp.FindStringSubmatch(typeName); match != nil {
+		b, err := typedDataBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if size, _ := strconv.Atoi(match[1]); size < 1 || size > 32 || len(b) > size {
+			return nil, fmt.Errorf("invalid %s value length %d", typeName, len(b))
+		}
+		encoded := make([]byte, 32)
+		copy(encoded, b)
+		return encoded, nil
+	}
+
+	if match := typedDataIntegerRegexp.FindStringSubmatch(typeName); match != nil {
+		i, err := typedDataInteger(value)
+		if err != nil {
+			return nil, err
+		}
+
+		size := 256
+		if match[2] != "" {
+			size, _ = strconv.Atoi(match[2])
+		}
+		if match[1] == "u" {
+			if i.Sign() < 0 || i.BitLen() > size {
+				return nil, fmt.Errorf("value %s overflows %s", i, typeName)
+			}
+			return i.FillBytes(make([]byte, 32)), nil
+		}
+
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(size-1))
+		if i.Cmp(limit) >= 0 || i.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("value %s overflows %s", i, typeName)
+		}
+		// two's complement
+		if i.Sign() < 0 {
+			i.Add(i, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return i.FillBytes(make([]byte, 32)), nil
+	}
+
+	return nil, fmt.Errorf("unknown type %s", typeName)
+}
+
+func typedDataBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		if !strings.HasPrefix(v, "0x") {
+			return nil, fmt.Errorf("invalid hex value %s", v)
+		}
+		return hexToBytes(v)
+	}
+
+	return nil, fmt.Errorf("invalid bytes value %v", value)
+}
+
+func typedDataInteger(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		return new(big.Int).Set(v), nil
+	case big.Int:
+		return new(big.Int).Set(&v), nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case float64:
+		if v != float64(int64(v)) {
+			return nil, fmt.Errorf("invalid integer value %v", v)
+		}
+		return big.NewInt(int64(v)), nil
+	case json.Number:
+		return typedDataInteger(string(v))
+	case string:
+		i, ok := new(big.Int).SetString(v, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer value %s", v)
+		}
+		return i, nil
+	}
+
+	return nil, fmt.Errorf("invalid integer value %v", value)
+}
@@ -0,0 +1,119 @@
+package ethrpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// EIP-712 example
+const mailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func TestTypedDataHash(t *testing.T) {
+	data := TypedData{}
+	require.Nil(t, json.Unmarshal([]byte(mailTypedData), &data))
+
+	require.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)", data.encodeType("Mail"))
+
+	domainSeparator, err := data.hashStruct("EIP712Domain", data.domainMessage())
+	require.Nil(t, err)
+	require.Equal(t, "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", hex.EncodeToString(domainSeparator))
+
+	messageHash, err := data.hashStruct("Mail", data.Message)
+	require.Nil(t, err)
+	require.Equal(t, "c52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e", hex.EncodeToString(messageHash))
+
+	hash, err := data.Hash()
+	require.Nil(t, err)
+	require.Equal(t, "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToString(hash))
+
+	// Domain type is derived when it is not declared
+	delete(data.Types, "EIP712Domain")
+	hash, err = data.Hash()
+	require.Nil(t, err)
+	require.Equal(t, "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToString(hash))
+
+	data.Message["contents"] = 1
+	_, err = data.Hash()
+	require.NotNil(t, err)
+
+	delete(data.Message, "contents")
+	_, err = data.Hash()
+	require.NotNil(t, err)
+}
+
+func TestTypedDataEncodeValue(t *testing.T) {
+	data := TypedData{
+		Types: map[string][]TypedDataField{
+			"Item": {{Name: "id", Type: "uint8"}},
+		},
+	}
+
+	encoded, err := data.encodeValue("int8", big.NewInt(-1))
+	require.Nil(t, err)
+	require.Equal(t, "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", hex.EncodeToString(encoded))
+
+	encoded, err = data.encodeValue("bytes4", "0x01020304")
+	require.Nil(t, err)
+	require.Equal(t, "0102030400000000000000000000000000000000000000000000000000000000", hex.EncodeToString(encoded))
+
+	encoded, err = data.encodeValue("bool", true)
+	require.Nil(t, err)
+	require.Equal(t, "0000000000000000000000000000000000000000000000000000000000000001", hex.EncodeToString(encoded))
+
+	encoded, err = data.encodeValue("uint256[]", []interface{}{"0x1", float64(2)})
+	require.Nil(t, err)
+	require.Equal(t, Keccak256(big.NewInt(1).FillBytes(make([]byte, 32)), big.NewInt(2).FillBytes(make([]byte, 32))), encoded)
+
+	_, err = data.encodeValue("Item[1]", []interface{}{map[string]interface{}{"id": 1}})
+	require.Nil(t, err)
+
+	for typeName, value := range map[string]interface{}{
+		"uint8":    256,
+		"int8":     128,
+		"uint256":  -1,
+		"address":  "0x01",
+		"bytes2":   "0x010203",
+		"bytes":    "01",
+		"Item[2]":  []interface{}{map[string]interface{}{"id": 1}},
+		"Item":     "item",
+		"unknown":  1,
+		"string[]": "string",
+	} {
+		_, err := data.encodeValue(typeName, value)
+		require.NotNil(t, err, typeName)
+	}
+}
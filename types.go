@@ -28,6 +28,15 @@ func (s *Syncing) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Transaction types
+const (
+	LegacyTxType     = 0
+	AccessListTxType = 1
+	DynamicFeeTxType = 2
+	BlobTxType       = 3
+	SetCodeTxType    = 4
+)
+
 // T - input transaction object
 type T struct {
 	From     string
@@ -37,6 +46,15 @@ type T struct {
 	Value    *big.Int
 	Data     string
 	Nonce    int
+	// Type is detected from the set fields if zero
+	Type                 int
+	ChainID              *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	AccessList           []AccessTuple
+	MaxFeePerBlobGas     *big.Int
+	BlobVersionedHashes  []string
+	AuthorizationList    []Authorization
 }
 
 // MarshalJSON implements the json.Unmarshaler interface.
@@ -63,10 +81,114 @@ func (t T) MarshalJSON() ([]byte, error) {
 	if t.Nonce > 0 {
 		params["nonce"] = IntToHex(t.Nonce)
 	}
+	if t.Type > 0 {
+		params["type"] = IntToHex(t.Type)
+	}
+	if t.ChainID != nil {
+		params["chainId"] = BigToHex(*t.ChainID)
+	}
+	if t.MaxFeePerGas != nil {
+		params["maxFeePerGas"] = BigToHex(*t.MaxFeePerGas)
+	}
+	if t.MaxPriorityFeePerGas != nil {
+		params["maxPriorityFeePerGas"] = BigToHex(*t.MaxPriorityFeePerGas)
+	}
+	if t.AccessList != nil {
+		params["accessList"] = t.AccessList
+	}
+	if t.MaxFeePerBlobGas != nil {
+		params["maxFeePerBlobGas"] = BigToHex(*t.MaxFeePerBlobGas)
+	}
+	if t.BlobVersionedHashes != nil {
+		params["blobVersionedHashes"] = t.BlobVersionedHashes
+	}
+	if t.AuthorizationList != nil {
+		params["authorizationList"] = t.AuthorizationList
+	}
 
 	return json.Marshal(params)
 }
 
+// TxType returns the transaction type, detected from the set fields if Type is zero
+func (t T) TxType() int {
+	switch {
+	case t.Type > 0:
+		return t.Type
+	case t.AuthorizationList != nil:
+		return SetCodeTxType
+	case t.BlobVersionedHashes != nil || t.MaxFeePerBlobGas != nil:
+		return BlobTxType
+	case t.MaxFeePerGas != nil || t.MaxPriorityFeePerGas != nil:
+		return DynamicFeeTxType
+	case t.AccessList != nil:
+		return AccessListTxType
+	default:
+		return LegacyTxType
+	}
+}
+
+// AccessTuple - EIP-2930 access list entry
+type AccessTuple struct {
+	Address     string   `json:"address"`
+	StorageKeys []string `json:"storageKeys"`
+}
+
+// Authorization - EIP-7702 signed authorization to set code of the authority account
+type Authorization struct {
+	ChainID *big.Int
+	Address string
+	Nonce   int
+	YParity int
+	R       *big.Int
+	S       *big.Int
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (a Authorization) MarshalJSON() ([]byte, error) {
+	return json.Marshal((*proxyAuthorization)(unsafe.Pointer(&a)))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (a *Authorization) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyAuthorization)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return err
+	}
+
+	*a = *(*Authorization)(unsafe.Pointer(proxy))
+
+	return nil
+}
+
+// SignedTransaction - signed transaction object
+type SignedTransaction struct {
+	Raw string      `json:"raw"`
+	Tx  Transaction `json:"tx"`
+}
+
+// TypedData - EIP-712 typed structured data
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      TypedDataDomain             `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+// TypedDataField - EIP-712 struct member
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedDataDomain - EIP-712 domain separator fields
+type TypedDataDomain struct {
+	Name              string   `json:"name,omitempty"`
+	Version           string   `json:"version,omitempty"`
+	ChainID           *big.Int `json:"chainId,omitempty"`
+	VerifyingContract string   `json:"verifyingContract,omitempty"`
+	Salt              string   `json:"salt,omitempty"`
+}
+
 // Transaction - transaction object
 type Transaction struct {
 	Hash             string
@@ -80,6 +202,19 @@ type Transaction struct {
 	Gas              int
 	GasPrice         big.Int
 	Input            string
+	// Typed transactions fields
+	Type                 int
+	ChainID              *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	AccessList           []AccessTuple
+	MaxFeePerBlobGas     *big.Int
+	BlobVersionedHashes  []string
+	AuthorizationList    []Authorization
+	// Signature values, V is y parity for typed transactions
+	V *big.Int
+	R *big.Int
+	S *big.Int
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -140,6 +275,7 @@ type TransactionReceipt struct {
 	LogsBloom         string
 	Root              string
 	Status            string
+	Type              int
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -172,8 +308,46 @@ type Block struct {
 	GasLimit         int
 	GasUsed          int
 	Timestamp        int
-	Uncles           []string
-	Transactions     []Transaction
+	MixHash          string
+	ReceiptsRoot     string
+	// London
+	BaseFeePerGas *big.Int
+	// Shanghai
+	WithdrawalsRoot string
+	Withdrawals     []Withdrawal
+	// Cancun
+	BlobGasUsed           *int
+	ExcessBlobGas         *int
+	ParentBeaconBlockRoot string
+	// Prague
+	RequestsHash string
+	Uncles       []string
+	Transactions []Transaction
+}
+
+// Withdrawal - validator withdrawal object, amount is in Gwei
+type Withdrawal struct {
+	Index          int
+	ValidatorIndex int
+	Address        string
+	Amount         int
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (w Withdrawal) MarshalJSON() ([]byte, error) {
+	return json.Marshal((*proxyWithdrawal)(unsafe.Pointer(&w)))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (w *Withdrawal) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyWithdrawal)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return err
+	}
+
+	*w = *(*Withdrawal)(unsafe.Pointer(proxy))
+
+	return nil
 }
 
 type proxySyncing struct {
@@ -184,17 +358,28 @@ type proxySyncing struct {
 }
 
 type proxyTransaction struct {
-	Hash             string  `json:"hash"`
-	Nonce            hexInt  `json:"nonce"`
-	BlockHash        string  `json:"blockHash"`
-	BlockNumber      *hexInt `json:"blockNumber"`
-	TransactionIndex *hexInt `json:"transactionIndex"`
-	From             string  `json:"from"`
-	To               string  `json:"to"`
-	Value            hexBig  `json:"value"`
-	Gas              hexInt  `json:"gas"`
-	GasPrice         hexBig  `json:"gasPrice"`
-	Input            string  `json:"input"`
+	Hash                 string          `json:"hash"`
+	Nonce                hexInt          `json:"nonce"`
+	BlockHash            string          `json:"blockHash"`
+	BlockNumber          *hexInt         `json:"blockNumber"`
+	TransactionIndex     *hexInt         `json:"transactionIndex"`
+	From                 string          `json:"from"`
+	To                   string          `json:"to"`
+	Value                hexBig          `json:"value"`
+	Gas                  hexInt          `json:"gas"`
+	GasPrice             hexBig          `json:"gasPrice"`
+	Input                string          `json:"input"`
+	Type                 hexInt          `json:"type"`
+	ChainID              *hexBig         `json:"chainId"`
+	MaxFeePerGas         *hexBig         `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexBig         `json:"maxPriorityFeePerGas"`
+	AccessList           []AccessTuple   `json:"accessList"`
+	MaxFeePerBlobGas     *hexBig         `json:"maxFeePerBlobGas"`
+	BlobVersionedHashes  []string        `json:"blobVersionedHashes"`
+	AuthorizationList    []Authorization `json:"authorizationList"`
+	V                    *hexBig         `json:"v"`
+	R                    *hexBig         `json:"r"`
+	S                    *hexBig         `json:"s"`
 }
 
 type proxyLog struct {
@@ -221,10 +406,31 @@ type proxyTransactionReceipt struct {
 	LogsBloom         string `json:"logsBloom"`
 	Root              string `json:"root"`
 	Status            string `json:"status,omitempty"`
+	Type              hexInt `json:"type"`
+}
+
+type proxyWithdrawal struct {
+	Index          hexInt `json:"index"`
+	ValidatorIndex hexInt `json:"validatorIndex"`
+	Address        string `json:"address"`
+	Amount         hexInt `json:"amount"`
+}
+
+type proxyAuthorization struct {
+	ChainID *hexBig `json:"chainId"`
+	Address string  `json:"address"`
+	Nonce   hexInt  `json:"nonce"`
+	YParity hexInt  `json:"yParity"`
+	R       *hexBig `json:"r"`
+	S       *hexBig `json:"s"`
 }
 
 type hexInt int
 
+func (i hexInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(IntToHex(int(i)))
+}
+
 func (i *hexInt) UnmarshalJSON(data []byte) error {
 	result, err := ParseInt(string(bytes.Trim(data, `"`)))
 	*i = hexInt(result)
@@ -234,6 +440,10 @@ func (i *hexInt) UnmarshalJSON(data []byte) error {
 
 type hexBig big.Int
 
+func (i hexBig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(BigToHex(big.Int(i)))
+}
+
 func (i *hexBig) UnmarshalJSON(data []byte) error {
 	result, err := ParseBigInt(string(bytes.Trim(data, `"`)))
 	*i = hexBig(result)
@@ -246,24 +456,33 @@ type proxyBlock interface {
 }
 
 type proxyBlockWithTransactions struct {
-	Number           hexInt             `json:"number"`
-	Hash             string             `json:"hash"`
-	ParentHash       string             `json:"parentHash"`
-	Nonce            string             `json:"nonce"`
-	Sha3Uncles       string             `json:"sha3Uncles"`
-	LogsBloom        string             `json:"logsBloom"`
-	TransactionsRoot string             `json:"transactionsRoot"`
-	StateRoot        string             `json:"stateRoot"`
-	Miner            string             `json:"miner"`
-	Difficulty       hexBig             `json:"difficulty"`
-	TotalDifficulty  hexBig             `json:"totalDifficulty"`
-	ExtraData        string             `json:"extraData"`
-	Size             hexInt             `json:"size"`
-	GasLimit         hexInt             `json:"gasLimit"`
-	GasUsed          hexInt             `json:"gasUsed"`
-	Timestamp        hexInt             `json:"timestamp"`
-	Uncles           []string           `json:"uncles"`
-	Transactions     []proxyTransaction `json:"transactions"`
+	Number                hexInt             `json:"number"`
+	Hash                  string             `json:"hash"`
+	ParentHash            string             `json:"parentHash"`
+	Nonce                 string             `json:"nonce"`
+	Sha3Uncles            string             `json:"sha3Uncles"`
+	LogsBloom             string             `json:"logsBloom"`
+	TransactionsRoot      string             `json:"transactionsRoot"`
+	StateRoot             string             `json:"stateRoot"`
+	Miner                 string             `json:"miner"`
+	Difficulty            hexBig             `json:"difficulty"`
+	TotalDifficulty       hexBig             `json:"totalDifficulty"`
+	ExtraData             string             `json:"extraData"`
+	Size                  hexInt             `json:"size"`
+	GasLimit              hexInt             `json:"gasLimit"`
+	GasUsed               hexInt             `json:"gasUsed"`
+	Timestamp             hexInt             `json:"timestamp"`
+	MixHash               string             `json:"mixHash"`
+	ReceiptsRoot          string             `json:"receiptsRoot"`
+	BaseFeePerGas         *hexBig            `json:"baseFeePerGas"`
+	WithdrawalsRoot       string             `json:"withdrawalsRoot"`
+	Withdrawals           []Withdrawal       `json:"withdrawals"`
+	BlobGasUsed           *hexInt            `json:"blobGasUsed"`
+	ExcessBlobGas         *hexInt            `json:"excessBlobGas"`
+	ParentBeaconBlockRoot string             `json:"parentBeaconBlockRoot"`
+	RequestsHash          string             `json:"requestsHash"`
+	Uncles                []string           `json:"uncles"`
+	Transactions          []proxyTransaction `json:"transactions"`
 }
 
 func (proxy *proxyBlockWithTransactions) toBlock() Block {
@@ -271,45 +490,63 @@ func (proxy *proxyBlockWithTransactions) toBlock() Block {
 }
 
 type proxyBlockWithoutTransactions struct {
-	Number           hexInt   `json:"number"`
-	Hash             string   `json:"hash"`
-	ParentHash       string   `json:"parentHash"`
-	Nonce            string   `json:"nonce"`
-	Sha3Uncles       string   `json:"sha3Uncles"`
-	LogsBloom        string   `json:"logsBloom"`
-	TransactionsRoot string   `json:"transactionsRoot"`
-	StateRoot        string   `json:"stateRoot"`
-	Miner            string   `json:"miner"`
-	Difficulty       hexBig   `json:"difficulty"`
-	TotalDifficulty  hexBig   `json:"totalDifficulty"`
-	ExtraData        string   `json:"extraData"`
-	Size             hexInt   `json:"size"`
-	GasLimit         hexInt   `json:"gasLimit"`
-	GasUsed          hexInt   `json:"gasUsed"`
-	Timestamp        hexInt   `json:"timestamp"`
-	Uncles           []string `json:"uncles"`
-	Transactions     []string `json:"transactions"`
+	Number                hexInt       `json:"number"`
+	Hash                  string       `json:"hash"`
+	ParentHash            string       `json:"parentHash"`
+	Nonce                 string       `json:"nonce"`
+	Sha3Uncles            string       `json:"sha3Uncles"`
+	LogsBloom             string       `json:"logsBloom"`
+	TransactionsRoot      string       `json:"transactionsRoot"`
+	StateRoot             string       `json:"stateRoot"`
+	Miner                 string       `json:"miner"`
+	Difficulty            hexBig       `json:"difficulty"`
+	TotalDifficulty       hexBig       `json:"totalDifficulty"`
+	ExtraData             string       `json:"extraData"`
+	Size                  hexInt       `json:"size"`
+	GasLimit              hexInt       `json:"gasLimit"`
+	GasUsed               hexInt       `json:"gasUsed"`
+	Timestamp             hexInt       `json:"timestamp"`
+	MixHash               string       `json:"mixHash"`
+	ReceiptsRoot          string       `json:"receiptsRoot"`
+	BaseFeePerGas         *hexBig      `json:"baseFeePerGas"`
+	WithdrawalsRoot       string       `json:"withdrawalsRoot"`
+	Withdrawals           []Withdrawal `json:"withdrawals"`
+	BlobGasUsed           *hexInt      `json:"blobGasUsed"`
+	ExcessBlobGas         *hexInt      `json:"excessBlobGas"`
+	ParentBeaconBlockRoot string       `json:"parentBeaconBlockRoot"`
+	RequestsHash          string       `json:"requestsHash"`
+	Uncles                []string     `json:"uncles"`
+	Transactions          []string     `json:"transactions"`
 }
 
 func (proxy *proxyBlockWithoutTransactions) toBlock() Block {
 	block := Block{
-		Number:           int(proxy.Number),
-		Hash:             proxy.Hash,
-		ParentHash:       proxy.ParentHash,
-		Nonce:            proxy.Nonce,
-		Sha3Uncles:       proxy.Sha3Uncles,
-		LogsBloom:        proxy.LogsBloom,
-		TransactionsRoot: proxy.TransactionsRoot,
-		StateRoot:        proxy.StateRoot,
-		Miner:            proxy.Miner,
-		Difficulty:       big.Int(proxy.Difficulty),
-		TotalDifficulty:  big.Int(proxy.TotalDifficulty),
-		ExtraData:        proxy.ExtraData,
-		Size:             int(proxy.Size),
-		GasLimit:         int(proxy.GasLimit),
-		GasUsed:          int(proxy.GasUsed),
-		Timestamp:        int(proxy.Timestamp),
-		Uncles:           proxy.Uncles,
+		Number:                int(proxy.Number),
+		Hash:                  proxy.Hash,
+		ParentHash:            proxy.ParentHash,
+		Nonce:                 proxy.Nonce,
+		Sha3Uncles:            proxy.Sha3Uncles,
+		LogsBloom:             proxy.LogsBloom,
+		TransactionsRoot:      proxy.TransactionsRoot,
+		StateRoot:             proxy.StateRoot,
+		Miner:                 proxy.Miner,
+		Difficulty:            big.Int(proxy.Difficulty),
+		TotalDifficulty:       big.Int(proxy.TotalDifficulty),
+		ExtraData:             proxy.ExtraData,
+		Size:                  int(proxy.Size),
+		GasLimit:              int(proxy.GasLimit),
+		GasUsed:               int(proxy.GasUsed),
+		Timestamp:             int(proxy.Timestamp),
+		MixHash:               proxy.MixHash,
+		ReceiptsRoot:          proxy.ReceiptsRoot,
+		BaseFeePerGas:         (*big.Int)(proxy.BaseFeePerGas),
+		WithdrawalsRoot:       proxy.WithdrawalsRoot,
+		Withdrawals:           proxy.Withdrawals,
+		BlobGasUsed:           (*int)(proxy.BlobGasUsed),
+		ExcessBlobGas:         (*int)(proxy.ExcessBlobGas),
+		ParentBeaconBlockRoot: proxy.ParentBeaconBlockRoot,
+		RequestsHash:          proxy.RequestsHash,
+		Uncles:                proxy.Uncles,
 	}
 
 	block.Transactions = make([]Transaction, len(proxy.Transactions))
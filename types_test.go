@@ -172,3 +172,27 @@ func TestTransactionReceiptUnmarshal(t *testing.T) {
 	require.Equal(t, 6, receipt.Logs[0].LogIndex)
 	require.Equal(t, false, receipt.Logs[0].Removed)
 }
+
+func TestTMarshal(t *testing.T) {
+	data, err := json.Marshal(T{
+		From:                 "0x9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f",
+		Type:                 BlobTxType,
+		ChainID:              big.NewInt(1),
+		MaxFeePerGas:         big.NewInt(30),
+		MaxPriorityFeePerGas: big.NewInt(2),
+		AccessList:           []AccessTuple{{Address: "0x3535353535353535353535353535353535353535", StorageKeys: []string{}}},
+		MaxFeePerBlobGas:     big.NewInt(1),
+		BlobVersionedHashes:  []string{"0x01"},
+	})
+	require.Nil(t, err)
+	require.JSONEq(t, `{
+		"from": "0x9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f",
+		"type": "0x3",
+		"chainId": "0x1",
+		"maxFeePerGas": "0x1e",
+		"maxPriorityFeePerGas": "0x2",
+		"accessList": [{"address": "0x3535353535353535353535353535353535353535", "storageKeys": []}],
+		"maxFeePerBlobGas": "0x1",
+		"blobVersionedHashes": ["0x01"]
+	}`, string(data))
+}